@@ -2,11 +2,17 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sbenjam1n/gamsync/internal/region"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +26,8 @@ var archSyncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Bidirectional sync between arch.md and PostgreSQL",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
 		if err != nil {
@@ -27,72 +35,130 @@ var archSyncCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		// Read arch.md regions
-		archPaths, err := region.ParseArchMd(projectRoot())
-		if err != nil {
-			return fmt.Errorf("parse arch.md: %w", err)
+		return runArchSync(ctx, pool, os.Stdout, projectRoot(), dryRun)
+	},
+}
+
+// archSyncPlan lists the paths arch sync adds in each direction.
+type archSyncPlan struct {
+	ToDB   []string // in arch.md, missing from the regions table
+	ToArch []string // in the regions table, missing from arch.md
+}
+
+// planArchSync computes the additions that reconcile arch.md and the DB,
+// each list sorted by path.
+func planArchSync(archPaths, dbPaths []string) archSyncPlan {
+	inArch := make(map[string]bool)
+	for _, p := range archPaths {
+		inArch[p] = true
+	}
+	inDB := make(map[string]bool)
+	for _, p := range dbPaths {
+		inDB[p] = true
+	}
+
+	var plan archSyncPlan
+	for _, p := range archPaths {
+		if !inDB[p] {
+			plan.ToDB = append(plan.ToDB, p)
+			inDB[p] = true
 		}
+	}
+	for _, p := range dbPaths {
+		if !inArch[p] {
+			plan.ToArch = append(plan.ToArch, p)
+			inArch[p] = true
+		}
+	}
+	sort.Strings(plan.ToDB)
+	sort.Strings(plan.ToArch)
+	return plan
+}
 
-		// Read DB regions
-		rows, err := pool.Query(ctx, `SELECT path FROM regions ORDER BY path`)
-		if err != nil {
-			return err
+// regionPaths returns every region path in the database.
+func regionPaths(ctx context.Context, pool *pgxpool.Pool) ([]string, error) {
+	rows, err := pool.Query(ctx, `SELECT path::text FROM regions ORDER BY path`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
 		}
-		defer rows.Close()
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
 
-		dbPaths := make(map[string]bool)
-		for rows.Next() {
-			var path string
-			rows.Scan(&path)
-			dbPaths[path] = true
-		}
-
-		archPathMap := make(map[string]bool)
-		for _, p := range archPaths {
-			archPathMap[p] = true
-		}
-
-		// Add arch.md paths to DB
-		added := 0
-		for _, p := range archPaths {
-			if !dbPaths[p] {
-				_, err := pool.Exec(ctx, `
-					INSERT INTO regions (path, lifecycle_state) VALUES ($1, 'draft')
-					ON CONFLICT (path) DO NOTHING
-				`, p)
-				if err == nil {
-					added++
-					fmt.Printf("  DB <- arch.md: added %s\n", p)
-				}
-			}
+// runArchSync reconciles arch.md and the DB. With dryRun set it only prints
+// the planned additions.
+func runArchSync(ctx context.Context, pool *pgxpool.Pool, w io.Writer, root string, dryRun bool) error {
+	archPaths, err := region.ParseArchMd(root)
+	if err != nil {
+		return fmt.Errorf("parse arch.md: %w", err)
+	}
+	dbPaths, err := regionPaths(ctx, pool)
+	if err != nil {
+		return err
+	}
+	plan := planArchSync(archPaths, dbPaths)
+
+	if dryRun {
+		for _, p := range plan.ToDB {
+			fmt.Fprintf(w, "  DB <- arch.md: would add %s\n", p)
+		}
+		for _, p := range plan.ToArch {
+			fmt.Fprintf(w, "  arch.md <- DB: would add %s\n", p)
 		}
+		fmt.Fprintf(w, "\nDry run: %d to add to DB, %d to add to arch.md. No changes written.\n", len(plan.ToDB), len(plan.ToArch))
+		return nil
+	}
 
-		// Add DB paths to arch.md
-		var newArchPaths []string
-		for path := range dbPaths {
-			if !archPathMap[path] {
-				newArchPaths = append(newArchPaths, path)
-				fmt.Printf("  arch.md <- DB: adding %s\n", path)
-			}
+	added := insertDraftRegions(ctx, pool, plan.ToDB, func(p string) {
+		fmt.Fprintf(w, "  DB <- arch.md: added %s\n", p)
+	})
+
+	if len(plan.ToArch) > 0 {
+		archFile := filepath.Join(root, "arch.md")
+		data, err := os.ReadFile(archFile)
+		if err != nil {
+			return err
 		}
+		content := string(data)
+		for _, p := range plan.ToArch {
+			fmt.Fprintf(w, "  arch.md <- DB: adding %s\n", p)
+			content += fmt.Sprintf("# @region:%s\n# @endregion:%s\n", p, p)
+		}
+		if err := os.WriteFile(archFile, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
 
-		if len(newArchPaths) > 0 {
-			archFile := filepath.Join(projectRoot(), "arch.md")
-			data, err := os.ReadFile(archFile)
-			if err != nil {
-				return err
-			}
-			content := string(data)
-			for _, p := range newArchPaths {
-				marker := fmt.Sprintf("# @region:%s\n# @endregion:%s\n", p, p)
-				content += marker
+	fmt.Fprintf(w, "\nSync complete: %d added to DB, %d added to arch.md\n", added, len(plan.ToArch))
+	return nil
+}
+
+// insertDraftRegions registers paths as draft regions, calling added for each
+// one inserted, and returns how many were inserted.
+func insertDraftRegions(ctx context.Context, pool *pgxpool.Pool, paths []string, added func(string)) int {
+	n := 0
+	for _, p := range paths {
+		tag, err := pool.Exec(ctx, `
+			INSERT INTO regions (path, lifecycle_state) VALUES ($1, 'draft')
+			ON CONFLICT (path) DO NOTHING
+		`, p)
+		if err == nil && tag.RowsAffected() > 0 {
+			n++
+			if added != nil {
+				added(p)
 			}
-			os.WriteFile(archFile, []byte(content), 0644)
 		}
-
-		fmt.Printf("\nSync complete: %d added to DB, %d added to arch.md\n", added, len(newArchPaths))
-		return nil
-	},
+	}
+	return n
 }
 
 var archExportCmd = &cobra.Command{
@@ -141,8 +207,124 @@ var archExportCmd = &cobra.Command{
 
 var archImportCmd = &cobra.Command{
 	Use:   "import",
-	Short: "Import arch.md namespace tree to DB",
+	Short: "Import arch.md namespace tree and descriptions to DB",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		return runArchImport(ctx, pool, os.Stdout, projectRoot(), overwrite, dryRun)
+	},
+}
+
+// archImportPlan lists the arch.md entries import writes: regions missing
+// from the DB, and existing regions whose description it sets.
+type archImportPlan struct {
+	Insert   []region.ArchEntry
+	Describe []region.ArchEntry
+}
+
+// planArchImport compares arch.md entries with the DB's region descriptions
+// (keyed by path, present for every DB region). An existing non-empty
+// description is only replaced when overwrite is set.
+func planArchImport(entries []region.ArchEntry, existing map[string]string, overwrite bool) archImportPlan {
+	var plan archImportPlan
+	for _, e := range entries {
+		desc, ok := existing[e.Path]
+		switch {
+		case !ok:
+			plan.Insert = append(plan.Insert, e)
+		case e.Description != "" && e.Description != desc && (desc == "" || overwrite):
+			plan.Describe = append(plan.Describe, e)
+		}
+	}
+	return plan
+}
+
+// regionDescriptions returns every DB region's description keyed by path.
+func regionDescriptions(ctx context.Context, pool *pgxpool.Pool) (map[string]string, error) {
+	rows, err := pool.Query(ctx, `SELECT path::text, COALESCE(description, '') FROM regions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	descs := make(map[string]string)
+	for rows.Next() {
+		var path, desc string
+		if err := rows.Scan(&path, &desc); err != nil {
+			return nil, err
+		}
+		descs[path] = desc
+	}
+	return descs, rows.Err()
+}
+
+// runArchImport upserts arch.md regions with their descriptions. With dryRun
+// set it only prints the plan.
+func runArchImport(ctx context.Context, pool *pgxpool.Pool, w io.Writer, root string, overwrite, dryRun bool) error {
+	entries, err := region.ParseArchMdEntries(root)
+	if err != nil {
+		return fmt.Errorf("parse arch.md: %w", err)
+	}
+	existing, err := regionDescriptions(ctx, pool)
+	if err != nil {
+		return err
+	}
+	plan := planArchImport(entries, existing, overwrite)
+
+	if dryRun {
+		for _, e := range plan.Insert {
+			fmt.Fprintf(w, "  would import %s\n", e.Path)
+		}
+		for _, e := range plan.Describe {
+			fmt.Fprintf(w, "  would describe %s: %s\n", e.Path, e.Description)
+		}
+		fmt.Fprintf(w, "Dry run: %d region(s) to import, %d description(s) to set. No changes written.\n", len(plan.Insert), len(plan.Describe))
+		return nil
+	}
+
+	imported, described := 0, 0
+	for _, e := range append(plan.Insert, plan.Describe...) {
+		var inserted bool
+		err := pool.QueryRow(ctx, `
+			INSERT INTO regions (path, lifecycle_state, description) VALUES ($1, 'draft', NULLIF($2, ''))
+			ON CONFLICT (path) DO UPDATE SET description = EXCLUDED.description
+			WHERE EXCLUDED.description IS NOT NULL
+			  AND EXCLUDED.description IS DISTINCT FROM regions.description
+			  AND ($3 OR COALESCE(regions.description, '') = '')
+			RETURNING xmax = 0
+		`, e.Path, e.Description, overwrite).Scan(&inserted)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("import %s: %w", e.Path, err)
+		}
+		if inserted {
+			imported++
+		} else {
+			described++
+		}
+	}
+
+	fmt.Fprintf(w, "Imported %d regions from arch.md, updated %d description(s).\n", imported, described)
+	return nil
+}
+
+var archDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show drift between arch.md, source markers, and the database",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		all, _ := cmd.Flags().GetBool("all")
+
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
 		if err != nil {
@@ -150,29 +332,131 @@ var archImportCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		archPaths, err := region.ParseArchMd(projectRoot())
+		root := projectRoot()
+		archPaths, err := region.ParseArchMd(root)
 		if err != nil {
 			return fmt.Errorf("parse arch.md: %w", err)
 		}
 
-		imported := 0
-		for _, p := range archPaths {
-			_, err := pool.Exec(ctx, `
-				INSERT INTO regions (path, lifecycle_state) VALUES ($1, 'draft')
-				ON CONFLICT (path) DO NOTHING
-			`, p)
-			if err == nil {
-				imported++
+		markers, _, _ := region.ScanDirectory(root, region.ParseGamignore(root))
+		var sourcePaths []string
+		for _, m := range markers {
+			sourcePaths = append(sourcePaths, m.Path)
+		}
+
+		rows, err := pool.Query(ctx, `SELECT path FROM regions ORDER BY path`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var dbPaths []string
+		for rows.Next() {
+			var path string
+			rows.Scan(&path)
+			dbPaths = append(dbPaths, path)
+		}
+
+		entries := region.DiffArch(archPaths, sourcePaths, dbPaths)
+		drifted := 0
+		var shown []region.ArchDiffEntry
+		for _, e := range entries {
+			if e.Drifted() {
+				drifted++
+			}
+			if all || e.Drifted() {
+				shown = append(shown, e)
 			}
 		}
 
-		fmt.Printf("Imported %d regions from arch.md.\n", imported)
+		if asJSON {
+			if shown == nil {
+				shown = []region.ArchDiffEntry{}
+			}
+			out, _ := json.MarshalIndent(shown, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if drifted == 0 && !all {
+			fmt.Printf("No drift: %d paths agree across arch.md, source, and DB.\n", len(entries))
+			return nil
+		}
+
+		mark := func(b bool) string {
+			if b {
+				return "yes"
+			}
+			return "-"
+		}
+		fmt.Printf("%-40s %-8s %-8s %-8s\n", "PATH", "ARCH.MD", "SOURCE", "DB")
+		for _, e := range shown {
+			flag := ""
+			if e.Drifted() {
+				flag = "  <- drift"
+			}
+			fmt.Printf("%-40s %-8s %-8s %-8s%s\n", e.Path, mark(e.InArch), mark(e.InSource), mark(e.InDB), flag)
+		}
+		fmt.Printf("\n%d of %d path(s) drifted.\n", drifted, len(entries))
 		return nil
 	},
 }
 
+var archLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check arch.md namespace depth, fan-out, and required roots",
+	Long: `Check arch.md namespace tree shape against configured limits:
+
+  GAM_ARCH_MAX_DEPTH       Maximum path segments (e.g. 4)
+  GAM_ARCH_MAX_CHILDREN    Maximum direct children per namespace
+  GAM_ARCH_REQUIRED_ROOTS  Comma-separated top-level namespaces that must exist
+
+Flags override the environment. Parent-existence is checked as well.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := region.ShapeOpts{
+			MaxDepth:      cfg.ArchMaxDepth,
+			MaxChildren:   cfg.ArchMaxChildren,
+			RequiredRoots: cfg.ArchRequiredRoots,
+		}
+		if cmd.Flags().Changed("max-depth") {
+			opts.MaxDepth, _ = cmd.Flags().GetInt("max-depth")
+		}
+		if cmd.Flags().Changed("max-children") {
+			opts.MaxChildren, _ = cmd.Flags().GetInt("max-children")
+		}
+		if cmd.Flags().Changed("roots") {
+			opts.RequiredRoots, _ = cmd.Flags().GetStringSlice("roots")
+		}
+
+		root := projectRoot()
+		issues := region.ValidateArchNamespaces(root)
+		issues = append(issues, region.ValidateArchShape(root, opts)...)
+
+		if len(issues) == 0 {
+			fmt.Println("arch.md lint passed.")
+			return nil
+		}
+		for _, issue := range issues {
+			fmt.Printf("  %s\n", issue)
+		}
+		return fmt.Errorf("%d arch.md lint issue(s)", len(issues))
+	},
+}
+
 func init() {
+	archSyncCmd.Flags().Bool("dry-run", false, "Print the planned additions in both directions without writing")
+	archImportCmd.Flags().Bool("dry-run", false, "Print the regions and descriptions that would be written without writing")
+	archImportCmd.Flags().Bool("overwrite", false, "Replace non-empty DB descriptions with the arch.md description")
+	archDiffCmd.Flags().Bool("json", false, "Output as JSON")
+	archDiffCmd.Flags().Bool("all", false, "Include paths present in all three sources")
+
+	archLintCmd.Flags().Int("max-depth", 0, "Maximum namespace depth (0 = unlimited)")
+	archLintCmd.Flags().Int("max-children", 0, "Maximum children per namespace (0 = unlimited)")
+	archLintCmd.Flags().StringSlice("roots", nil, "Required top-level namespaces")
+
 	archCmd.AddCommand(archSyncCmd)
 	archCmd.AddCommand(archExportCmd)
 	archCmd.AddCommand(archImportCmd)
+	archCmd.AddCommand(archLintCmd)
+	archCmd.AddCommand(archDiffCmd)
 }
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestPlanArchSync(t *testing.T) {
+	plan := planArchSync(
+		[]string{"app.search", "app.auth", "app.search"},
+		[]string{"app.search", "app.billing", "app.admin"},
+	)
+	if !reflect.DeepEqual(plan.ToDB, []string{"app.auth"}) {
+		t.Errorf("ToDB = %v", plan.ToDB)
+	}
+	if !reflect.DeepEqual(plan.ToArch, []string{"app.admin", "app.billing"}) {
+		t.Errorf("ToArch = %v", plan.ToArch)
+	}
+}
+
+func TestArchSyncDryRunWritesNothing(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	root := t.TempDir()
+	arch := "# @region:archdry.fromarch\n# @endregion:archdry.fromarch\n"
+	archFile := filepath.Join(root, "arch.md")
+	os.WriteFile(archFile, []byte(arch), 0644)
+	pool.Exec(ctx, `INSERT INTO regions (path) VALUES ('archdry.fromdb') ON CONFLICT (path) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'archdry'`)
+
+	var buf bytes.Buffer
+	if err := runArchSync(ctx, pool, &buf, root, true); err != nil {
+		t.Fatalf("runArchSync: %v", err)
+	}
+	for _, want := range []string{"DB <- arch.md: would add archdry.fromarch", "arch.md <- DB: would add archdry.fromdb", "No changes written"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("dry run output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	if data, _ := os.ReadFile(archFile); string(data) != arch {
+		t.Errorf("arch.md changed under --dry-run:\n%s", data)
+	}
+	var n int
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM regions WHERE path = 'archdry.fromarch'`).Scan(&n)
+	if n != 0 {
+		t.Error("region inserted under --dry-run")
+	}
+}
+
+func TestPlanArchImport(t *testing.T) {
+	entries := []region.ArchEntry{
+		{Path: "app.new", Description: "New region"},
+		{Path: "app.blank", Description: "Fill me"},
+		{Path: "app.kept", Description: "From arch.md"},
+		{Path: "app.same", Description: "Same"},
+	}
+	existing := map[string]string{"app.blank": "", "app.kept": "Curated in DB", "app.same": "Same"}
+
+	paths := func(es []region.ArchEntry) []string {
+		var out []string
+		for _, e := range es {
+			out = append(out, e.Path)
+		}
+		return out
+	}
+
+	plan := planArchImport(entries, existing, false)
+	if !reflect.DeepEqual(paths(plan.Insert), []string{"app.new"}) || !reflect.DeepEqual(paths(plan.Describe), []string{"app.blank"}) {
+		t.Errorf("plan = %+v", plan)
+	}
+	plan = planArchImport(entries, existing, true)
+	if !reflect.DeepEqual(paths(plan.Describe), []string{"app.blank", "app.kept"}) {
+		t.Errorf("overwrite plan = %+v", plan)
+	}
+}
+
+func TestArchImportSetsDescription(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "arch.md"), []byte(
+		"# @region:archdesc.search Search Source Implementations\n# @endregion:archdesc.search\n"+
+			"# @region:archdesc.curated Arch wording\n# @endregion:archdesc.curated\n"), 0644)
+	pool.Exec(ctx, `INSERT INTO regions (path, description) VALUES ('archdesc.curated', 'Curated') ON CONFLICT (path) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'archdesc'`)
+
+	desc := func(path string) string {
+		var d *string
+		pool.QueryRow(ctx, `SELECT description FROM regions WHERE path = $1`, path).Scan(&d)
+		if d == nil {
+			return ""
+		}
+		return *d
+	}
+
+	var buf bytes.Buffer
+	if err := runArchImport(ctx, pool, &buf, root, false, false); err != nil {
+		t.Fatalf("runArchImport: %v", err)
+	}
+	if got := desc("archdesc.search"); got != "Search Source Implementations" {
+		t.Errorf("imported description = %q", got)
+	}
+	if got := desc("archdesc.curated"); got != "Curated" {
+		t.Errorf("curated description clobbered without --overwrite: %q", got)
+	}
+
+	if err := runArchImport(ctx, pool, &buf, root, true, false); err != nil {
+		t.Fatalf("runArchImport --overwrite: %v", err)
+	}
+	if got := desc("archdesc.curated"); got != "Arch wording" {
+		t.Errorf("description after --overwrite = %q", got)
+	}
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export database state to a portable JSON bundle",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, _ := cmd.Flags().GetString("out")
+		if out == "" {
+			return fmt.Errorf("--out is required")
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		bundle, err := memorizer.ExportBundle(ctx, pool)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode bundle: %w", err)
+		}
+		if err := os.WriteFile(out, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("write bundle: %w", err)
+		}
+
+		fmt.Printf("Exported bundle v%d to %s: %d concepts, %d syncs, %d regions, %d plans (%d plan turns), %d grades, %d principles\n",
+			bundle.Version, out, len(bundle.Concepts), len(bundle.Syncs), len(bundle.Regions),
+			len(bundle.Plans), len(bundle.PlanTurns), len(bundle.QualityGrades), len(bundle.Principles))
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import [bundle]",
+	Short: "Import a JSON bundle produced by gam export",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("read bundle: %w", err)
+		}
+		bundle, err := memorizer.ReadBundle(data)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if err := memorizer.ImportBundle(ctx, pool, bundle); err != nil {
+			return fmt.Errorf("import bundle: %w", err)
+		}
+
+		fmt.Printf("Imported bundle v%d: %d concepts, %d syncs, %d regions, %d plans (%d plan turns), %d grades, %d principles\n",
+			bundle.Version, len(bundle.Concepts), len(bundle.Syncs), len(bundle.Regions),
+			len(bundle.Plans), len(bundle.PlanTurns), len(bundle.QualityGrades), len(bundle.Principles))
+		return nil
+	},
+}
+
+var exportOwnersCmd = &cobra.Command{
+	Use:   "owners",
+	Short: "Export region owners as a CODEOWNERS-style file",
+	Long: `Map every source file containing region markers to the owners of its
+regions, inheriting owners from ancestor regions. Output goes to stdout
+unless --out is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, _ := cmd.Flags().GetString("out")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		owners, err := memorizer.RegionOwners(ctx, pool)
+		if err != nil {
+			return err
+		}
+
+		root := projectRoot()
+		markers, _, err := region.ScanDirectory(root, region.ParseGamignore(root))
+		if err != nil {
+			return fmt.Errorf("scan regions: %w", err)
+		}
+
+		entries := region.CodeOwners(root, markers, owners)
+		content := region.FormatCodeOwners(entries)
+		if out == "" {
+			fmt.Print(content)
+			return nil
+		}
+		if err := os.WriteFile(out, []byte(content), 0644); err != nil {
+			return fmt.Errorf("write owners: %w", err)
+		}
+		fmt.Printf("Wrote %d owner mapping(s) to %s\n", len(entries), out)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("out", "", "Output bundle file")
+	exportOwnersCmd.Flags().String("out", "", "Output file (default stdout)")
+	exportCmd.AddCommand(exportOwnersCmd)
+}
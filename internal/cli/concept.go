@@ -3,11 +3,20 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/config"
 	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/region"
 	"github.com/spf13/cobra"
 )
 
@@ -32,21 +41,15 @@ var conceptAddCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		var concept gam.Concept
-		concept.Name = name
-
+		concept := gam.Concept{Name: name}
 		if specFile != "" {
 			data, err := os.ReadFile(specFile)
 			if err != nil {
 				return fmt.Errorf("read spec file: %w", err)
 			}
-
-			// Try parsing as full concept JSON
-			if err := json.Unmarshal(data, &concept); err != nil {
-				// Try parsing as just ConceptSpec
-				if err := json.Unmarshal(data, &concept.Spec); err != nil {
-					return fmt.Errorf("parse spec file: %w (expected JSON with concept spec fields)", err)
-				}
+			concept, err = memorizer.ParseConcept(data, name)
+			if err != nil {
+				return fmt.Errorf("parse spec file: %w", err)
 			}
 		}
 
@@ -58,17 +61,7 @@ var conceptAddCmd = &cobra.Command{
 			return fmt.Errorf("--purpose is required when not provided in spec file")
 		}
 
-		specJSON, _ := json.Marshal(concept.Spec)
-		smJSON, _ := json.Marshal(concept.StateMachine)
-		invJSON, _ := json.Marshal(concept.Invariants)
-
-		_, err = pool.Exec(ctx, `
-			INSERT INTO concepts (name, purpose, spec, state_machine, invariants)
-			VALUES ($1, $2, $3, $4, $5)
-			ON CONFLICT (name) DO UPDATE
-			SET purpose = $2, spec = $3, state_machine = $4, invariants = $5, updated_at = NOW()
-		`, concept.Name, concept.Purpose, specJSON, smJSON, invJSON)
-		if err != nil {
+		if err := memorizer.UpsertConcept(ctx, pool, concept); err != nil {
 			return fmt.Errorf("insert concept: %w", err)
 		}
 
@@ -77,6 +70,479 @@ var conceptAddCmd = &cobra.Command{
 	},
 }
 
+var conceptDiffCmd = &cobra.Command{
+	Use:   "diff [name]",
+	Short: "Compare a stored concept to a candidate spec file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		against, _ := cmd.Flags().GetString("against")
+		if against == "" {
+			return fmt.Errorf("--against is required")
+		}
+
+		data, err := os.ReadFile(against)
+		if err != nil {
+			return fmt.Errorf("read spec file: %w", err)
+		}
+		candidate, err := memorizer.ParseConcept(data, name)
+		if err != nil {
+			return fmt.Errorf("parse spec file: %w", err)
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		stored, err := memorizer.LoadConcept(ctx, pool, name)
+		if err != nil {
+			return err
+		}
+		actionRefs, fieldRefs, err := memorizer.ConceptSyncRefs(ctx, pool, name)
+		if err != nil {
+			return fmt.Errorf("load sync refs: %w", err)
+		}
+
+		diff := memorizer.DiffConcepts(stored, candidate, actionRefs, fieldRefs)
+		return printConceptDiff(diff, fmt.Sprintf("Concept '%s'", name), against)
+	},
+}
+
+var conceptTemplateCmd = &cobra.Command{
+	Use:   "template [name]",
+	Short: "Print an empty concept spec skeleton to start a new concept from",
+	Long: `Print a concept JSON skeleton with empty state, actions, state machine and
+invariants, and placeholder purpose and operational principle. Redirect it
+to a file, fill it in, then check it with gam concept validate --file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := os.Stdout.Write(gam.ConceptTemplate(args[0]))
+		return err
+	},
+}
+
+var conceptValidateCmd = &cobra.Command{
+	Use:   "validate [name]",
+	Short: "Check a stored concept or spec file: spec shape, state machine, operational principle",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		lintOP, _ := cmd.Flags().GetBool("lint-op")
+		if (file == "") == (len(args) == 0) {
+			return fmt.Errorf("specify a concept name or --file")
+		}
+
+		var concept gam.Concept
+		var source string
+		if file != "" {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("read spec file: %w", err)
+			}
+			name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+			if len(args) == 1 {
+				name = args[0]
+			}
+			if concept, err = memorizer.ParseConcept(data, name); err != nil {
+				return fmt.Errorf("parse spec file: %w", err)
+			}
+			source = file
+		} else {
+			ctx := context.Background()
+			pool, err := connectDB(ctx)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			if concept, err = memorizer.LoadConcept(ctx, pool, args[0]); err != nil {
+				return err
+			}
+			source = "database"
+		}
+
+		issues := gam.CheckConcept(concept)
+		warnings := gam.CheckActionCases(concept)
+		if lintOP {
+			warnings = append(warnings, gam.LintOperationalPrinciple(concept)...)
+		}
+		if len(issues) == 0 && len(warnings) == 0 {
+			fmt.Printf("Concept '%s' (%s): all checks passed.\n", concept.Name, source)
+			return nil
+		}
+		fmt.Printf("Concept '%s' (%s):\n", concept.Name, source)
+		for _, d := range warnings {
+			fmt.Printf("  WARN %s: %s\n", d.Check, d.Got)
+			fmt.Printf("    Fix: %s\n", d.Fix)
+		}
+		if len(issues) == 0 {
+			return nil
+		}
+		for _, d := range issues {
+			fmt.Printf("  FAIL %s: expected %s, got %s\n", d.Check, d.Expected, d.Got)
+			fmt.Printf("    Fix: %s\n", d.Fix)
+		}
+		return fmt.Errorf("%d concept issue(s)", len(issues))
+	},
+}
+
+var conceptGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Rank concepts by the lines of code in their assigned regions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "list" && format != "json" {
+			return fmt.Errorf("unknown --format %q (want list or json)", format)
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		assigned, err := memorizer.ConceptRegions(ctx, pool)
+		if err != nil {
+			return err
+		}
+		root := projectRoot()
+		markers, _, err := region.ScanDirectory(root, region.ParseGamignore(root))
+		if err != nil {
+			return fmt.Errorf("scan regions: %w", err)
+		}
+		coverage := memorizer.CoverageByConcept(assigned, region.RegionLOC(markers))
+
+		if format == "json" {
+			if coverage == nil {
+				coverage = []memorizer.ConceptCoverage{}
+			}
+			out, _ := json.MarshalIndent(map[string]any{"name": "concepts", "children": coverage}, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if len(coverage) == 0 {
+			fmt.Println("No concepts are assigned to regions.")
+			return nil
+		}
+		total := 0
+		for _, c := range coverage {
+			total += c.LOC
+		}
+		for _, c := range coverage {
+			share := 0.0
+			if total > 0 {
+				share = 100 * float64(c.LOC) / float64(total)
+			}
+			fmt.Printf("%-30s %7d LOC  %5.1f%%  (%d region(s))\n", c.Concept, c.LOC, share, len(c.Regions))
+			for _, r := range c.Regions {
+				fmt.Printf("  %-40s %7d\n", r.Region, r.LOC)
+			}
+		}
+		return nil
+	},
+}
+
+var conceptCoverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Warn about concepts assigned to regions with no source markers",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		assigned, err := memorizer.ConceptRegions(ctx, pool)
+		if err != nil {
+			return err
+		}
+		root := projectRoot()
+		markers, _, err := region.ScanDirectory(root, region.ParseGamignore(root))
+		if err != nil {
+			return fmt.Errorf("scan regions: %w", err)
+		}
+		archPaths, err := region.ParseArchMd(root)
+		if err != nil {
+			return fmt.Errorf("parse arch.md: %w", err)
+		}
+		markedPaths := make([]string, len(markers))
+		for i, m := range markers {
+			markedPaths[i] = m.Path
+		}
+		gaps := memorizer.CoverageGaps(assigned, markedPaths, archPaths)
+
+		if asJSON {
+			if gaps == nil {
+				gaps = []memorizer.CoverageGap{}
+			}
+			out, _ := json.MarshalIndent(gaps, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+		writeCoverageGaps(os.Stdout, gaps)
+		return nil
+	},
+}
+
+// writeCoverageGaps prints a WARN line per concept assignment whose region
+// has no source markers, noting where the region is declared.
+func writeCoverageGaps(w io.Writer, gaps []memorizer.CoverageGap) {
+	if len(gaps) == 0 {
+		fmt.Fprintln(w, "Every concept assignment has source markers.")
+		return
+	}
+	for _, g := range gaps {
+		where := "the database only"
+		if g.InArch {
+			where = "arch.md and the database"
+		}
+		fmt.Fprintf(w, "  WARN %s assigned to %s, which has no source markers (declared in %s)\n", g.Concept, g.Region, where)
+	}
+	fmt.Fprintf(w, "\n%d assignment(s) without markers. Scaffold them with: gam region touch <region> --file <f>\n", len(gaps))
+}
+
+var conceptUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Report assigned regions, recent turns, and referencing syncs per concept",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		window, err := config.ParseWindow(sinceFlag)
+		if err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		usage, err := memorizer.ConceptUsageReport(ctx, pool, time.Now().Add(-window))
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			if usage == nil {
+				usage = []memorizer.ConceptUsage{}
+			}
+			out, _ := json.MarshalIndent(usage, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+		writeConceptUsage(os.Stdout, usage, sinceFlag)
+		return nil
+	},
+}
+
+var conceptDeadActionsCmd = &cobra.Command{
+	Use:   "dead-actions",
+	Short: "List concept actions referenced by no sync and absent from recent flow_log",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		windowFlag, _ := cmd.Flags().GetString("window")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		window, err := config.ParseWindow(windowFlag)
+		if err != nil {
+			return fmt.Errorf("--window: %w", err)
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		dead, err := memorizer.DeadActions(ctx, pool, time.Now().Add(-window))
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			if dead == nil {
+				dead = []memorizer.DeadAction{}
+			}
+			out, _ := json.MarshalIndent(dead, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+		writeDeadActions(os.Stdout, dead, windowFlag)
+		return nil
+	},
+}
+
+// writeDeadActions prints dead actions grouped by concept.
+func writeDeadActions(w io.Writer, dead []memorizer.DeadAction, window string) {
+	if len(dead) == 0 {
+		fmt.Fprintf(w, "No dead actions: every action is referenced by a sync or ran in the last %s.\n", window)
+		return
+	}
+	fmt.Fprintf(w, "Actions with no sync reference and no flow_log entry in the last %s:\n", window)
+	concept := ""
+	for _, d := range dead {
+		if d.Concept != concept {
+			concept = d.Concept
+			fmt.Fprintf(w, "  %s\n", concept)
+		}
+		fmt.Fprintf(w, "    %s\n", d.Action)
+	}
+}
+
+// writeConceptUsage prints the usage report as a table.
+func writeConceptUsage(w io.Writer, usage []memorizer.ConceptUsage, window string) {
+	if len(usage) == 0 {
+		fmt.Fprintln(w, "No concepts registered.")
+		return
+	}
+	fmt.Fprintf(w, "%-30s %7s %14s %6s\n", "CONCEPT", "REGIONS", "TURNS ("+window+")", "SYNCS")
+	for _, u := range usage {
+		fmt.Fprintf(w, "%-30s %7d %14d %6d\n", u.Concept, u.Regions, u.Turns, u.Syncs)
+	}
+}
+
+var conceptHistoryCmd = &cobra.Command{
+	Use:   "history [name]",
+	Short: "List superseded versions of a concept",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		diffVersion, _ := cmd.Flags().GetInt("diff")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		current, err := memorizer.LoadConcept(ctx, pool, name)
+		if err != nil {
+			return err
+		}
+		versions, err := memorizer.ConceptVersions(ctx, pool, name)
+		if err != nil {
+			return fmt.Errorf("load versions: %w", err)
+		}
+
+		if diffVersion > 0 {
+			for _, v := range versions {
+				if v.Version == diffVersion {
+					actionRefs, fieldRefs, err := memorizer.ConceptSyncRefs(ctx, pool, name)
+					if err != nil {
+						return fmt.Errorf("load sync refs: %w", err)
+					}
+					diff := memorizer.DiffConcepts(v.Concept, current, actionRefs, fieldRefs)
+					return printConceptDiff(diff, fmt.Sprintf("Concept '%s' v%d", name, v.Version), "current")
+				}
+			}
+			return fmt.Errorf("concept '%s' has no version %d", name, diffVersion)
+		}
+
+		if len(versions) == 0 {
+			fmt.Printf("Concept '%s' has no prior versions.\n", name)
+			return nil
+		}
+
+		fmt.Printf("Versions of '%s':\n", name)
+		for _, v := range versions {
+			from := "?"
+			if v.ValidFrom != nil {
+				from = v.ValidFrom.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Printf("  v%-3d %s -> %s  %d action(s), %d transition(s)\n",
+				v.Version, from, v.SupersededAt.Local().Format("2006-01-02 15:04"),
+				len(v.Concept.Spec.Actions), len(v.Concept.StateMachine.Transitions))
+		}
+		fmt.Printf("  current  %d action(s), %d transition(s)\n",
+			len(current.Spec.Actions), len(current.StateMachine.Transitions))
+		return nil
+	},
+}
+
+// printConceptDiff prints diff between from and to, failing if the change
+// breaks syncs.
+func printConceptDiff(diff memorizer.ConceptDiff, from, to string) error {
+	if !diff.HasChanges() {
+		fmt.Printf("%s matches %s.\n", from, to)
+		return nil
+	}
+
+	fmt.Printf("%s vs %s:\n", from, to)
+	printDiffSection("Actions", diff.AddedActions, diff.RemovedActions)
+	printDiffSection("State", diff.AddedState, diff.RemovedState)
+	printDiffSection("Transitions", diff.AddedTransitions, diff.RemovedTransitions)
+
+	if len(diff.Breaking) == 0 {
+		return nil
+	}
+	fmt.Println("\nBREAKING:")
+	for _, b := range diff.Breaking {
+		fmt.Printf("  removed %s %s is referenced by sync(s): %s\n", b.Kind, b.Name, strings.Join(b.Syncs, ", "))
+	}
+	return fmt.Errorf("%d breaking change(s); update or delete the affected syncs first", len(diff.Breaking))
+}
+
+func printDiffSection(title string, added, removed []string) {
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	fmt.Printf("\n  %s:\n", title)
+	for _, a := range added {
+		fmt.Printf("    + %s\n", a)
+	}
+	for _, r := range removed {
+		fmt.Printf("    - %s\n", r)
+	}
+}
+
+var conceptImportCmd = &cobra.Command{
+	Use:   "import [dir]",
+	Short: "Import every *.json concept spec in a directory",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		results, err := memorizer.ImportConcepts(ctx, pool, args[0])
+		if err != nil {
+			return err
+		}
+		return reportImport("concept", results)
+	},
+}
+
+// reportImport prints per-file import results and fails if any file failed.
+func reportImport(kind string, results []memorizer.ImportResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Printf("  FAIL %s: %v\n", filepath.Base(r.File), r.Err)
+		} else {
+			fmt.Printf("  ok   %s -> %s\n", filepath.Base(r.File), r.Name)
+		}
+	}
+	fmt.Printf("\nImported %d %s(s), %d failed.\n", len(results)-failed, kind, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d %s spec(s) failed to import", failed, kind)
+	}
+	return nil
+}
+
 var conceptShowCmd = &cobra.Command{
 	Use:   "show [name]",
 	Short: "Display concept spec",
@@ -221,16 +687,23 @@ var conceptListCmd = &cobra.Command{
 }
 
 var conceptAssignCmd = &cobra.Command{
-	Use:   "assign [concept] [region]",
-	Short: "Create concept-region assignment",
+	Use:   "assign [concept[,concept...]] [region]",
+	Short: "Create concept-region assignments",
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		conceptName := args[0]
+		concepts := splitConceptNames(args[0])
 		regionPath := args[1]
 		role, _ := cmd.Flags().GetString("role")
+		force, _ := cmd.Flags().GetBool("force")
 		if role == "" {
 			role = "implementation"
 		}
+		if len(concepts) == 0 {
+			return fmt.Errorf("at least one concept name is required")
+		}
+		if err := checkAssignmentRole(role, force); err != nil {
+			return err
+		}
 
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
@@ -239,29 +712,119 @@ var conceptAssignCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		_, err = pool.Exec(ctx, `
+		if err := assignConcepts(ctx, pool, concepts, regionPath, role); err != nil {
+			return err
+		}
+
+		for _, name := range concepts {
+			fmt.Printf("Concept '%s' assigned to region '%s' with role '%s'\n", name, regionPath, role)
+		}
+		return nil
+	},
+}
+
+// splitConceptNames splits a comma-separated concept list, dropping blanks
+// and duplicates while keeping the given order.
+func splitConceptNames(arg string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(arg, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// assignConcepts assigns every concept to regionPath with role in a single
+// transaction. Nothing is written unless the region and all concepts exist.
+func assignConcepts(ctx context.Context, pool *pgxpool.Pool, concepts []string, regionPath, role string) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var regionID string
+	err = tx.QueryRow(ctx, `SELECT id::text FROM regions WHERE path = $1`, regionPath).Scan(&regionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("region '%s' not found", regionPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, name := range concepts {
+		tag, err := tx.Exec(ctx, `
 			INSERT INTO concept_region_assignments (concept_id, region_id, role)
 			SELECT c.id, r.id, $3
 			FROM concepts c, regions r
 			WHERE c.name = $1 AND r.path = $2
 			ON CONFLICT (concept_id, region_id) DO UPDATE SET role = $3
-		`, conceptName, regionPath, role)
+		`, name, regionPath, role)
 		if err != nil {
-			return fmt.Errorf("assign concept: %w", err)
+			return fmt.Errorf("assign concept %s: %w", name, err)
 		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("concept '%s' not found", name)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit assignments: %w", err)
+	}
+	return nil
+}
 
-		fmt.Printf("Concept '%s' assigned to region '%s' with role '%s'\n", conceptName, regionPath, role)
+// assignmentRoles are the concept_region_assignments roles the rest of gam
+// understands. The column itself is free-form so new roles can be stored
+// with --force ahead of tooling support.
+var assignmentRoles = []string{"implementation", "integration", "test", "consumer"}
+
+// checkAssignmentRole rejects a role outside assignmentRoles unless force is set.
+func checkAssignmentRole(role string, force bool) error {
+	if force {
 		return nil
-	},
+	}
+	for _, r := range assignmentRoles {
+		if role == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown role %q: valid roles are %s (use --force to store it anyway)",
+		role, strings.Join(assignmentRoles, ", "))
 }
 
 func init() {
 	conceptAddCmd.Flags().String("spec", "", "Path to concept spec JSON file")
 	conceptAddCmd.Flags().String("purpose", "", "Concept purpose (overrides spec file)")
 
+	conceptDiffCmd.Flags().String("against", "", "Candidate concept spec JSON file")
+	conceptValidateCmd.Flags().String("file", "", "Validate a concept spec JSON file instead of a stored concept")
+	conceptValidateCmd.Flags().Bool("lint-op", false, "Also warn about prose action references in the operational principle that match no declared action")
+	conceptGraphCmd.Flags().String("format", "list", "Output format: list|json (treemap-friendly)")
+	conceptUsageCmd.Flags().String("since", "30d", "Count turns created within this window (e.g. 7d, 72h)")
+	conceptUsageCmd.Flags().Bool("json", false, "Output as JSON")
+	conceptCoverageCmd.Flags().Bool("json", false, "Output as JSON")
+	conceptDeadActionsCmd.Flags().String("window", "30d", "flow_log lookback; actions that ran within it are not dead (e.g. 7d, 72h)")
+	conceptDeadActionsCmd.Flags().Bool("json", false, "Output as JSON")
+	conceptHistoryCmd.Flags().Int("diff", 0, "Compare version N to the current definition")
 	conceptAssignCmd.Flags().String("role", "implementation", "Assignment role: implementation|integration|test|consumer")
+	conceptAssignCmd.Flags().Bool("force", false, "Accept a role outside the known set")
 
 	conceptCmd.AddCommand(conceptAddCmd)
+	conceptCmd.AddCommand(conceptImportCmd)
+	conceptCmd.AddCommand(conceptDiffCmd)
+	conceptCmd.AddCommand(conceptTemplateCmd)
+	conceptCmd.AddCommand(conceptValidateCmd)
+	conceptCmd.AddCommand(conceptGraphCmd)
+	conceptCmd.AddCommand(conceptUsageCmd)
+	conceptCmd.AddCommand(conceptCoverageCmd)
+	conceptCmd.AddCommand(conceptDeadActionsCmd)
+	conceptCmd.AddCommand(conceptHistoryCmd)
 	conceptCmd.AddCommand(conceptShowCmd)
 	conceptCmd.AddCommand(conceptListCmd)
 	conceptCmd.AddCommand(conceptAssignCmd)
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestCheckAssignmentRole(t *testing.T) {
+	if err := checkAssignmentRole("integration", false); err != nil {
+		t.Errorf("valid role rejected: %v", err)
+	}
+
+	err := checkAssignmentRole("implmentation", false)
+	if err == nil {
+		t.Fatal("expected an error for a misspelled role")
+	}
+	for _, r := range assignmentRoles {
+		if !strings.Contains(err.Error(), r) {
+			t.Errorf("error %q does not list valid role %q", err, r)
+		}
+	}
+
+	if err := checkAssignmentRole("observer", true); err != nil {
+		t.Errorf("--force should accept any role: %v", err)
+	}
+}
+
+func TestSplitConceptNames(t *testing.T) {
+	got := splitConceptNames(" A,B,,A , C")
+	want := []string{"A", "B", "C"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("splitConceptNames = %v, want %v", got, want)
+	}
+}
+
+func TestAssignConceptsAtomic(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	names := []string{"AssignTestA", "AssignTestB", "AssignTestC"}
+	for _, name := range names {
+		pool.Exec(ctx, `INSERT INTO concepts (name, purpose, spec, state_machine) VALUES ($1, 'test', '{}', '{}') ON CONFLICT (name) DO NOTHING`, name)
+	}
+	defer pool.Exec(ctx, `DELETE FROM concepts WHERE name LIKE 'AssignTest%'`)
+	pool.Exec(ctx, `INSERT INTO regions (path) VALUES ('assigntest') ON CONFLICT (path) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'assigntest'`)
+
+	count := func() int {
+		var n int
+		pool.QueryRow(ctx, `
+			SELECT COUNT(*) FROM concept_region_assignments cra
+			JOIN regions r ON r.id = cra.region_id
+			WHERE r.path = 'assigntest'
+		`).Scan(&n)
+		return n
+	}
+
+	// An unknown concept rolls back the whole batch.
+	err := assignConcepts(ctx, pool, append(names[:2:2], "AssignTestMissing"), "assigntest", "implementation")
+	if err == nil || !strings.Contains(err.Error(), "AssignTestMissing") {
+		t.Fatalf("expected missing concept error, got %v", err)
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("failed batch left %d assignments", n)
+	}
+
+	if err := assignConcepts(ctx, pool, names, "assigntest", "implementation"); err != nil {
+		t.Fatalf("assignConcepts: %v", err)
+	}
+	if n := count(); n != 3 {
+		t.Errorf("assignments = %d, want 3", n)
+	}
+
+	if err := assignConcepts(ctx, pool, names, "assigntest.missing", "implementation"); err == nil {
+		t.Error("expected an error for an unknown region")
+	}
+}
+
+func TestWriteCoverageGaps(t *testing.T) {
+	var buf bytes.Buffer
+	writeCoverageGaps(&buf, []memorizer.CoverageGap{
+		{Concept: "Billing", Region: "app.billing", InArch: true},
+		{Concept: "Session", Region: "app.auth"},
+	})
+	out := buf.String()
+	for _, want := range []string{
+		"WARN Billing assigned to app.billing, which has no source markers (declared in arch.md and the database)",
+		"WARN Session assigned to app.auth, which has no source markers (declared in the database only)",
+		"2 assignment(s) without markers",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/spf13/cobra"
+)
+
+var docsServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Preview the docs/ directory as HTML over HTTP",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		live, _ := cmd.Flags().GetBool("live")
+
+		srv := &docsServer{dir: filepath.Join(projectRoot(), "docs")}
+		if live {
+			ctx := context.Background()
+			pool, err := connectDB(ctx)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			rdb, err := connectRedis()
+			if err != nil {
+				return err
+			}
+			defer rdb.Close()
+
+			m := memorizer.New(pool, rdb, projectRoot())
+			srv.regen = memorizer.NewDocsExporter(m, projectRoot()).ExportAll
+		}
+
+		fmt.Printf("Serving %s on http://%s\n", srv.dir, addr)
+		return http.ListenAndServe(addr, srv)
+	},
+}
+
+// docsSections are the top-level areas linked from the docs home page.
+var docsSections = []struct{ Title, Path string }{
+	{"Concepts", "/concepts/index.md"},
+	{"Syncs", "/syncs/index.md"},
+	{"Active plans", "/exec-plans/active/"},
+	{"Completed plans", "/exec-plans/completed/"},
+	{"Quality", "/quality/"},
+}
+
+// docsServer renders the markdown files under dir as HTML. When regen is set
+// the docs are re-exported from the database before each request.
+type docsServer struct {
+	dir   string
+	regen func(context.Context) error
+	mu    sync.Mutex
+}
+
+func (s *docsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.regen != nil {
+		s.mu.Lock()
+		err := s.regen(r.Context())
+		s.mu.Unlock()
+		if err != nil {
+			http.Error(w, "regenerate docs: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	urlPath := path.Clean("/" + r.URL.Path)
+	if urlPath == "/" {
+		var body strings.Builder
+		body.WriteString("<h1>Docs</h1>\n<ul>\n")
+		for _, sec := range docsSections {
+			body.WriteString(fmt.Sprintf("<li><a href=\"%s\">%s</a></li>\n", sec.Path, sec.Title))
+		}
+		body.WriteString("</ul>\n")
+		writeDocsPage(w, "Docs", body.String())
+		return
+	}
+
+	file := filepath.Join(s.dir, filepath.FromSlash(urlPath))
+	info, err := os.Stat(file)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if info.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			http.Redirect(w, r, urlPath+"/", http.StatusMovedPermanently)
+			return
+		}
+		s.serveDir(w, urlPath, file)
+		return
+	}
+	if !strings.HasSuffix(file, ".md") {
+		http.ServeFile(w, r, file)
+		return
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeDocsPage(w, path.Base(urlPath), renderMarkdown(string(data)))
+}
+
+// serveDir lists the markdown files in a directory that has no index page.
+func (s *docsServer) serveDir(w http.ResponseWriter, urlPath, dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name()+"/")
+		} else if strings.HasSuffix(e.Name(), ".md") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("<h1>%s</h1>\n<ul>\n", html.EscapeString(urlPath)))
+	for _, n := range names {
+		body.WriteString(fmt.Sprintf("<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(n), html.EscapeString(n)))
+	}
+	body.WriteString("</ul>\n")
+	writeDocsPage(w, urlPath, body.String())
+}
+
+func writeDocsPage(w http.ResponseWriter, title, body string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>%s</title></head>\n<body>\n",
+		html.EscapeString(title))
+	fmt.Fprint(w, "<nav><a href=\"/\">Docs</a>")
+	for _, sec := range docsSections {
+		fmt.Fprintf(w, " | <a href=\"%s\">%s</a>", sec.Path, sec.Title)
+	}
+	fmt.Fprint(w, "</nav>\n")
+	fmt.Fprint(w, body)
+	fmt.Fprint(w, "</body></html>\n")
+}
+
+// renderMarkdown converts the markdown subset written by the docs exporter
+// (headings, bullet lists, fenced code, paragraphs, and inline code, bold,
+// and links) to HTML.
+func renderMarkdown(src string) string {
+	var out strings.Builder
+	var items, para []string
+	inCode := false
+
+	flush := func() {
+		if len(para) > 0 {
+			out.WriteString("<p>" + renderInline(strings.Join(para, " ")) + "</p>\n")
+			para = nil
+		}
+		if len(items) > 0 {
+			out.WriteString("<ul>\n")
+			for _, item := range items {
+				out.WriteString("<li>" + item + "</li>\n")
+			}
+			out.WriteString("</ul>\n")
+			items = nil
+		}
+	}
+
+	for _, line := range strings.Split(src, "\n") {
+		switch {
+		case strings.HasPrefix(line, "```"):
+			if inCode {
+				out.WriteString("</code></pre>\n")
+			} else {
+				flush()
+				out.WriteString("<pre><code>")
+			}
+			inCode = !inCode
+		case inCode:
+			out.WriteString(html.EscapeString(line) + "\n")
+		case strings.TrimSpace(line) == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			if level > 6 || !strings.HasPrefix(line[level:], " ") {
+				para = append(para, line)
+				continue
+			}
+			flush()
+			out.WriteString(fmt.Sprintf("<h%d>%s</h%d>\n", level, renderInline(strings.TrimSpace(line[level:])), level))
+		case strings.HasPrefix(line, "- "):
+			if len(para) > 0 {
+				flush()
+			}
+			items = append(items, renderInline(line[2:]))
+		case len(items) > 0 && strings.HasPrefix(line, "  "):
+			items[len(items)-1] += "<br>" + renderInline(strings.TrimSpace(line))
+		default:
+			para = append(para, line)
+		}
+	}
+	if inCode {
+		out.WriteString("</code></pre>\n")
+	}
+	flush()
+	return out.String()
+}
+
+var (
+	mdBold = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	mdLink = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// renderInline escapes text and renders `code`, **bold**, and [text](url).
+func renderInline(s string) string {
+	parts := strings.Split(s, "`")
+	var out strings.Builder
+	for i, part := range parts {
+		escaped := html.EscapeString(part)
+		switch {
+		case i%2 == 1 && i < len(parts)-1:
+			out.WriteString("<code>" + escaped + "</code>")
+		case i%2 == 1:
+			out.WriteString("`" + escaped)
+		default:
+			escaped = mdLink.ReplaceAllString(escaped, `<a href="$2">$1</a>`)
+			out.WriteString(mdBold.ReplaceAllString(escaped, "<strong>$1</strong>"))
+		}
+	}
+	return out.String()
+}
+
+func init() {
+	docsServeCmd.Flags().String("addr", "localhost:8080", "Address to listen on")
+	docsServeCmd.Flags().Bool("live", false, "Re-export docs from the database on each request")
+	docsCmd.AddCommand(docsServeCmd)
+}
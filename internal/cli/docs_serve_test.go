@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocsServerServesConceptIndex(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "concepts"), 0755)
+	os.WriteFile(filepath.Join(dir, "concepts", "index.md"),
+		[]byte("# Concept Catalog\n\n- **Password**: securely store credentials\n"), 0644)
+
+	regenerated := 0
+	srv := &docsServer{dir: dir, regen: func(context.Context) error {
+		regenerated++
+		return nil
+	}}
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/concepts/index.md", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"<h1>Concept Catalog</h1>",
+		"<li><strong>Password</strong>: securely store credentials</li>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+	if regenerated != 1 {
+		t.Errorf("regenerated %d times, want 1", regenerated)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !strings.Contains(rec.Body.String(), `href="/concepts/index.md"`) {
+		t.Errorf("home page does not link the concept index:\n%s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/../../etc/passwd", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("path outside docs: status = %d, want 404", rec.Code)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	got := renderMarkdown("## Actions\n\n- `set [a: A] => []`\n  Synced by: [Reg](../syncs/reg.md) (then)\n\n```\nx < y\n```\n")
+	for _, want := range []string{
+		"<h2>Actions</h2>",
+		"<li><code>set [a: A] =&gt; []</code><br>Synced by: <a href=\"../syncs/reg.md\">Reg</a> (then)</li>",
+		"<pre><code>x &lt; y\n</code></pre>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sbenjam1n/gamsync/internal/config"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/spf13/cobra"
 )
 
@@ -111,9 +113,46 @@ var flowListCmd = &cobra.Command{
 	},
 }
 
+var flowGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete flows whose root entry is older than a window, with all their children",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		window, err := config.ParseWindow(olderThan)
+		if err != nil || window <= 0 {
+			return fmt.Errorf("invalid --older-than %q: use a duration such as 30d or 72h", olderThan)
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		res, err := memorizer.PruneFlowLog(ctx, pool, time.Now().Add(-window), batchSize, dryRun)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Printf("Would delete %d flow(s), %d flow_log row(s) older than %s (dry run).\n", res.Flows, res.Rows, olderThan)
+			return nil
+		}
+		fmt.Printf("Deleted %d flow(s), %d flow_log row(s) older than %s.\n", res.Flows, res.Rows, olderThan)
+		return nil
+	},
+}
+
 func init() {
 	flowListCmd.Flags().Int("recent", 10, "Number of recent flow tokens to show")
 
 	flowCmd.AddCommand(flowTraceCmd)
 	flowCmd.AddCommand(flowListCmd)
+
+	flowGCCmd.Flags().String("older-than", "30d", "Delete flows whose root entry is older than this (e.g. 30d, 72h)")
+	flowGCCmd.Flags().Int("batch-size", memorizer.DefaultFlowGCBatch, "Flows deleted per statement")
+	flowGCCmd.Flags().Bool("dry-run", false, "Count what would be deleted without deleting")
+	flowCmd.AddCommand(flowGCCmd)
 }
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/spf13/cobra"
+)
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Lifecycle hook management",
+}
+
+var hookAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Register or update a lifecycle hook",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		event, _ := cmd.Flags().GetString("event")
+		name, _ := cmd.Flags().GetString("name")
+		handler, _ := cmd.Flags().GetString("handler")
+		priority, _ := cmd.Flags().GetInt("priority")
+		scope, _ := cmd.Flags().GetString("scope")
+		command, _ := cmd.Flags().GetString("command")
+
+		if event == "" || name == "" {
+			return fmt.Errorf("--event and --name are required")
+		}
+		if handler == "exec" && command == "" {
+			return fmt.Errorf("--command is required for the exec handler")
+		}
+
+		hook := gam.LifecycleHook{
+			Event:    event,
+			HookName: name,
+			Priority: priority,
+			Handler:  handler,
+			Scope:    scope,
+		}
+		if command != "" {
+			hook.Config = map[string]any{"command": command}
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		reg := memorizer.NewHookRegistry(pool, projectRoot())
+		if err := reg.AddHook(ctx, hook); err != nil {
+			return fmt.Errorf("add hook: %w", err)
+		}
+
+		fmt.Printf("Hook '%s' registered for %s (handler: %s, priority: %d)\n", name, event, handler, priority)
+		return nil
+	},
+}
+
+var hookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List lifecycle hooks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		event, _ := cmd.Flags().GetString("event")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		reg := memorizer.NewHookRegistry(pool, projectRoot())
+		hooks, err := reg.ListHooks(ctx, event)
+		if err != nil {
+			return fmt.Errorf("list hooks: %w", err)
+		}
+		if len(hooks) == 0 {
+			fmt.Println("No hooks registered.")
+			return nil
+		}
+
+		fmt.Printf("%-18s %-24s %-8s %-8s %-8s %s\n", "EVENT", "NAME", "PRIORITY", "HANDLER", "ENABLED", "SCOPE")
+		for _, h := range hooks {
+			scope := h.Scope
+			if scope == "" {
+				scope = "*"
+			}
+			fmt.Printf("%-18s %-24s %-8d %-8s %-8v %s\n", h.Event, h.HookName, h.Priority, h.Handler, h.Enabled, scope)
+		}
+		return nil
+	},
+}
+
+var hookRmCmd = &cobra.Command{
+	Use:   "rm",
+	Short: "Remove a lifecycle hook",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		event, _ := cmd.Flags().GetString("event")
+		if name == "" {
+			return fmt.Errorf("--name is required")
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		reg := memorizer.NewHookRegistry(pool, projectRoot())
+		n, err := reg.RemoveHook(ctx, name, event)
+		if err != nil {
+			return fmt.Errorf("remove hook: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("hook '%s' not found", name)
+		}
+
+		fmt.Printf("Removed %d hook(s) named '%s'\n", n, name)
+		return nil
+	},
+}
+
+func init() {
+	hookAddCmd.Flags().String("event", "", "Lifecycle event (turn_start, turn_end, proposal_approved)")
+	hookAddCmd.Flags().String("name", "", "Hook name")
+	hookAddCmd.Flags().String("handler", "exec", "Hook handler")
+	hookAddCmd.Flags().Int("priority", 100, "Execution order (lower runs first)")
+	hookAddCmd.Flags().String("scope", "", "Restrict the hook to a region subtree")
+	hookAddCmd.Flags().String("command", "", "Shell command for the exec handler")
+
+	hookListCmd.Flags().String("event", "", "Filter by event")
+
+	hookRmCmd.Flags().String("name", "", "Hook name")
+	hookRmCmd.Flags().String("event", "", "Only remove the hook for this event")
+
+	hookCmd.AddCommand(hookAddCmd)
+	hookCmd.AddCommand(hookListCmd)
+	hookCmd.AddCommand(hookRmCmd)
+}
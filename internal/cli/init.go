@@ -3,24 +3,33 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 
 	"github.com/sbenjam1n/gamsync/internal/db"
 	"github.com/sbenjam1n/gamsync/internal/queue"
+	"github.com/sbenjam1n/gamsync/skills"
 	"github.com/spf13/cobra"
 )
 
-var minimal bool
+var (
+	minimal bool
+	upgrade bool
+)
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a GAM+Sync project",
-	Long:  "Initialize project: arch.md, .gamignore, docs/, PostgreSQL schema, Redis streams",
+	Long:  "Initialize project: arch.md, .gamignore, docs/, skills/, PostgreSQL schema, Redis streams",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		root := projectRoot()
 		ctx := context.Background()
 
+		if upgrade {
+			return runUpgrade(ctx)
+		}
+
 		// Create arch.md
 		archPath := filepath.Join(root, "arch.md")
 		if _, err := os.Stat(archPath); os.IsNotExist(err) {
@@ -92,6 +101,17 @@ testdata/
 		}
 		fmt.Println("Created docs/ directory structure")
 
+		// Create skills/ with the default skill prompts
+		created, err := scaffoldSkills(root)
+		if err != nil {
+			return fmt.Errorf("create skills/: %w", err)
+		}
+		if created > 0 {
+			fmt.Printf("Created skills/ (%d skill prompts)\n", created)
+		} else {
+			fmt.Println("skills/ already exists")
+		}
+
 		if minimal {
 			fmt.Println("\nMinimal init complete. Run 'gam init' (without --minimal) to set up PostgreSQL and Redis.")
 			return nil
@@ -101,7 +121,7 @@ testdata/
 		fmt.Println("Connecting to PostgreSQL...")
 		pool, err := db.Connect(ctx, cfg.DatabaseURL)
 		if err != nil {
-			return fmt.Errorf("database connection failed: %w", err)
+			return fmt.Errorf("database connection failed: %w", redactError(err, cfg.DatabaseURL))
 		}
 		defer pool.Close()
 
@@ -111,17 +131,8 @@ testdata/
 		}
 		fmt.Println("PostgreSQL schema created")
 
-		// Set up Redis streams
-		fmt.Println("Connecting to Redis...")
-		rdb, err := connectRedis()
-		if err != nil {
-			return fmt.Errorf("redis connection failed: %w", err)
-		}
-		defer rdb.Close()
-
-		q := queue.New(rdb)
-		if err := q.EnsureStreams(ctx); err != nil {
-			return fmt.Errorf("redis stream setup failed: %w", err)
+		if err := ensureStreams(ctx); err != nil {
+			return err
 		}
 		fmt.Println("Redis streams created")
 
@@ -135,6 +146,87 @@ testdata/
 	},
 }
 
+// runUpgrade applies pending migrations to an initialized project without
+// touching scaffolded files.
+func runUpgrade(ctx context.Context) error {
+	fmt.Println("Connecting to PostgreSQL...")
+	pool, err := db.Connect(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("database connection failed: %w", redactError(err, cfg.DatabaseURL))
+	}
+	defer pool.Close()
+
+	if err := db.EnsureExtensions(ctx, pool); err != nil {
+		return fmt.Errorf("upgrade failed: %w", err)
+	}
+
+	applied, err := db.MigrateUp(ctx, pool, migrationsDir())
+	for _, v := range applied {
+		fmt.Printf("Applied migration %s\n", v)
+	}
+	if err != nil {
+		return fmt.Errorf("upgrade failed: %w", err)
+	}
+	if len(applied) == 0 {
+		fmt.Println("Schema is up to date.")
+	}
+
+	if err := ensureStreams(ctx); err != nil {
+		return err
+	}
+	fmt.Println("Redis streams verified")
+
+	fmt.Println("\nUpgrade complete.")
+	return nil
+}
+
+// scaffoldSkills copies the embedded default skill prompts into root/skills,
+// leaving any existing files untouched. It returns the number of files written.
+func scaffoldSkills(root string) (int, error) {
+	dir := filepath.Join(root, "skills")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, err
+	}
+
+	entries, err := fs.ReadDir(skills.FS, ".")
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		data, err := skills.FS.ReadFile(e.Name())
+		if err != nil {
+			return created, err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+func ensureStreams(ctx context.Context) error {
+	fmt.Println("Connecting to Redis...")
+	rdb, err := connectRedis()
+	if err != nil {
+		return fmt.Errorf("redis connection failed: %w", err)
+	}
+	defer rdb.Close()
+
+	q := queue.New(rdb)
+	if err := q.EnsureStreams(ctx); err != nil {
+		return fmt.Errorf("redis stream setup failed: %w", err)
+	}
+	return nil
+}
+
 func init() {
-	initCmd.Flags().BoolVar(&minimal, "minimal", false, "Minimal init: arch.md + .gamignore + docs/ only")
+	initCmd.Flags().BoolVar(&minimal, "minimal", false, "Minimal init: arch.md + .gamignore + docs/ + skills/ only")
+	initCmd.Flags().BoolVar(&upgrade, "upgrade", false, "Apply pending migrations only; skip file scaffolding")
 }
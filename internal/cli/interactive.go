@@ -3,6 +3,9 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,6 +37,8 @@ var interactiveCmd = &cobra.Command{
 		var regions []dbRegionInfo
 		var concepts []dbConceptInfo
 		var syncs []dbSyncInfo
+		var describe func(path, desc string) error
+		brokenRefs := 0
 		ctx := context.Background()
 		pool, poolErr := connectDB(ctx)
 		if poolErr == nil {
@@ -41,9 +46,18 @@ var interactiveCmd = &cobra.Command{
 			regions = loadDBRegions(ctx, pool)
 			concepts = loadDBConcepts(ctx, pool)
 			syncs = loadDBSyncs(ctx, pool)
+			brokenRefs = countBrokenSyncRefs(ctx, pool)
+			describe = func(path, desc string) error {
+				return describeRegion(ctx, pool, root, path, desc)
+			}
 		}
 
 		m := newInteractiveModel(tree, archEntries, markers, warnings, regions, concepts, syncs)
+		m.describe = describe
+		m.root = root
+		m.dbLoaded = poolErr == nil
+		m.brokenRefs = brokenRefs
+		m.stats = m.computeStats()
 		p := tea.NewProgram(m, tea.WithAltScreen())
 		if _, err := p.Run(); err != nil {
 			return err
@@ -126,6 +140,22 @@ type interactiveModel struct {
 	searchMode   bool
 	searchBuffer string
 	detailPath   string
+
+	// dbLoaded reports whether concepts, syncs and brokenRefs came from the
+	// database; stats summarizes the project for the header.
+	dbLoaded   bool
+	brokenRefs int
+	stats      headerStats
+
+	// root resolves marker files, which are relative to the project root.
+	root string
+
+	// describe saves a region description; nil without a database, which
+	// disables editing.
+	describe   func(path, desc string) error
+	editMode   bool
+	editBuffer string
+	status     string
 }
 
 func newInteractiveModel(
@@ -148,7 +178,7 @@ func newInteractiveModel(
 		item.expanded = true
 	}
 
-	return interactiveModel{
+	m := interactiveModel{
 		tree:        tree,
 		archEntries: archEntries,
 		markers:     markers,
@@ -160,6 +190,72 @@ func newInteractiveModel(
 		width:       80,
 		height:      24,
 	}
+	m.stats = m.computeStats()
+	return m
+}
+
+// headerStats is the at-a-glance project summary shown in the header.
+type headerStats struct {
+	regions        int
+	concepts       int
+	syncs          int
+	warnings       int
+	archMismatches int // regions in source or arch.md but not both
+	brokenRefs     int // sync refs to undefined concept actions
+}
+
+// computeStats derives the header summary from the loaded data.
+func (m interactiveModel) computeStats() headerStats {
+	inSource := make(map[string]bool)
+	for _, mk := range m.markers {
+		inSource[mk.Path] = true
+	}
+	inArch := make(map[string]bool)
+	for _, e := range m.archEntries {
+		inArch[e.Path] = true
+	}
+	st := headerStats{
+		regions:    len(inSource),
+		concepts:   len(m.dbConcepts),
+		syncs:      len(m.dbSyncs),
+		warnings:   len(m.warnings),
+		brokenRefs: m.brokenRefs,
+	}
+	for p := range inSource {
+		if !inArch[p] {
+			st.archMismatches++
+		}
+	}
+	for p := range inArch {
+		if !inSource[p] {
+			st.archMismatches++
+		}
+	}
+	return st
+}
+
+// headerLine renders the counts and a health indicator.
+func (m interactiveModel) headerLine() string {
+	st := m.stats
+	counts := fmt.Sprintf("%d regions", st.regions)
+	if m.dbLoaded {
+		counts += fmt.Sprintf(" · %d concepts · %d syncs", st.concepts, st.syncs)
+	} else {
+		counts += " · no database"
+	}
+	counts += fmt.Sprintf(" · %d warnings", st.warnings)
+
+	var problems []string
+	if st.archMismatches > 0 {
+		problems = append(problems, fmt.Sprintf("%d arch mismatches", st.archMismatches))
+	}
+	if st.brokenRefs > 0 {
+		problems = append(problems, fmt.Sprintf("%d broken sync refs", st.brokenRefs))
+	}
+	if len(problems) == 0 {
+		return dimStyle.Render(counts) + "  " + headerStyle.Render("healthy")
+	}
+	return dimStyle.Render(counts) + "  " + warnStyle.Render(strings.Join(problems, ", "))
 }
 
 func buildTreeItems(node *region.TreeNode, descs map[string]string, depth int) []*treeItem {
@@ -191,10 +287,20 @@ func (m interactiveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case editorDoneMsg:
+		if msg.err != nil {
+			m.status = "Editor failed: " + msg.err.Error()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.searchMode {
 			return m.handleSearchKey(msg)
 		}
+		if m.editMode {
+			return m.handleEditKey(msg)
+		}
+		m.status = ""
 
 		switch msg.String() {
 		case "q", "ctrl+c":
@@ -239,17 +345,37 @@ func (m interactiveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "1":
 			m.viewMode = viewRegions
 			m.cursor = 0
+			m.stats = m.computeStats()
 		case "2":
 			m.viewMode = viewConcepts
 			m.cursor = 0
+			m.stats = m.computeStats()
 		case "3":
 			m.viewMode = viewSyncs
 			m.cursor = 0
+			m.stats = m.computeStats()
 
 		case "/":
 			m.searchMode = true
 			m.searchBuffer = ""
 
+		case "o":
+			cmd := m.openSelected()
+			return m, cmd
+		case "y":
+			m.copySelected()
+
+		case "e":
+			if m.viewMode != viewDetail {
+				break
+			}
+			if m.describe == nil {
+				m.status = "Editing needs a database connection."
+				break
+			}
+			m.editMode = true
+			m.editBuffer = m.archDescription(m.detailPath)
+
 		case "esc":
 			if m.viewMode == viewDetail {
 				m.viewMode = viewRegions
@@ -288,6 +414,180 @@ func (m *interactiveModel) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	return m, nil
 }
 
+// selectedItem returns the region under the cursor, or the one shown in the
+// detail view. It returns nil on the concept and sync tabs.
+func (m interactiveModel) selectedItem() *treeItem {
+	switch m.viewMode {
+	case viewRegions:
+		visible := m.visibleItems()
+		if m.cursor < len(visible) {
+			return visible[m.cursor]
+		}
+	case viewDetail:
+		for _, item := range m.visibleItems() {
+			if item.fullPath == m.detailPath {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+// selectedSource returns the selected region's file, resolved against the
+// project root, and start line. ok is false, with m.status explaining why,
+// when nothing is selected or the region has no source markers.
+func (m *interactiveModel) selectedSource() (file string, line int, ok bool) {
+	item := m.selectedItem()
+	if item == nil {
+		m.status = "Select a region first."
+		return "", 0, false
+	}
+	if item.file == "" {
+		m.status = "Region " + item.fullPath + " has no source markers."
+		return "", 0, false
+	}
+	file = filepath.FromSlash(item.file)
+	if m.root != "" && !filepath.IsAbs(file) {
+		file = filepath.Join(m.root, file)
+	}
+	return file, item.startLine, true
+}
+
+// openSelected suspends the TUI and opens the selected region in $EDITOR.
+func (m *interactiveModel) openSelected() tea.Cmd {
+	file, line, ok := m.selectedSource()
+	if !ok {
+		return nil
+	}
+	cmd, err := editorCommand(os.Getenv("EDITOR"), file, line)
+	if err != nil {
+		m.status = err.Error()
+		return nil
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg { return editorDoneMsg{err} })
+}
+
+// editorDoneMsg reports that the editor started by openSelected exited.
+type editorDoneMsg struct{ err error }
+
+// copySelected copies the selected region's file:line to the clipboard.
+func (m *interactiveModel) copySelected() {
+	file, line, ok := m.selectedSource()
+	if !ok {
+		return
+	}
+	loc := fmt.Sprintf("%s:%d", file, line)
+	cmd := clipboardCommand()
+	if cmd == nil {
+		m.status = "No clipboard tool found (pbcopy, wl-copy, xclip, xsel): " + loc
+		return
+	}
+	cmd.Stdin = strings.NewReader(loc)
+	if err := cmd.Run(); err != nil {
+		m.status = "Copy failed: " + err.Error()
+		return
+	}
+	m.status = "Copied " + loc
+}
+
+// editorCommand builds the command that opens file at line in editor, a
+// command line such as "vim" or "code -w" taken from $EDITOR. Editors that
+// take file:line get that form; the rest get the +line argument vi, emacs,
+// nano and most terminal editors understand.
+func editorCommand(editor, file string, line int) (*exec.Cmd, error) {
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("$EDITOR is not set")
+	}
+	if line < 1 {
+		line = 1
+	}
+	args := fields[1:]
+	switch strings.TrimSuffix(filepath.Base(fields[0]), ".exe") {
+	case "code", "code-insiders", "codium":
+		args = append(args, "--goto", fmt.Sprintf("%s:%d", file, line))
+	case "subl", "zed", "hx", "helix":
+		args = append(args, fmt.Sprintf("%s:%d", file, line))
+	default:
+		args = append(args, fmt.Sprintf("+%d", line), file)
+	}
+	return exec.Command(fields[0], args...), nil
+}
+
+// clipboardCommand returns a command that copies its stdin to the system
+// clipboard, or nil when no known clipboard tool is installed.
+func clipboardCommand() *exec.Cmd {
+	for _, tool := range [][]string{
+		{"pbcopy"},
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+		{"clip.exe"},
+	} {
+		if _, err := exec.LookPath(tool[0]); err == nil {
+			return exec.Command(tool[0], tool[1:]...)
+		}
+	}
+	return nil
+}
+
+// handleEditKey edits the detail region's description: enter saves it to the
+// database and arch.md, esc discards it.
+func (m *interactiveModel) handleEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.editMode = false
+		desc := strings.Join(strings.Fields(m.editBuffer), " ")
+		if err := m.describe(m.detailPath, desc); err != nil {
+			m.status = "Save failed: " + err.Error()
+			break
+		}
+		m.setDescription(m.detailPath, desc)
+		m.status = "Saved description of " + m.detailPath + "."
+	case tea.KeyEsc:
+		m.editMode = false
+		m.status = "Edit cancelled."
+	case tea.KeyBackspace:
+		if r := []rune(m.editBuffer); len(r) > 0 {
+			m.editBuffer = string(r[:len(r)-1])
+		}
+	case tea.KeySpace:
+		m.editBuffer += " "
+	case tea.KeyRunes:
+		m.editBuffer += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// archDescription returns the arch.md description of path.
+func (m interactiveModel) archDescription(path string) string {
+	for _, e := range m.archEntries {
+		if e.Path == path {
+			return e.Description
+		}
+	}
+	return ""
+}
+
+// setDescription updates the loaded arch entries and tree items after a save.
+func (m *interactiveModel) setDescription(path, desc string) {
+	for i := range m.archEntries {
+		if m.archEntries[i].Path == path {
+			m.archEntries[i].Description = desc
+		}
+	}
+	var walk func(items []*treeItem)
+	walk = func(items []*treeItem) {
+		for _, item := range items {
+			if item.fullPath == path {
+				item.description = desc
+			}
+			walk(item.children)
+		}
+	}
+	walk(m.items)
+}
+
 func (m interactiveModel) visibleItems() []*treeItem {
 	var result []*treeItem
 	var collect func(items []*treeItem)
@@ -321,9 +621,10 @@ func (m interactiveModel) View() string {
 	}
 
 	b.WriteString(header + "  " + tabs + "\n")
+	b.WriteString(m.headerLine() + "\n")
 	b.WriteString(strings.Repeat("─", min(m.width, 80)) + "\n")
 
-	contentHeight := m.height - 5 // header + tabs + divider + help + search
+	contentHeight := m.height - 6 // header + stats + divider + help + search + status
 
 	switch m.viewMode {
 	case viewRegions:
@@ -340,10 +641,20 @@ func (m interactiveModel) View() string {
 	if m.searchMode {
 		b.WriteString("\n/" + m.searchBuffer + "█")
 	}
+	if m.status != "" {
+		b.WriteString("\n" + warnStyle.Render(m.status))
+	}
 
 	// Help
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("j/k:navigate  enter:expand/detail  1/2/3:tabs  /:search  q:quit"))
+	switch {
+	case m.editMode:
+		b.WriteString(helpStyle.Render("enter:save  esc:cancel"))
+	case m.viewMode == viewDetail:
+		b.WriteString(helpStyle.Render("e:edit description  o:open  y:copy file:line  esc:back  1/2/3:tabs  q:quit"))
+	default:
+		b.WriteString(helpStyle.Render("j/k:navigate  enter:expand/detail  o:open  y:copy  1/2/3:tabs  /:search  q:quit"))
+	}
 
 	return b.String()
 }
@@ -451,10 +762,15 @@ func (m interactiveModel) renderDetail(b *strings.Builder, maxLines int) {
 	b.WriteString(strings.Repeat("─", 40) + "\n")
 	lines := 2
 
+	if m.editMode {
+		b.WriteString("  Description: " + m.editBuffer + "█\n")
+		lines++
+	}
+
 	// Find in arch entries
 	for _, e := range m.archEntries {
 		if e.Path == m.detailPath && lines < maxLines {
-			if e.Description != "" {
+			if e.Description != "" && !m.editMode {
 				b.WriteString("  Description: " + e.Description + "\n")
 				lines++
 			}
@@ -514,6 +830,22 @@ func loadDBRegions(ctx context.Context, pool *pgxpool.Pool) []dbRegionInfo {
 	return result
 }
 
+// countBrokenSyncRefs counts sync refs to actions no concept defines, as
+// gam sync check reports them.
+func countBrokenSyncRefs(ctx context.Context, pool *pgxpool.Pool) int {
+	var n int
+	pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM sync_refs sr
+		WHERE sr.action_name IS NOT NULL
+		  AND NOT EXISTS (
+			  SELECT 1 FROM concepts c
+			  WHERE c.name = sr.concept_name
+			  AND c.spec->'actions' ? sr.action_name
+		  )
+	`).Scan(&n)
+	return n
+}
+
 func loadDBConcepts(ctx context.Context, pool *pgxpool.Pool) []dbConceptInfo {
 	rows, err := pool.Query(ctx, `SELECT name, purpose FROM concepts ORDER BY name`)
 	if err != nil {
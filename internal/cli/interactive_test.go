@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sbenjam1n/gamsync/internal/region"
+)
+
+func keyMsg(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func press(t *testing.T, m tea.Model, keys ...string) interactiveModel {
+	t.Helper()
+	for _, k := range keys {
+		m, _ = m.Update(keyMsg(k))
+	}
+	switch v := m.(type) {
+	case interactiveModel:
+		return v
+	case *interactiveModel:
+		return *v
+	}
+	t.Fatalf("unexpected model type %T", m)
+	return interactiveModel{}
+}
+
+func detailModel() interactiveModel {
+	markers := []*region.RegionMarker{{Path: "app", File: "app.go", StartLine: 1, EndLine: 3}}
+	arch := []region.ArchEntry{{Path: "app", Description: "Old text", Line: 1}}
+	return newInteractiveModel(region.BuildTree(markers), arch, markers, nil, nil, nil, nil)
+}
+
+func TestInteractiveEditDescription(t *testing.T) {
+	m := detailModel()
+	var savedPath, savedDesc string
+	m.describe = func(path, desc string) error {
+		savedPath, savedDesc = path, desc
+		return nil
+	}
+
+	// Open the leaf's detail view, start editing, and replace the text.
+	m = press(t, m, "enter", "e")
+	if !m.editMode || m.editBuffer != "Old text" {
+		t.Fatalf("editMode=%v buffer=%q, want editing the current description", m.editMode, m.editBuffer)
+	}
+	keys := []string{}
+	for range "Old text" {
+		keys = append(keys, "backspace")
+	}
+	keys = append(keys, "Core", " ", "app", "enter")
+	m = press(t, m, keys...)
+
+	if m.editMode {
+		t.Error("still editing after enter")
+	}
+	if savedPath != "app" || savedDesc != "Core app" {
+		t.Errorf("saved %q = %q, want app = Core app", savedPath, savedDesc)
+	}
+	if m.archDescription("app") != "Core app" || m.items[0].description != "Core app" {
+		t.Errorf("model not updated: arch=%q item=%q", m.archDescription("app"), m.items[0].description)
+	}
+	if !strings.Contains(m.View(), "Description: Core app") {
+		t.Errorf("detail view does not show the new description:\n%s", m.View())
+	}
+
+	// A failed save keeps the old description and reports the error.
+	m.describe = func(path, desc string) error { return errors.New("region app not found") }
+	m = press(t, m, "e", "!", "enter")
+	if m.archDescription("app") != "Core app" || !strings.Contains(m.status, "region app not found") {
+		t.Errorf("after failed save: desc=%q status=%q", m.archDescription("app"), m.status)
+	}
+
+	// Esc discards the edit without saving.
+	savedDesc = ""
+	m.describe = func(path, desc string) error { savedDesc = desc; return nil }
+	m = press(t, m, "e", "x", "esc")
+	if m.editMode || savedDesc != "" {
+		t.Errorf("esc: editMode=%v saved=%q", m.editMode, savedDesc)
+	}
+}
+
+func TestInteractiveEditDisabledWithoutDB(t *testing.T) {
+	m := press(t, detailModel(), "enter", "e")
+	if m.editMode {
+		t.Error("edit mode entered without a database")
+	}
+	if !strings.Contains(m.status, "database") {
+		t.Errorf("status = %q, want a no-database message", m.status)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	for _, tc := range []struct {
+		editor string
+		want   []string
+	}{
+		{"vim", []string{"vim", "+12", "/src/app.go"}},
+		{"emacs -nw", []string{"emacs", "-nw", "+12", "/src/app.go"}},
+		{"code -w", []string{"code", "-w", "--goto", "/src/app.go:12"}},
+		{"/usr/local/bin/subl", []string{"/usr/local/bin/subl", "/src/app.go:12"}},
+	} {
+		cmd, err := editorCommand(tc.editor, "/src/app.go", 12)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.editor, err)
+		}
+		if strings.Join(cmd.Args, " ") != strings.Join(tc.want, " ") {
+			t.Errorf("%s: args = %q, want %q", tc.editor, cmd.Args, tc.want)
+		}
+	}
+
+	if _, err := editorCommand("  ", "/src/app.go", 12); err == nil {
+		t.Error("expected error with no $EDITOR")
+	}
+}
+
+func TestInteractiveOpenSelectedSource(t *testing.T) {
+	t.Setenv("EDITOR", "vim")
+	markers := []*region.RegionMarker{{Path: "app.search", File: "src/search.go", StartLine: 7, EndLine: 20}}
+	m := newInteractiveModel(region.BuildTree(markers), nil, markers, nil, nil, nil, nil)
+	m.root = "/project"
+
+	// The tree holds app (no markers of its own) with app.search beneath it.
+	if _, cmd := m.Update(keyMsg("o")); cmd != nil {
+		t.Error("opening a region without source returned a command")
+	}
+	m = press(t, m, "o")
+	if !strings.Contains(m.status, "app has no source markers") {
+		t.Errorf("status = %q", m.status)
+	}
+
+	m = press(t, m, "j")
+	file, line, ok := m.selectedSource()
+	if !ok || file != filepath.Join("/project", "src", "search.go") || line != 7 {
+		t.Errorf("selectedSource = %q %d %v", file, line, ok)
+	}
+	if _, cmd := m.Update(keyMsg("o")); cmd == nil {
+		t.Error("opening a region with source returned no command")
+	}
+}
+
+func TestInteractiveHeaderCounts(t *testing.T) {
+	markers := []*region.RegionMarker{
+		{Path: "app", File: "app.go", StartLine: 1, EndLine: 9},
+		{Path: "app.search", File: "search.go", StartLine: 1, EndLine: 9},
+		{Path: "app.extra", File: "extra.go", StartLine: 1, EndLine: 9},
+	}
+	arch := []region.ArchEntry{{Path: "app"}, {Path: "app.search"}, {Path: "app.billing"}}
+	concepts := []dbConceptInfo{{Name: "Session"}, {Name: "SearchSource"}}
+	syncs := []dbSyncInfo{{Name: "FanOut", Enabled: true}}
+	m := newInteractiveModel(region.BuildTree(markers), arch, markers, []string{"unclosed region"}, nil, concepts, syncs)
+	m.dbLoaded = true
+	m.brokenRefs = 2
+	m.stats = m.computeStats()
+
+	header := m.View()
+	for _, want := range []string{
+		"3 regions · 2 concepts · 1 syncs · 1 warnings",
+		"2 arch mismatches, 2 broken sync refs",
+	} {
+		if !strings.Contains(header, want) {
+			t.Errorf("header missing %q:\n%s", want, header)
+		}
+	}
+
+	// Counts are recomputed on tab switches.
+	m.dbSyncs = append(m.dbSyncs, dbSyncInfo{Name: "Notify"})
+	m = press(t, m, "3")
+	if !strings.Contains(m.View(), "2 syncs") {
+		t.Errorf("sync count not refreshed on tab switch:\n%s", m.View())
+	}
+
+	clean := []*region.RegionMarker{{Path: "app", File: "app.go"}, {Path: "app.search", File: "search.go"}}
+	healthy := newInteractiveModel(region.BuildTree(clean), arch[:2], clean, nil, nil, nil, nil)
+	line := healthy.headerLine()
+	if !strings.Contains(line, "2 regions · no database · 0 warnings") || !strings.Contains(line, "healthy") {
+		t.Errorf("healthy header = %q", line)
+	}
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(&buf, "warn", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("dropped")
+	logger.Warn("kept", "proposal", "p1")
+	if out := buf.String(); strings.Contains(out, "dropped") || !strings.Contains(out, `"proposal":"p1"`) {
+		t.Errorf("JSON logger output = %q", out)
+	}
+
+	buf.Reset()
+	logger, _ = newLogger(&buf, "debug", true)
+	logger.Debug("processing proposal", "region", "app.search")
+	if out := buf.String(); !strings.Contains(out, "region=app.search") {
+		t.Errorf("text logger output = %q", out)
+	}
+
+	if _, err := newLogger(&buf, "loud", false); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
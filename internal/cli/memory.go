@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/spf13/cobra"
+)
+
+var memoryCmd = &cobra.Command{
+	Use:   "memory",
+	Short: "Turn memory (scratchpad) maintenance",
+}
+
+var memoryExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export completed turn scratchpads with their regions as JSONL or markdown",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		regionPath, _ := cmd.Flags().GetString("region")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		format, _ := cmd.Flags().GetString("format")
+		out, _ := cmd.Flags().GetString("out")
+
+		if format != "jsonl" && format != "markdown" {
+			return fmt.Errorf("unknown --format %q: use jsonl or markdown", format)
+		}
+		now := time.Now()
+		var since, until time.Time
+		var err error
+		if sinceFlag != "" {
+			if since, err = parseSince(sinceFlag, now); err != nil {
+				return err
+			}
+		}
+		if untilFlag != "" {
+			if until, err = parseSince(untilFlag, now); err != nil {
+				return fmt.Errorf("invalid --until %q: use a duration (36h, 7d) or a date (2006-01-02)", untilFlag)
+			}
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		entries, err := memorizer.ExportScratchpads(ctx, pool, regionPath, since, until)
+		if err != nil {
+			return fmt.Errorf("export scratchpads: %w", err)
+		}
+
+		w := io.Writer(os.Stdout)
+		if out != "" {
+			f, err := os.Create(out)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := writeScratchpads(w, entries, format); err != nil {
+			return err
+		}
+		if out != "" {
+			fmt.Printf("Exported %d scratchpad(s) to %s\n", len(entries), out)
+		}
+		return nil
+	},
+}
+
+// writeScratchpads writes entries as one JSON object per line (jsonl) or as
+// a markdown document with a section per turn.
+func writeScratchpads(w io.Writer, entries []memorizer.ScratchpadExport, format string) error {
+	if format == "jsonl" {
+		enc := json.NewEncoder(w)
+		for _, e := range entries {
+			if e.Regions == nil {
+				e.Regions = []string{}
+			}
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, "# Turn scratchpads")
+	for _, e := range entries {
+		fmt.Fprintf(w, "\n## %s\n\n", e.TurnID)
+		fmt.Fprintf(w, "- Scope: %s\n", e.Scope)
+		if e.AgentRole != "" {
+			fmt.Fprintf(w, "- Role: %s\n", e.AgentRole)
+		}
+		fmt.Fprintf(w, "- Completed: %s\n", e.CompletedAt.Format(time.RFC3339))
+		if len(e.Regions) > 0 {
+			fmt.Fprintf(w, "- Regions: %s\n", strings.Join(e.Regions, ", "))
+		}
+		fmt.Fprintf(w, "\n%s\n", strings.TrimSpace(e.Scratchpad))
+	}
+	return nil
+}
+
+func init() {
+	memoryExportCmd.Flags().String("region", "", "Only turns scoped to or touching this region subtree")
+	memoryExportCmd.Flags().String("since", "", "Only turns completed since a duration ago (36h, 7d) or a date (2006-01-02)")
+	memoryExportCmd.Flags().String("until", "", "Only turns completed before a duration ago or a date")
+	memoryExportCmd.Flags().String("format", "jsonl", "Output format: jsonl|markdown")
+	memoryExportCmd.Flags().String("out", "", "Write to a file instead of stdout")
+
+	memoryCmd.AddCommand(memoryExportCmd)
+}
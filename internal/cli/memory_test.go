@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestWriteScratchpadsMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeScratchpads(&buf, []memorizer.ScratchpadExport{{
+		TurnID:      "T1",
+		Scope:       "app.search",
+		CompletedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Regions:     []string{"app.search", "app.search.sources"},
+		Scratchpad:  "Added adapter.\n",
+	}}, "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## T1", "- Scope: app.search", "- Regions: app.search, app.search.sources", "Added adapter."} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("markdown missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestExportScratchpadsIncludesRegions(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	var regionID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO regions (path) VALUES ('memexport.a') ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path RETURNING id
+	`).Scan(&regionID); err != nil {
+		t.Fatalf("insert region: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'memexport.a'`)
+
+	const turnID = "memexport-turn"
+	pool.Exec(ctx, `
+		INSERT INTO turns (id, agent_role, scope_path, status, scratchpad, completed_at)
+		VALUES ($1, 'implementer', 'memexport', 'COMPLETED', 'Seeded scratchpad', NOW())
+	`, turnID)
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = $1`, turnID)
+	pool.Exec(ctx, `INSERT INTO turn_regions (turn_id, region_id, action) VALUES ($1, $2, 'modified')`, turnID, regionID)
+	defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = $1`, turnID)
+
+	entries, err := memorizer.ExportScratchpads(ctx, pool, "memexport", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("ExportScratchpads: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeScratchpads(&buf, entries, "jsonl"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 exported turn, got %d:\n%s", len(lines), buf.String())
+	}
+	var got memorizer.ScratchpadExport
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("parse jsonl: %v", err)
+	}
+	if got.TurnID != turnID || got.Scratchpad != "Seeded scratchpad" {
+		t.Errorf("exported %+v", got)
+	}
+	if len(got.Regions) != 1 || got.Regions[0] != "memexport.a" {
+		t.Errorf("regions = %v, want [memexport.a]", got.Regions)
+	}
+}
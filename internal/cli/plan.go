@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/spf13/cobra"
 )
 
@@ -117,20 +121,37 @@ var planShowCmd = &cobra.Command{
 		json.Unmarshal(decisionsJSON, &decisions)
 		if len(decisions) > 0 {
 			fmt.Println("\nDecisions:")
-			for _, d := range decisions {
-				fmt.Printf("  - %s\n    Rationale: %s\n", d.Description, d.Rationale)
-			}
+			fmt.Print(formatDecisions(decisions))
 		}
 
 		return nil
 	},
 }
 
+// formatDecisions renders plan decisions for plan show.
+func formatDecisions(decisions []gam.Decision) string {
+	var b strings.Builder
+	for _, d := range decisions {
+		fmt.Fprintf(&b, "  - %s\n    Rationale: %s\n", d.Description, d.Rationale)
+		if len(d.Alternatives) > 0 {
+			fmt.Fprintf(&b, "    Alternatives considered:\n")
+			for _, alt := range d.Alternatives {
+				fmt.Fprintf(&b, "      * %s\n", alt)
+			}
+		}
+		if d.TurnID != "" {
+			fmt.Fprintf(&b, "    Turn: %s\n", d.TurnID)
+		}
+	}
+	return b.String()
+}
+
 var planListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List execution plans",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		activeOnly, _ := cmd.Flags().GetBool("active")
+		all, _ := cmd.Flags().GetBool("all")
 
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
@@ -139,7 +160,10 @@ var planListCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		query := `SELECT name, goal, status, quality_grade FROM execution_plans ORDER BY created_at DESC`
+		query := `SELECT name, goal, status, quality_grade FROM execution_plans WHERE status != 'ARCHIVED' ORDER BY created_at DESC`
+		if all {
+			query = `SELECT name, goal, status, quality_grade FROM execution_plans ORDER BY created_at DESC`
+		}
 		if activeOnly {
 			query = `SELECT name, goal, status, quality_grade FROM execution_plans WHERE status = 'ACTIVE' ORDER BY created_at DESC`
 		}
@@ -165,6 +189,62 @@ var planListCmd = &cobra.Command{
 	},
 }
 
+var planNextCmd = &cobra.Command{
+	Use:   "next [name]",
+	Short: "List a plan's active turns and pending turns whose dependencies are done",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		turns, err := memorizer.NextPlanTurns(ctx, pool, args[0])
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			if turns == nil {
+				turns = []gam.PlanTurn{}
+			}
+			data, err := json.MarshalIndent(turns, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		writePlanNext(os.Stdout, args[0], turns)
+		return nil
+	},
+}
+
+// writePlanNext renders plan next output, active turns first.
+func writePlanNext(w io.Writer, name string, turns []gam.PlanTurn) {
+	if len(turns) == 0 {
+		fmt.Fprintf(w, "No actionable turns in plan '%s'.\n", name)
+		return
+	}
+	fmt.Fprintf(w, "Next in plan '%s':\n", name)
+	for _, status := range []string{"active", "pending"} {
+		for _, pt := range turns {
+			if pt.Status != status {
+				continue
+			}
+			marker := "[ ]"
+			if status == "active" {
+				marker = "[>]"
+			}
+			fmt.Fprintf(w, "  %s %s — %s (%s)\n", marker, pt.TurnID, pt.RegionPath, pt.Status)
+		}
+	}
+}
+
 var planDecideCmd = &cobra.Command{
 	Use:   "decide [name]",
 	Short: "Record a design decision in a plan",
@@ -173,6 +253,8 @@ var planDecideCmd = &cobra.Command{
 		name := args[0]
 		decision, _ := cmd.Flags().GetString("decision")
 		rationale, _ := cmd.Flags().GetString("rationale")
+		alternatives, _ := cmd.Flags().GetStringArray("alternative")
+		turnID, _ := cmd.Flags().GetString("turn")
 
 		if decision == "" || rationale == "" {
 			return fmt.Errorf("--decision and --rationale are required")
@@ -185,20 +267,32 @@ var planDecideCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
+		planID, err := memorizer.LookupPlanID(ctx, pool, name)
+		if err != nil {
+			return err
+		}
+
+		if turnID != "" {
+			var exists bool
+			if err := pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM turns WHERE id = $1)`, turnID).Scan(&exists); err != nil {
+				return fmt.Errorf("check turn: %w", err)
+			}
+			if !exists {
+				return fmt.Errorf("turn '%s' not found", turnID)
+			}
+		}
+
 		dec := gam.Decision{
-			Description: decision,
-			Rationale:   rationale,
-			DecidedAt:   time.Now().Format(time.RFC3339),
+			Description:  decision,
+			Rationale:    rationale,
+			Alternatives: alternatives,
+			TurnID:       turnID,
+			DecidedAt:    time.Now().Format(time.RFC3339),
 		}
-		decJSON, _ := json.Marshal([]gam.Decision{dec})
 
-		_, err = pool.Exec(ctx, `
-			UPDATE execution_plans
-			SET decisions = COALESCE(decisions, '[]'::jsonb) || $1::jsonb
-			WHERE name = $2 AND status = 'ACTIVE'
-		`, decJSON, name)
-		if err != nil {
-			return fmt.Errorf("record decision: %w", err)
+		m := memorizer.New(pool, nil, projectRoot())
+		if err := m.RecordDecision(ctx, planID, dec); err != nil {
+			return fmt.Errorf("record decision in plan '%s': %w", name, err)
 		}
 
 		fmt.Printf("Decision recorded in plan '%s'\n", name)
@@ -220,16 +314,80 @@ var planCloseCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		_, err = pool.Exec(ctx, `
+		var planID string
+		err = pool.QueryRow(ctx, `
 			UPDATE execution_plans
 			SET status = 'COMPLETED', completed_at = NOW()
 			WHERE name = $1 AND status = 'ACTIVE'
-		`, name)
+			RETURNING id
+		`, name).Scan(&planID)
 		if err != nil {
-			return fmt.Errorf("close plan: %w", err)
+			return fmt.Errorf("close plan: no active plan '%s': %w", name, err)
 		}
 
 		fmt.Printf("Plan '%s' marked as completed.\n", name)
+
+		grade, err := memorizer.PlanGrade(ctx, pool, planID)
+		if err != nil {
+			return fmt.Errorf("compute plan grade: %w", err)
+		}
+		if grade != "" {
+			pool.Exec(ctx, `UPDATE execution_plans SET quality_grade = $1 WHERE id = $2`, grade, planID)
+			fmt.Printf("Quality grade: %s\n", grade)
+		}
+		return nil
+	},
+}
+
+var planDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a plan and its plan turns",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		force, _ := cmd.Flags().GetBool("force")
+		deleteTurns, _ := cmd.Flags().GetBool("turns")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		res, err := memorizer.DeletePlan(ctx, pool, name, force, deleteTurns)
+		if err != nil {
+			return fmt.Errorf("delete plan: %w", err)
+		}
+
+		fmt.Printf("Plan '%s' deleted (%d plan turn(s)", name, res.PlanTurns)
+		if deleteTurns {
+			fmt.Printf(", %d unused turn(s)", res.Turns)
+		}
+		fmt.Println(").")
+		return nil
+	},
+}
+
+var planArchiveCmd = &cobra.Command{
+	Use:   "archive [name]",
+	Short: "Archive a plan, hiding it from default listings",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if err := memorizer.ArchivePlan(ctx, pool, name); err != nil {
+			return fmt.Errorf("archive plan: %w", err)
+		}
+
+		fmt.Printf("Plan '%s' archived.\n", name)
 		return nil
 	},
 }
@@ -239,13 +397,24 @@ func init() {
 	planCreateCmd.MarkFlagRequired("goal")
 
 	planListCmd.Flags().Bool("active", false, "Show only active plans")
+	planListCmd.Flags().Bool("all", false, "Include archived plans")
+
+	planNextCmd.Flags().Bool("json", false, "Output as JSON")
+
+	planDeleteCmd.Flags().Bool("force", false, "Delete even if the plan has in-progress turns")
+	planDeleteCmd.Flags().Bool("turns", false, "Also delete the plan's generated turns if unused")
 
 	planDecideCmd.Flags().String("decision", "", "Decision description")
 	planDecideCmd.Flags().String("rationale", "", "Decision rationale")
+	planDecideCmd.Flags().StringArray("alternative", nil, "Alternative considered (repeatable)")
+	planDecideCmd.Flags().String("turn", "", "Turn in which the decision was made")
 
 	planCmd.AddCommand(planCreateCmd)
 	planCmd.AddCommand(planShowCmd)
 	planCmd.AddCommand(planListCmd)
+	planCmd.AddCommand(planNextCmd)
 	planCmd.AddCommand(planDecideCmd)
 	planCmd.AddCommand(planCloseCmd)
+	planCmd.AddCommand(planDeleteCmd)
+	planCmd.AddCommand(planArchiveCmd)
 }
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+func TestDecisionAlternativesRoundTripIntoPlanShow(t *testing.T) {
+	dec := gam.Decision{
+		Description:  "Use LTREE for region paths",
+		Rationale:    "Subtree queries are native",
+		Alternatives: []string{"materialized path strings", "closure table"},
+		TurnID:       "T_20260101_120000_abcdef",
+	}
+
+	// Stored the same way plan decide appends to execution_plans.decisions.
+	stored, _ := json.Marshal([]gam.Decision{dec})
+	var decisions []gam.Decision
+	if err := json.Unmarshal(stored, &decisions); err != nil {
+		t.Fatalf("unmarshal decisions: %v", err)
+	}
+
+	out := formatDecisions(decisions)
+	for _, want := range []string{
+		"- Use LTREE for region paths",
+		"Rationale: Subtree queries are native",
+		"* materialized path strings",
+		"* closure table",
+		"Turn: T_20260101_120000_abcdef",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("plan show output missing %q:\n%s", want, out)
+		}
+	}
+
+	plain := formatDecisions([]gam.Decision{{Description: "d", Rationale: "r"}})
+	if strings.Contains(plain, "Alternatives") || strings.Contains(plain, "Turn:") {
+		t.Errorf("unexpected sections for decision without alternatives:\n%s", plain)
+	}
+}
+
+func TestWritePlanNextActiveFirst(t *testing.T) {
+	var buf bytes.Buffer
+	writePlanNext(&buf, "p", []gam.PlanTurn{
+		{TurnID: "T1", RegionPath: "app.a", Status: "pending"},
+		{TurnID: "T2", RegionPath: "app.b", Status: "active"},
+	})
+	out := buf.String()
+	if strings.Index(out, "[>] T2") > strings.Index(out, "[ ] T1") {
+		t.Errorf("active turn should be listed first:\n%s", out)
+	}
+}
@@ -0,0 +1,392 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/queue"
+	"github.com/sbenjam1n/gamsync/internal/validator"
+	"github.com/spf13/cobra"
+)
+
+var proposalCmd = &cobra.Command{
+	Use:   "proposal",
+	Short: "Proposal operations",
+}
+
+var proposalRetryCmd = &cobra.Command{
+	Use:   "retry [id]",
+	Short: "Re-queue a rejected proposal for validation",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		rdb, err := connectRedis()
+		if err != nil {
+			return err
+		}
+		defer rdb.Close()
+
+		q := queue.New(rdb)
+		msg, err := memorizer.RetryProposal(ctx, pool, args[0], func(ctx context.Context, msg queue.ProposalMessage) error {
+			_, err := q.PushProposal(ctx, msg)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Proposal %s re-queued for validation (attempt %d, region %s).\n", msg.ProposalID, msg.Attempt, msg.RegionPath)
+		return nil
+	},
+}
+
+var proposalResultCmd = &cobra.Command{
+	Use:   "result [id]",
+	Short: "Show the validation result recorded for a proposal",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		result, err := memorizer.ProposalResult(ctx, pool, args[0])
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			out, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+
+		verdict := "REJECTED"
+		if result.Passed {
+			verdict = "PASSED"
+		}
+		fmt.Printf("%s (Tier %d, Code %d)\n", verdict, result.Tier, result.Code)
+		if result.Message != "" {
+			fmt.Printf("%s\n", result.Message)
+		}
+		for _, d := range result.Details {
+			if d.Passed {
+				continue
+			}
+			fmt.Printf("  Check: %s | Expected: %s | Got: %s\n", d.Check, d.Expected, d.Got)
+			if d.Fix != "" {
+				fmt.Printf("  Fix: %s\n", d.Fix)
+			}
+		}
+		return nil
+	},
+}
+
+var proposalShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Show a proposal's status and review history",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		p, err := memorizer.LoadProposal(ctx, pool, args[0])
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			out, _ := json.MarshalIndent(p, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+		writeProposalSummary(os.Stdout, p, reviewLimit())
+		return nil
+	},
+}
+
+var proposalReviewCmd = &cobra.Command{
+	Use:   "review [id]",
+	Short: "Record a Tier 3 review comment on a pending proposal",
+	Long: `Appends a review comment to the proposal's history as the next iteration.
+--severity request_changes queues a review_response task for the Researcher,
+reject rejects the proposal, and escalate_human hands it to a human. A request
+for changes on the last allowed iteration (GAM_MAX_REVIEW_ITERATIONS, default
+3) escalates instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		severity, _ := cmd.Flags().GetString("severity")
+		concern, _ := cmd.Flags().GetString("concern")
+		remediation, _ := cmd.Flags().GetString("remediation")
+		if concern == "" {
+			return fmt.Errorf("--concern is required")
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		rdb, err := connectRedis()
+		if err != nil {
+			return err
+		}
+		defer rdb.Close()
+
+		m := memorizer.New(pool, rdb, projectRoot())
+		m.SetMaxReviewIterations(cfg.MaxReviewIterations)
+		out, err := m.ReviewProposal(ctx, args[0], gam.ReviewComment{
+			Concern:     concern,
+			Remediation: remediation,
+			Severity:    severity,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case out.Escalated:
+			fmt.Printf("Proposal %s escalated for human review (iteration %d/%d).\n", args[0], out.Iteration, reviewLimit())
+		case out.Status == "REJECTED":
+			fmt.Printf("Proposal %s rejected at review iteration %d.\n", args[0], out.Iteration)
+		default:
+			fmt.Printf("Changes requested on proposal %s (iteration %d/%d); review_response task queued.\n", args[0], out.Iteration, reviewLimit())
+		}
+		return nil
+	},
+}
+
+// reviewLimit is the configured review iteration limit.
+func reviewLimit() int {
+	if cfg.MaxReviewIterations > 0 {
+		return cfg.MaxReviewIterations
+	}
+	return memorizer.DefaultMaxReviewIterations
+}
+
+// writeProposalSummary prints a proposal's state and review history.
+func writeProposalSummary(w io.Writer, p *gam.Proposal, maxIterations int) {
+	fmt.Fprintf(w, "Proposal: %s\n", p.ID)
+	fmt.Fprintf(w, "Region: %s\n", p.RegionPath)
+	fmt.Fprintf(w, "Status: %s\n", p.Status)
+	fmt.Fprintf(w, "Action: %s\n", p.ActionTaken)
+	if p.CurrentState != "" || p.ProposedState != "" {
+		fmt.Fprintf(w, "Transition: %s -> %s\n", p.CurrentState, p.ProposedState)
+	}
+	if p.TurnID != "" {
+		fmt.Fprintf(w, "Turn: %s\n", p.TurnID)
+	}
+	if p.BranchName != "" {
+		fmt.Fprintf(w, "Branch: %s\n", p.BranchName)
+	}
+	if p.CommitSHA != "" {
+		fmt.Fprintf(w, "Commit: %s\n", p.CommitSHA)
+	}
+	fmt.Fprintf(w, "Review iterations: %d/%d\n", p.ReviewIterations, maxIterations)
+	if p.RejectionReason != "" {
+		fmt.Fprintf(w, "Reason: %s\n", p.RejectionReason)
+	}
+	if len(p.ReviewHistory) > 0 {
+		fmt.Fprintln(w, "\nReview History:")
+		for _, c := range p.ReviewHistory {
+			fmt.Fprintf(w, "  #%d [%s] %s\n", c.Iteration, c.Severity, c.Concern)
+			if c.Remediation != "" {
+				fmt.Fprintf(w, "      Fix: %s\n", c.Remediation)
+			}
+		}
+	}
+}
+
+var proposalLinkCmd = &cobra.Command{
+	Use:   "link [id]",
+	Short: "Record the git branch and commit a proposal's changes are on",
+	Long: `Record the branch and/or commit holding a proposal's changes, shown by
+gam proposal show and used by gam proposal diff. Inside a git repository the
+commit must exist and is stored as its full SHA; outside one it must already
+be a full 40-character SHA.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		branch, _ := cmd.Flags().GetString("branch")
+		commit, _ := cmd.Flags().GetString("commit")
+		if branch == "" && commit == "" {
+			return fmt.Errorf("specify --branch, --commit, or both")
+		}
+		if commit != "" {
+			var err error
+			if commit, err = resolveCommit(projectRoot(), commit); err != nil {
+				return err
+			}
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if err := memorizer.LinkProposal(ctx, pool, args[0], branch, commit); err != nil {
+			return err
+		}
+		fmt.Printf("Linked proposal %s", args[0])
+		if branch != "" {
+			fmt.Printf(" to branch %s", branch)
+		}
+		if commit != "" {
+			fmt.Printf(" at %s", commit)
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+// fullSHA matches a full hexadecimal commit SHA, the form commit_sha stores.
+var fullSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// resolveCommit expands commit to the full SHA of an existing commit in the
+// git repository containing root. Outside a repository it cannot be checked,
+// so only a full SHA is accepted.
+func resolveCommit(root, commit string) (string, error) {
+	if exec.Command("git", "-C", root, "rev-parse", "--is-inside-work-tree").Run() != nil {
+		if !fullSHA.MatchString(strings.ToLower(commit)) {
+			return "", fmt.Errorf("%s is not a git repository; --commit must be a full 40-character SHA", root)
+		}
+		return strings.ToLower(commit), nil
+	}
+	out, err := exec.Command("git", "-C", root, "rev-parse", "--verify", "--quiet", commit+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("commit %s not found in %s", commit, root)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+var proposalEvidenceCmd = &cobra.Command{
+	Use:   "evidence",
+	Short: "Proposal evidence checks",
+}
+
+var proposalEvidenceValidateCmd = &cobra.Command{
+	Use:   "validate [proposal.json]",
+	Short: "Check a proposal's evidence against its region's invariants before submitting",
+	Long: `Loads a proposal JSON file, looks up the concepts covering its region_path
+(including ancestors), and checks the evidence against every invariant.
+Reports each missing or failing evidence block. Nothing is written to the
+proposals table.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		var p gam.Proposal
+		if err := json.Unmarshal(data, &p); err != nil {
+			return fmt.Errorf("parse %s: %w", args[0], err)
+		}
+		if p.RegionPath == "" {
+			return fmt.Errorf("%s: region_path is required", args[0])
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		concepts, err := validator.New(pool, projectRoot()).GetConceptsForRegion(ctx, p.RegionPath)
+		if err != nil {
+			return fmt.Errorf("concept lookup: %w", err)
+		}
+		details := validator.CheckEvidence(concepts, p.Evidence)
+
+		if asJSON {
+			if details == nil {
+				details = []gam.ValidationDetail{}
+			}
+			out, _ := json.MarshalIndent(details, "", "  ")
+			fmt.Println(string(out))
+		} else {
+			writeEvidenceReport(os.Stdout, p.RegionPath, details)
+		}
+
+		failed := 0
+		for _, d := range details {
+			if !d.Passed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d invariant(s) not satisfied by the evidence", failed)
+		}
+		return nil
+	},
+}
+
+// writeEvidenceReport prints one line per invariant check with the expected
+// evidence and fix for failures.
+func writeEvidenceReport(w io.Writer, regionPath string, details []gam.ValidationDetail) {
+	if len(details) == 0 {
+		fmt.Fprintf(w, "No invariants apply to %s.\n", regionPath)
+		return
+	}
+	fmt.Fprintf(w, "Evidence for %s:\n", regionPath)
+	for _, d := range details {
+		if d.Passed {
+			fmt.Fprintf(w, "  PASS %s\n", d.Check)
+			continue
+		}
+		fmt.Fprintf(w, "  FAIL %s\n    Expected: %s | Got: %s\n", d.Check, d.Expected, d.Got)
+		if d.Fix != "" {
+			fmt.Fprintf(w, "    Fix: %s\n", d.Fix)
+		}
+	}
+}
+
+func init() {
+	proposalResultCmd.Flags().Bool("json", false, "Output the ValidationResult as JSON")
+
+	proposalCmd.AddCommand(proposalRetryCmd)
+	proposalEvidenceValidateCmd.Flags().Bool("json", false, "Output the checks as JSON")
+	proposalShowCmd.Flags().Bool("json", false, "Output the proposal as JSON")
+	proposalReviewCmd.Flags().String("severity", memorizer.ReviewRequestChanges, "request_changes, reject, or escalate_human")
+	proposalReviewCmd.Flags().String("concern", "", "What the review found (required)")
+	proposalReviewCmd.Flags().String("remediation", "", "How to address the concern")
+
+	proposalCmd.AddCommand(proposalResultCmd)
+	proposalCmd.AddCommand(proposalShowCmd)
+	proposalCmd.AddCommand(proposalDiffCmd)
+	proposalLinkCmd.Flags().String("branch", "", "Branch holding the proposal's changes")
+	proposalLinkCmd.Flags().String("commit", "", "Commit holding the proposal's changes (any revision git can resolve)")
+	proposalCmd.AddCommand(proposalLinkCmd)
+	proposalCmd.AddCommand(proposalReviewCmd)
+	proposalEvidenceCmd.AddCommand(proposalEvidenceValidateCmd)
+	proposalCmd.AddCommand(proposalEvidenceCmd)
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/spf13/cobra"
+)
+
+var proposalDiffCmd = &cobra.Command{
+	Use:   "diff [id]",
+	Short: "Show the regions a proposal modifies and, with git info, their diff",
+	Long: `List the regions and files a proposal claims to modify. When the proposal
+records a commit or branch that exists in the project's git repository, also
+show what that revision changed since it diverged from the current HEAD
+(git diff HEAD...rev), limited to the hunks inside each modified region.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		p, err := memorizer.LoadProposal(ctx, pool, args[0])
+		if err != nil {
+			return err
+		}
+		return writeProposalDiff(os.Stdout, projectRoot(), p)
+	},
+}
+
+// writeProposalDiff lists the proposal's claimed modifications, then, when
+// its commit or branch resolves in the git repository at root, the region
+// diff for each one.
+func writeProposalDiff(w io.Writer, root string, p *gam.Proposal) error {
+	mods := p.Evidence.ModifiedRegions
+	fmt.Fprintf(w, "Proposal %s (region %s)\n", p.ID, p.RegionPath)
+	if len(mods) == 0 {
+		fmt.Fprintln(w, "  Claims no modified regions.")
+		return nil
+	}
+	fmt.Fprintln(w, "Claimed modifications:")
+	for _, mr := range mods {
+		fmt.Fprintf(w, "  %s  %s", mr.Path, region.NormalizePath(root, mr.File))
+		if mr.Hash != "" {
+			fmt.Fprintf(w, "  hash=%s", mr.Hash)
+		}
+		fmt.Fprintln(w)
+		if mr.Description != "" {
+			fmt.Fprintf(w, "    %s\n", mr.Description)
+		}
+	}
+
+	rev := p.CommitSHA
+	if rev == "" {
+		rev = p.BranchName
+	}
+	if rev == "" {
+		fmt.Fprintln(w, "\nNo branch or commit recorded; showing claimed modifications only.")
+		return nil
+	}
+	if strings.HasPrefix(rev, "-") {
+		// Would be parsed as an option by git.
+		fmt.Fprintf(w, "\n%q is not a valid revision; showing claimed modifications only.\n", rev)
+		return nil
+	}
+	if err := exec.Command("git", "-C", root, "rev-parse", "--verify", "--quiet", rev+"^{commit}").Run(); err != nil {
+		fmt.Fprintf(w, "\n%s is not available in %s; showing claimed modifications only.\n", rev, root)
+		return nil
+	}
+
+	for _, mr := range mods {
+		file := region.NormalizePath(root, mr.File)
+		fmt.Fprintf(w, "\n=== %s (%s) HEAD...%s ===\n", mr.Path, file, rev)
+		diff, err := regionDiff(root, rev, file, mr.Path)
+		if err != nil {
+			fmt.Fprintf(w, "  %v\n", err)
+			continue
+		}
+		if diff == "" {
+			fmt.Fprintln(w, "  No changes inside the region.")
+			continue
+		}
+		fmt.Fprint(w, diff)
+	}
+	return nil
+}
+
+// regionDiff returns the git diff of file from the merge base of HEAD and rev
+// to rev, so commits made on HEAD since rev branched off do not show up as
+// reverted, keeping only the hunks that touch regionPath's lines in rev. When
+// rev's copy of the file has no markers for the region the whole file diff
+// is returned. rev must not start with "-".
+func regionDiff(root, rev, file, regionPath string) (string, error) {
+	out, err := exec.Command("git", "-C", root, "diff", "--no-color", "--no-ext-diff", "HEAD..."+rev, "--", file).Output()
+	if err != nil {
+		return "", fmt.Errorf("git diff %s: %w", file, err)
+	}
+	if len(out) == 0 {
+		return "", nil
+	}
+
+	content, err := exec.Command("git", "-C", root, "show", rev+":./"+file).Output()
+	if err != nil {
+		return string(out), nil
+	}
+	markers, _, _ := region.ScanReader(bytes.NewReader(content), file)
+	for _, m := range markers {
+		if m.Path != regionPath {
+			continue
+		}
+		end := m.EndLine
+		if end == 0 {
+			end = strings.Count(string(content), "\n") + 1
+		}
+		return filterHunks(string(out), m.StartLine, end), nil
+	}
+	return string(out), nil
+}
+
+// filterHunks keeps the header of a single-file unified diff and the hunks
+// whose new-side line range overlaps [start, end]. It returns "" when no hunk
+// does.
+func filterHunks(diff string, start, end int) string {
+	var header, kept strings.Builder
+	var hunk strings.Builder
+	inHunk, keep := false, false
+	flush := func() {
+		if inHunk && keep {
+			kept.WriteString(hunk.String())
+		}
+		hunk.Reset()
+	}
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			flush()
+			inHunk = true
+			from, count := hunkNewRange(line)
+			keep = from <= end && from+max(count, 1)-1 >= start
+		}
+		if inHunk {
+			hunk.WriteString(line)
+		} else {
+			header.WriteString(line)
+		}
+	}
+	flush()
+	if kept.Len() == 0 {
+		return ""
+	}
+	return header.String() + kept.String()
+}
+
+// hunkNewRange parses the new-side start line and line count from a hunk
+// header such as "@@ -3,4 +5,6 @@".
+func hunkNewRange(header string) (from, count int) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0
+	}
+	startStr, countStr, hasCount := strings.Cut(fields[2][1:], ",")
+	from, _ = strconv.Atoi(startStr)
+	count = 1
+	if hasCount {
+		count, _ = strconv.Atoi(countStr)
+	}
+	return from, count
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+func TestFilterHunks(t *testing.T) {
+	diff := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n" +
+		"@@ -1,3 +1,4 @@\n a\n+b\n c\n d\n" +
+		"@@ -20,2 +21,3 @@\n x\n+y\n z\n"
+	got := filterHunks(diff, 18, 30)
+	if !strings.HasPrefix(got, "diff --git") || strings.Contains(got, "+b") || !strings.Contains(got, "+y") {
+		t.Errorf("filterHunks kept the wrong hunks:\n%s", got)
+	}
+	if got := filterHunks(diff, 8, 12); got != "" {
+		t.Errorf("expected no hunks in 8-12, got:\n%s", got)
+	}
+}
+
+func TestWriteProposalDiff(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	src := filepath.Join(dir, "search.go")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(src, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var base strings.Builder
+	base.WriteString("package search\n\n// @region:app.search.query\nfunc Query() {}\n// @endregion:app.search.query\n")
+	for i := 0; i < 10; i++ {
+		base.WriteString("\n")
+	}
+	base.WriteString("// @region:app.search.index\nfunc Index() {}\n// @endregion:app.search.index\n")
+	write(base.String())
+	git("init", "-q", "-b", "main")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+
+	git("checkout", "-q", "-b", "proposal/query")
+	changed := strings.Replace(base.String(), "func Query() {}", "func Query() { cached() }", 1)
+	changed = strings.Replace(changed, "func Index() {}", "func Index() { rebuild() }", 1)
+	write(changed)
+	git("commit", "-q", "-am", "proposal")
+	git("checkout", "-q", "main")
+
+	// main moves on after the branch; its commit is not part of the proposal.
+	write(strings.Replace(base.String(), "func Query() {}", "// tuned on main\nfunc Query() {}", 1))
+	git("commit", "-q", "-am", "main moves on")
+
+	p := &gam.Proposal{
+		ID:         "p1",
+		RegionPath: "app.search.query",
+		Evidence: gam.ProposalEvidence{ModifiedRegions: []gam.ModifiedRegion{
+			{Path: "app.search.query", File: src, Description: "cache query results", Hash: "abc123"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeProposalDiff(&buf, dir, p); err != nil {
+		t.Fatalf("writeProposalDiff: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"app.search.query  search.go  hash=abc123", "cache query results", "No branch or commit recorded"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("without git info, output missing %q:\n%s", want, out)
+		}
+	}
+
+	p.BranchName = "proposal/query"
+	buf.Reset()
+	if err := writeProposalDiff(&buf, dir, p); err != nil {
+		t.Fatalf("writeProposalDiff: %v", err)
+	}
+	out = buf.String()
+	if !strings.Contains(out, "+func Query() { cached() }") {
+		t.Errorf("diff missing the region's change:\n%s", out)
+	}
+	if strings.Contains(out, "rebuild") {
+		t.Errorf("diff includes a change outside the region:\n%s", out)
+	}
+	if strings.Contains(out, "tuned on main") {
+		t.Errorf("diff shows a later main commit as reverted:\n%s", out)
+	}
+
+	p.BranchName = "--output=" + filepath.Join(dir, "clobbered")
+	buf.Reset()
+	writeProposalDiff(&buf, dir, p)
+	if !strings.Contains(buf.String(), "is not a valid revision") {
+		t.Errorf("option-like revision not rejected:\n%s", buf.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "clobbered")); err == nil {
+		t.Error("option-like revision was passed to git")
+	}
+
+	p.BranchName = "proposal/missing"
+	buf.Reset()
+	writeProposalDiff(&buf, dir, p)
+	if !strings.Contains(buf.String(), "proposal/missing is not available") {
+		t.Errorf("missing branch not reported:\n%s", buf.String())
+	}
+}
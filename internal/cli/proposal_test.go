@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+func TestWriteProposalSummaryShowsLink(t *testing.T) {
+	p := &gam.Proposal{
+		ID:         "p1",
+		RegionPath: "app.search",
+		Status:     "PENDING",
+		BranchName: "proposal/search-cache",
+		CommitSHA:  "0123456789abcdef0123456789abcdef01234567",
+	}
+	var buf bytes.Buffer
+	writeProposalSummary(&buf, p, 3)
+	for _, want := range []string{
+		"Branch: proposal/search-cache\n",
+		"Commit: 0123456789abcdef0123456789abcdef01234567\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("summary missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	writeProposalSummary(&buf, &gam.Proposal{ID: "p2"}, 3)
+	if strings.Contains(buf.String(), "Branch:") || strings.Contains(buf.String(), "Commit:") {
+		t.Errorf("unlinked proposal shows link fields:\n%s", buf.String())
+	}
+}
+
+func TestResolveCommit(t *testing.T) {
+	outside := t.TempDir()
+	sha := "0123456789ABCDEF0123456789abcdef01234567"
+	if got, err := resolveCommit(outside, sha); err != nil || got != strings.ToLower(sha) {
+		t.Errorf("outside git: %q, %v", got, err)
+	}
+	if _, err := resolveCommit(outside, "abc123"); err == nil {
+		t.Error("outside git: short SHA accepted")
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "init")
+	head := git("rev-parse", "HEAD")
+
+	if got, err := resolveCommit(dir, head[:8]); err != nil || got != head {
+		t.Errorf("short SHA in repo: %q, %v (want %s)", got, err, head)
+	}
+	if _, err := resolveCommit(dir, "0123456789abcdef0123456789abcdef01234567"); err == nil {
+		t.Error("unknown commit accepted inside a repository")
+	}
+}
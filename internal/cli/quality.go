@@ -2,8 +2,14 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/sbenjam1n/gamsync/internal/config"
 	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/spf13/cobra"
 )
@@ -65,6 +71,46 @@ var qualityGradesCmd = &cobra.Command{
 	},
 }
 
+var qualityRollupCmd = &cobra.Command{
+	Use:   "rollup [region]",
+	Short: "Aggregate quality grades across a region subtree",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		regionPath := ""
+		if len(args) > 0 {
+			regionPath = args[0]
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		rollup, err := memorizer.SubtreeRollup(ctx, pool, regionPath)
+		if err != nil {
+			return fmt.Errorf("rollup: %w", err)
+		}
+
+		scope := regionPath
+		if scope == "" {
+			scope = "all regions"
+		}
+		if rollup.Overall == "" {
+			fmt.Printf("No quality grades recorded under %s.\n", scope)
+			return nil
+		}
+
+		fmt.Printf("Quality rollup for %s (%d graded region(s)):\n", scope, rollup.Regions)
+		for _, category := range rollup.SortedCategories() {
+			fmt.Printf("  %s: %s\n", category, rollup.Categories[category])
+		}
+		fmt.Printf("  overall: %s\n", rollup.Overall)
+		return nil
+	},
+}
+
 var qualityPrinciplesCmd = &cobra.Command{
 	Use:   "principles",
 	Short: "List golden principles",
@@ -77,7 +123,7 @@ var qualityPrinciplesCmd = &cobra.Command{
 		defer pool.Close()
 
 		rows, err := pool.Query(ctx, `
-			SELECT name, rule, remediation, enabled FROM golden_principles ORDER BY name
+			SELECT name, rule, remediation, severity, enabled FROM golden_principles ORDER BY name
 		`)
 		if err != nil {
 			return err
@@ -86,14 +132,14 @@ var qualityPrinciplesCmd = &cobra.Command{
 
 		fmt.Println("Golden Principles:")
 		for rows.Next() {
-			var name, rule, remediation string
+			var name, rule, remediation, severity string
 			var enabled bool
-			rows.Scan(&name, &rule, &remediation, &enabled)
+			rows.Scan(&name, &rule, &remediation, &severity, &enabled)
 			status := "enabled"
 			if !enabled {
 				status = "disabled"
 			}
-			fmt.Printf("  [%s] %s\n", status, name)
+			fmt.Printf("  [%s, %s] %s\n", status, severity, name)
 			fmt.Printf("    Rule: %s\n", rule)
 			fmt.Printf("    Remediation: %s\n\n", remediation)
 		}
@@ -108,10 +154,15 @@ var qualityPrinciplesAddCmd = &cobra.Command{
 		name, _ := cmd.Flags().GetString("name")
 		rule, _ := cmd.Flags().GetString("rule")
 		remediation, _ := cmd.Flags().GetString("remediation")
+		lintCheck, _ := cmd.Flags().GetString("lint-check")
+		severity, _ := cmd.Flags().GetString("severity")
 
 		if name == "" || rule == "" || remediation == "" {
 			return fmt.Errorf("--name, --rule, and --remediation are required")
 		}
+		if !memorizer.ValidSeverity(severity) {
+			return fmt.Errorf("invalid --severity %q: must be warn or block", severity)
+		}
 
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
@@ -120,11 +171,19 @@ var qualityPrinciplesAddCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
+		// Re-adding a principle keeps its lint check and severity unless
+		// --lint-check or --severity is given.
+		var severityArg *string
+		if cmd.Flags().Changed("severity") {
+			severityArg = &severity
+		}
 		_, err = pool.Exec(ctx, `
-			INSERT INTO golden_principles (name, rule, remediation, enabled)
-			VALUES ($1, $2, $3, true)
-			ON CONFLICT (name) DO UPDATE SET rule = $2, remediation = $3
-		`, name, rule, remediation)
+			INSERT INTO golden_principles (name, rule, remediation, lint_check, severity, enabled)
+			VALUES ($1, $2, $3, NULLIF($4, ''), COALESCE($5::varchar, 'warn'), true)
+			ON CONFLICT (name) DO UPDATE SET rule = $2, remediation = $3,
+				lint_check = COALESCE(NULLIF($4, ''), golden_principles.lint_check),
+				severity = COALESCE($5::varchar, golden_principles.severity)
+		`, name, rule, remediation, lintCheck, severityArg)
 		if err != nil {
 			return fmt.Errorf("add principle: %w", err)
 		}
@@ -134,6 +193,108 @@ var qualityPrinciplesAddCmd = &cobra.Command{
 	},
 }
 
+var qualityPrinciplesRmCmd = &cobra.Command{
+	Use:   "rm [name]",
+	Short: "Remove a golden principle",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if err := memorizer.RemovePrinciple(ctx, pool, name); err != nil {
+			return fmt.Errorf("remove principle: %w", err)
+		}
+
+		fmt.Printf("Golden principle '%s' removed.\n", name)
+		if memorizer.PrincipleInDocs(projectRoot(), name) {
+			fmt.Printf("Warning: docs/quality/golden-principles.md still lists '%s'. Run `gam docs export` to refresh it.\n", name)
+		}
+		return nil
+	},
+}
+
+var qualityPrinciplesDisableCmd = &cobra.Command{
+	Use:   "disable [name]",
+	Short: "Disable a golden principle",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPrincipleEnabled(args[0], false)
+	},
+}
+
+var qualityPrinciplesEnableCmd = &cobra.Command{
+	Use:   "enable [name]",
+	Short: "Enable a golden principle",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPrincipleEnabled(args[0], true)
+	},
+}
+
+func setPrincipleEnabled(name string, enabled bool) error {
+	ctx := context.Background()
+	pool, err := connectDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	if err := memorizer.SetPrincipleEnabled(ctx, pool, name, enabled); err != nil {
+		return fmt.Errorf("update principle: %w", err)
+	}
+
+	status := "enabled"
+	if !enabled {
+		status = "disabled"
+	}
+	fmt.Printf("Golden principle '%s' %s.\n", name, status)
+	return nil
+}
+
+var qualityLintCmd = &cobra.Command{
+	Use:   "lint [region]",
+	Short: "Run golden principle lint checks",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		regionPath := ""
+		if len(args) > 0 {
+			regionPath = args[0]
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		findings, err := memorizer.LintGoldenPrinciples(ctx, pool, projectRoot(), regionPath)
+		if err != nil {
+			return fmt.Errorf("lint: %w", err)
+		}
+
+		if len(findings) == 0 {
+			fmt.Println("All golden principle checks passed.")
+			return nil
+		}
+
+		for _, f := range findings {
+			fmt.Printf("  [%s] %s\n    %s\n    Fix: %s\n\n", f.Severity, f.RegionPath, f.Description, f.Fix)
+		}
+		if blocking := memorizer.BlockingFindings(findings); len(blocking) > 0 {
+			return fmt.Errorf("%d blocking golden principle violation(s)", len(blocking))
+		}
+		fmt.Printf("%d warning(s), none blocking.\n", len(findings))
+		return nil
+	},
+}
+
 var gardenerCmd = &cobra.Command{
 	Use:   "gardener",
 	Short: "Entropy management",
@@ -144,6 +305,20 @@ var gardenerRunCmd = &cobra.Command{
 	Short: "Run entropy sweep and queue fix-up turns",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dryRun, _ := cmd.Flags().GetBool("dry")
+		format, _ := cmd.Flags().GetString("format")
+		failOn, _ := cmd.Flags().GetStringSlice("fail-on")
+
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown --format %q (want text or json)", format)
+		}
+		failRules, err := parseFailOn(failOn)
+		if err != nil {
+			return err
+		}
+		todoAge, driftWindow, err := gardenerWindows(cmd)
+		if err != nil {
+			return err
+		}
 
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
@@ -159,32 +334,112 @@ var gardenerRunCmd = &cobra.Command{
 		defer rdb.Close()
 
 		m := memorizer.New(pool, rdb, projectRoot())
+		m.SetGardenerWindows(todoAge, driftWindow)
 
 		findings, err := m.RunGardener(ctx, dryRun)
 		if err != nil {
 			return fmt.Errorf("gardener: %w", err)
 		}
 
-		if len(findings) == 0 {
-			fmt.Println("No entropy issues found.")
-			return nil
+		if err := writeGardenFindings(os.Stdout, findings, format, dryRun); err != nil {
+			return err
 		}
+		return checkFailOn(findings, failRules)
+	},
+}
 
-		for _, f := range findings {
-			mechStr := ""
-			if f.Mechanical {
-				mechStr = " [auto-fixable]"
-			}
-			fmt.Printf("  [%s] %s%s\n    %s\n\n", f.Category, f.RegionPath, mechStr, f.Description)
+// gardenerWindows returns --todo-age and --drift-window when given, else the
+// GAM_GARDENER_TODO_AGE and GAM_GARDENER_DRIFT_WINDOW defaults. Zero means the
+// memorizer default.
+func gardenerWindows(cmd *cobra.Command) (todoAge, driftWindow time.Duration, err error) {
+	todoAge, driftWindow = cfg.GardenerTodoAge, cfg.GardenerDriftWindow
+	if cmd.Flags().Changed("todo-age") {
+		v, _ := cmd.Flags().GetString("todo-age")
+		if todoAge, err = config.ParseWindow(v); err != nil {
+			return 0, 0, fmt.Errorf("--todo-age: %w", err)
 		}
+	}
+	if cmd.Flags().Changed("drift-window") {
+		v, _ := cmd.Flags().GetString("drift-window")
+		if driftWindow, err = config.ParseWindow(v); err != nil {
+			return 0, 0, fmt.Errorf("--drift-window: %w", err)
+		}
+	}
+	return todoAge, driftWindow, nil
+}
 
-		fmt.Printf("%d finding(s)", len(findings))
-		if dryRun {
-			fmt.Print(" (dry run — no turns queued)")
+// writeGardenFindings prints findings as text or as a JSON array.
+func writeGardenFindings(w io.Writer, findings []memorizer.GardenFinding, format string, dryRun bool) error {
+	if format == "json" {
+		if findings == nil {
+			findings = []memorizer.GardenFinding{}
 		}
-		fmt.Println()
+		out, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(out))
 		return nil
-	},
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "No entropy issues found.")
+		return nil
+	}
+
+	for _, f := range findings {
+		mechStr := ""
+		if f.Mechanical {
+			mechStr = " [auto-fixable]"
+		}
+		fmt.Fprintf(w, "  [%s] %s%s\n    %s\n", f.Category, f.RegionPath, mechStr, f.Description)
+		if f.Fix != "" {
+			fmt.Fprintf(w, "    Fix: %s\n", f.Fix)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "%d finding(s)", len(findings))
+	if dryRun {
+		fmt.Fprint(w, " (dry run — no turns queued)")
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// failOnRule matches findings whose field (category or severity) equals value.
+type failOnRule struct {
+	field, value string
+}
+
+// parseFailOn parses --fail-on values of the form category=X or severity=X.
+func parseFailOn(specs []string) ([]failOnRule, error) {
+	var rules []failOnRule
+	for _, spec := range specs {
+		field, value, ok := strings.Cut(spec, "=")
+		if !ok || value == "" || (field != "category" && field != "severity") {
+			return nil, fmt.Errorf("invalid --fail-on %q (want category=NAME or severity=LEVEL)", spec)
+		}
+		rules = append(rules, failOnRule{field: field, value: value})
+	}
+	return rules, nil
+}
+
+// checkFailOn returns an error counting the findings matched by any rule.
+func checkFailOn(findings []memorizer.GardenFinding, rules []failOnRule) error {
+	matched := 0
+	for _, f := range findings {
+		for _, r := range rules {
+			if (r.field == "category" && f.Category == r.value) || (r.field == "severity" && f.Severity == r.value) {
+				matched++
+				break
+			}
+		}
+	}
+	if matched > 0 {
+		return fmt.Errorf("gardener: %d finding(s) matched --fail-on", matched)
+	}
+	return nil
 }
 
 func init() {
@@ -193,12 +448,23 @@ func init() {
 	qualityPrinciplesAddCmd.Flags().String("name", "", "Principle name")
 	qualityPrinciplesAddCmd.Flags().String("rule", "", "Principle rule")
 	qualityPrinciplesAddCmd.Flags().String("remediation", "", "Agent-actionable remediation")
+	qualityPrinciplesAddCmd.Flags().String("lint-check", "", "Shell command that exits non-zero on violation")
+	qualityPrinciplesAddCmd.Flags().String("severity", "warn", "warn (report only) or block (fail turn end); updates keep the current severity unless set")
 
 	gardenerRunCmd.Flags().Bool("dry", false, "Preview findings without creating turns")
+	gardenerRunCmd.Flags().String("format", "text", "Output format: text|json")
+	gardenerRunCmd.Flags().String("todo-age", "7d", "Report scratchpad TODOs older than this (e.g. 72h, 14d)")
+	gardenerRunCmd.Flags().String("drift-window", "7d", "Look back this far in flow_log for sync drift")
+	gardenerRunCmd.Flags().StringSlice("fail-on", nil, "Exit non-zero when findings match category=NAME or severity=LEVEL (repeatable)")
 
 	qualityCmd.AddCommand(qualityGradesCmd)
+	qualityCmd.AddCommand(qualityRollupCmd)
 	qualityCmd.AddCommand(qualityPrinciplesCmd)
+	qualityCmd.AddCommand(qualityLintCmd)
 	qualityPrinciplesCmd.AddCommand(qualityPrinciplesAddCmd)
+	qualityPrinciplesCmd.AddCommand(qualityPrinciplesRmCmd)
+	qualityPrinciplesCmd.AddCommand(qualityPrinciplesDisableCmd)
+	qualityPrinciplesCmd.AddCommand(qualityPrinciplesEnableCmd)
 
 	gardenerCmd.AddCommand(gardenerRunCmd)
 }
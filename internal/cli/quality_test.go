@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/config"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestGardenerJSONAndFailOn(t *testing.T) {
+	findings := []memorizer.GardenFinding{
+		{RegionPath: "app.search", Category: "stale_todo", Description: "TODO older than 30 days", Mechanical: true},
+		{RegionPath: "app.auth", Category: "golden_principle", Description: "no-println", Severity: "block"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeGardenFindings(&buf, findings, "json", false); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []memorizer.GardenFinding
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(decoded, findings) {
+		t.Errorf("decoded %+v, want %+v", decoded, findings)
+	}
+
+	buf.Reset()
+	writeGardenFindings(&buf, nil, "json", false)
+	if buf.String() != "[]\n" {
+		t.Errorf("no findings = %q, want []", buf.String())
+	}
+
+	tests := []struct {
+		failOn  []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"category=stale_todo"}, true},
+		{[]string{"category=sync_drift"}, false},
+		{[]string{"category=sync_drift", "severity=block"}, true},
+	}
+	for _, tt := range tests {
+		rules, err := parseFailOn(tt.failOn)
+		if err != nil {
+			t.Fatalf("parseFailOn(%v): %v", tt.failOn, err)
+		}
+		if err := checkFailOn(findings, rules); (err != nil) != tt.wantErr {
+			t.Errorf("--fail-on %v: err = %v, wantErr %v", tt.failOn, err, tt.wantErr)
+		}
+	}
+
+	if _, err := parseFailOn([]string{"stale_todo"}); err == nil {
+		t.Error("expected error for --fail-on without a field")
+	}
+}
+
+func TestPrincipleReAddKeepsLintCheck(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	oldCfg := cfg
+	cfg = &config.Config{DatabaseURL: pool.Config().ConnString(), ProjectRoot: t.TempDir()}
+	defer func() { cfg = oldCfg }()
+
+	const name = "readd-keeps-check"
+	defer pool.Exec(ctx, `DELETE FROM golden_principles WHERE name = $1`, name)
+
+	add := func(rule, lintCheck string) {
+		t.Helper()
+		qualityPrinciplesAddCmd.Flags().Set("name", name)
+		qualityPrinciplesAddCmd.Flags().Set("rule", rule)
+		qualityPrinciplesAddCmd.Flags().Set("remediation", "remove it")
+		qualityPrinciplesAddCmd.Flags().Set("lint-check", lintCheck)
+		if err := qualityPrinciplesAddCmd.RunE(qualityPrinciplesAddCmd, nil); err != nil {
+			t.Fatalf("principles add: %v", err)
+		}
+	}
+	defer func() {
+		for _, f := range []string{"name", "rule", "remediation", "lint-check"} {
+			qualityPrinciplesAddCmd.Flags().Set(f, "")
+		}
+	}()
+
+	add("no println", "grep -rn println .")
+	add("no println in library code", "")
+
+	var rule, lintCheck string
+	if err := pool.QueryRow(ctx, `SELECT rule, COALESCE(lint_check, '') FROM golden_principles WHERE name = $1`, name).Scan(&rule, &lintCheck); err != nil {
+		t.Fatalf("select principle: %v", err)
+	}
+	if rule != "no println in library code" {
+		t.Errorf("rule = %q, want the updated rule", rule)
+	}
+	if lintCheck != "grep -rn println ." {
+		t.Errorf("lint_check = %q, want it kept when --lint-check is omitted", lintCheck)
+	}
+}
@@ -2,8 +2,13 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/sbenjam1n/gamsync/internal/queue"
 	"github.com/spf13/cobra"
 )
@@ -42,6 +47,14 @@ var queueEscalatedCmd = &cobra.Command{
 	Use:   "escalated",
 	Short: "Show proposals awaiting human review",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		category, _ := cmd.Flags().GetString("category")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		switch category {
+		case "", memorizer.EscalationReviewLimit, memorizer.EscalationHumanRequested:
+		default:
+			return fmt.Errorf("--category must be %s or %s", memorizer.EscalationReviewLimit, memorizer.EscalationHumanRequested)
+		}
+
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
 		if err != nil {
@@ -49,35 +62,225 @@ var queueEscalatedCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		rows, err := pool.Query(ctx, `
-			SELECT p.id, r.path, p.rejection_reason, p.created_at
-			FROM proposals p
-			JOIN regions r ON r.id = p.region_id
-			WHERE p.status = 'PENDING' AND p.rejection_reason LIKE 'ESCALATED%'
-			ORDER BY p.created_at DESC
-		`)
+		escalated, err := memorizer.EscalatedProposals(ctx, pool, category)
 		if err != nil {
 			return err
 		}
-		defer rows.Close()
+		if asJSON {
+			if escalated == nil {
+				escalated = []memorizer.EscalatedProposal{}
+			}
+			out, _ := json.MarshalIndent(escalated, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+		writeEscalated(os.Stdout, escalated)
+		return nil
+	},
+}
 
-		fmt.Println("Escalated Proposals (awaiting human review):")
-		found := false
-		for rows.Next() {
-			found = true
-			var id, path, reason string
-			var createdAt interface{}
-			rows.Scan(&id, &path, &reason, &createdAt)
-			fmt.Printf("  %s  region=%s\n    %s\n\n", id, path, reason)
+// writeEscalated prints escalated proposals with their category and reason.
+func writeEscalated(w io.Writer, escalated []memorizer.EscalatedProposal) {
+	fmt.Fprintln(w, "Escalated Proposals (awaiting human review):")
+	if len(escalated) == 0 {
+		fmt.Fprintln(w, "  (none)")
+		return
+	}
+	for _, e := range escalated {
+		category := e.Category
+		if category == "" {
+			category = "uncategorized"
 		}
-		if !found {
-			fmt.Println("  (none)")
+		fmt.Fprintf(w, "  %s  region=%s  category=%s\n    %s\n\n", e.ID, e.RegionPath, category, e.Reason)
+	}
+}
+
+var queuePendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "List delivered but unacknowledged messages per consumer",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		which, _ := cmd.Flags().GetString("stream")
+		limit, _ := cmd.Flags().GetInt64("limit")
+
+		var streams []string
+		switch which {
+		case "":
+			streams = []string{queue.StreamTasks, queue.StreamProposals}
+		case "tasks":
+			streams = []string{queue.StreamTasks}
+		case "proposals":
+			streams = []string{queue.StreamProposals}
+		default:
+			return fmt.Errorf("--stream must be tasks or proposals")
+		}
+
+		rdb, err := connectRedis()
+		if err != nil {
+			return err
+		}
+		defer rdb.Close()
+
+		ctx := context.Background()
+		q := queue.New(rdb)
+
+		for _, stream := range streams {
+			total, err := q.PendingCount(ctx, stream)
+			if err != nil {
+				return err
+			}
+			pending, err := q.Pending(ctx, stream, limit)
+			if err != nil {
+				return err
+			}
+
+			writePending(os.Stdout, stream, total, pending)
+		}
+		return nil
+	},
+}
+
+// writePending prints a stream's total pending count and the listed
+// messages, noting when --limit truncated the listing.
+func writePending(w io.Writer, stream string, total int64, pending []queue.PendingMessage) {
+	if int64(len(pending)) < total {
+		fmt.Fprintf(w, "%s: %d pending (showing first %d; raise --limit to see more)\n", stream, total, len(pending))
+	} else {
+		fmt.Fprintf(w, "%s: %d pending\n", stream, total)
+	}
+	for _, p := range pending {
+		fmt.Fprintf(w, "  %s  consumer=%s  idle=%s  deliveries=%d\n",
+			p.ID, p.Consumer, p.Idle.Truncate(time.Second), p.Deliveries)
+	}
+}
+
+var queueDrainCmd = &cobra.Command{
+	Use:   "drain",
+	Short: "Pause Memorizer proposal reads and wait for in-flight proposals to finish",
+	Long: `Sets the drain flag, which stops Memorizer workers from reading new
+proposals, then waits until the memorizer group has no pending (delivered but
+unacked) proposals or --timeout elapses. Once drained, the Memorizer can be
+stopped without abandoning work. Workers still finish proposals left pending
+by an earlier run, so a Memorizer restarted mid-batch drains too. Reads stay
+paused until "gam queue drain --resume" clears the flag.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		resume, _ := cmd.Flags().GetBool("resume")
+
+		rdb, err := connectRedis()
+		if err != nil {
+			return err
+		}
+		defer rdb.Close()
+
+		ctx, stop := signalContext()
+		defer stop()
+		q := queue.New(rdb)
+
+		if resume {
+			if err := q.SetDraining(ctx, false); err != nil {
+				return err
+			}
+			fmt.Println("Drain cleared; Memorizer proposal reads resumed.")
+			return nil
+		}
+
+		if err := q.SetDraining(ctx, true); err != nil {
+			return err
+		}
+		fmt.Printf("Draining: Memorizer proposal reads paused. Waiting up to %s for pending proposals...\n", timeout)
+
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		_, waitErr := queue.WaitDrained(waitCtx, interval, func(ctx context.Context) (int64, error) {
+			return q.PendingCount(ctx, queue.StreamProposals)
+		})
+		if waitErr != nil && waitCtx.Err() == nil {
+			return waitErr
+		}
+
+		remaining := make(map[string]int64)
+		for _, stream := range []string{queue.StreamTasks, queue.StreamProposals} {
+			n, err := q.PendingCount(context.Background(), stream)
+			if err != nil {
+				return err
+			}
+			remaining[stream] = n
+			fmt.Printf("  %s: %d pending\n", stream, n)
+		}
+		if remaining[queue.StreamProposals] > 0 {
+			return fmt.Errorf("drain timed out with %d proposals still pending", remaining[queue.StreamProposals])
+		}
+		fmt.Println("Drained. The Memorizer can be stopped safely.")
+		return nil
+	},
+}
+
+var queueReplayPlanCmd = &cobra.Command{
+	Use:   "replay-plan [plan]",
+	Short: "Re-push tasks for active plan turns with no recent proposal",
+	Long: `Finds plan turns marked active whose turn is still ACTIVE but has produced
+no proposal within --window, and pushes their task again. This recovers plans
+stalled by a lost task, e.g. when the Memorizer stopped between activating a
+turn and the researcher reading it. Each replay gets a fresh idempotency key,
+since the original may already be claimed by the researcher that lost it.
+Without a plan name every plan is checked.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		window, _ := cmd.Flags().GetDuration("window")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		plan := ""
+		if len(args) == 1 {
+			plan = args[0]
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+		rdb, err := connectRedis()
+		if err != nil {
+			return err
+		}
+		defer rdb.Close()
+
+		m := memorizer.New(pool, rdb, projectRoot())
+		tasks, err := m.ReplayStalledPlanTurns(ctx, plan, window, dryRun)
+		for _, t := range tasks {
+			fmt.Printf("  %s  region=%s  type=%s\n", t.TurnID, t.RegionPath, t.TaskType)
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case len(tasks) == 0:
+			fmt.Println("No stalled plan turns.")
+		case dryRun:
+			fmt.Printf("\n%d stalled plan turn(s) (dry run). Re-run without --dry-run to replay them.\n", len(tasks))
+		default:
+			fmt.Printf("\nReplayed %d task(s).\n", len(tasks))
 		}
 		return nil
 	},
 }
 
 func init() {
+	queuePendingCmd.Flags().String("stream", "", "Stream to inspect: tasks or proposals (default both)")
+	queuePendingCmd.Flags().Int64("limit", 100, "Maximum messages to list per stream")
+
 	queueCmd.AddCommand(queueStatusCmd)
+	queueEscalatedCmd.Flags().String("category", "", "Only show escalations in this category: review_limit or human_requested")
+	queueEscalatedCmd.Flags().Bool("json", false, "Output as JSON")
 	queueCmd.AddCommand(queueEscalatedCmd)
+	queueDrainCmd.Flags().Duration("timeout", 5*time.Minute, "How long to wait for pending proposals")
+	queueDrainCmd.Flags().Duration("interval", time.Second, "How often to check the pending count")
+	queueDrainCmd.Flags().Bool("resume", false, "Clear the drain flag so Memorizer reads resume")
+
+	queueCmd.AddCommand(queuePendingCmd)
+	queueCmd.AddCommand(queueDrainCmd)
+	queueReplayPlanCmd.Flags().Duration("window", time.Hour, "Treat an active turn as stalled when it has no proposal this recent")
+	queueReplayPlanCmd.Flags().Bool("dry-run", false, "List stalled plan turns without pushing tasks")
+	queueCmd.AddCommand(queueReplayPlanCmd)
 }
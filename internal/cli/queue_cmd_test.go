@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/queue"
+)
+
+func TestWriteEscalated(t *testing.T) {
+	var buf bytes.Buffer
+	writeEscalated(&buf, []memorizer.EscalatedProposal{
+		{ID: "p1", RegionPath: "app.search", Category: memorizer.EscalationReviewLimit, Reason: "ESCALATED: review iteration limit (3) reached: naming"},
+		{ID: "p2", RegionPath: "app.billing", Reason: "ESCALATED: legacy"},
+	})
+	out := buf.String()
+	for _, want := range []string{
+		"p1  region=app.search  category=review_limit\n    ESCALATED: review iteration limit",
+		"p2  region=app.billing  category=uncategorized",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	writeEscalated(&buf, nil)
+	if !strings.Contains(buf.String(), "(none)") {
+		t.Errorf("empty output = %q", buf.String())
+	}
+}
+
+func TestWritePendingReportsTotal(t *testing.T) {
+	listed := []queue.PendingMessage{{ID: "1-0", Consumer: "memorizer-1", Deliveries: 1}}
+
+	var buf bytes.Buffer
+	writePending(&buf, queue.StreamProposals, 5000, listed)
+	if !strings.Contains(buf.String(), "5000 pending (showing first 1;") {
+		t.Errorf("truncated listing should report the total:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	writePending(&buf, queue.StreamProposals, 1, listed)
+	if !strings.Contains(buf.String(), ": 1 pending\n") || strings.Contains(buf.String(), "showing") {
+		t.Errorf("complete listing = %q", buf.String())
+	}
+}
@@ -2,9 +2,19 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/sbenjam1n/gamsync/internal/validator"
 	"github.com/spf13/cobra"
 )
 
@@ -20,16 +30,26 @@ var regionTouchCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		regionPath := args[0]
 		file, _ := cmd.Flags().GetString("file")
+		lang, _ := cmd.Flags().GetString("lang")
 
 		if file == "" {
 			return fmt.Errorf("--file is required")
 		}
 
 		// Scaffold region markers in the file
-		if err := region.ScaffoldRegion(file, regionPath); err != nil {
+		var err error
+		if lang != "" {
+			err = region.ScaffoldRegionLang(file, regionPath, lang)
+		} else {
+			err = region.ScaffoldRegion(file, regionPath)
+		}
+		if err != nil {
 			return fmt.Errorf("scaffold region: %w", err)
 		}
 		fmt.Printf("Region %s scaffolded in %s\n", regionPath, file)
+		if !region.IsScannable(file) {
+			fmt.Printf("Note: %s has an extension the scanner does not read; its markers will not appear in scans\n", file)
+		}
 
 		// Also register in DB if connected
 		ctx := context.Background()
@@ -55,6 +75,56 @@ var regionTouchCmd = &cobra.Command{
 	},
 }
 
+var regionScaffoldAllCmd = &cobra.Command{
+	Use:   "scaffold-all",
+	Short: "Scaffold markers for every arch.md region into the files named by a file map",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mapFile, _ := cmd.Flags().GetString("file-map")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if mapFile == "" {
+			return fmt.Errorf("--file-map is required")
+		}
+
+		fileMap, err := region.LoadFileMap(mapFile)
+		if err != nil {
+			return fmt.Errorf("load file map: %w", err)
+		}
+		report, err := region.ScaffoldArch(projectRoot(), fileMap)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			if report.Created == nil {
+				report.Created = []region.ScaffoldEntry{}
+			}
+			if report.Skipped == nil {
+				report.Skipped = []region.ScaffoldEntry{}
+			}
+			out, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+		writeScaffoldReport(os.Stdout, report)
+		return nil
+	},
+}
+
+// writeScaffoldReport lists created and skipped regions with a summary line.
+func writeScaffoldReport(w io.Writer, report region.ScaffoldReport) {
+	for _, e := range report.Created {
+		fmt.Fprintf(w, "  created  %s in %s\n", e.Path, e.File)
+	}
+	for _, e := range report.Skipped {
+		if e.File != "" {
+			fmt.Fprintf(w, "  skipped  %s in %s (%s)\n", e.Path, e.File, e.Reason)
+		} else {
+			fmt.Fprintf(w, "  skipped  %s (%s)\n", e.Path, e.Reason)
+		}
+	}
+	fmt.Fprintf(w, "\n%d created, %d skipped.\n", len(report.Created), len(report.Skipped))
+}
+
 var regionListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all regions",
@@ -115,10 +185,10 @@ var regionShowCmd = &cobra.Command{
 
 		// Region info
 		var state string
-		var desc *string
+		var desc, owner *string
 		err = pool.QueryRow(ctx, `
-			SELECT lifecycle_state, description FROM regions WHERE path = $1
-		`, regionPath).Scan(&state, &desc)
+			SELECT lifecycle_state, description, owner FROM regions WHERE path = $1
+		`, regionPath).Scan(&state, &desc, &owner)
 		if err != nil {
 			return fmt.Errorf("region %s not found", regionPath)
 		}
@@ -128,6 +198,9 @@ var regionShowCmd = &cobra.Command{
 		if desc != nil {
 			fmt.Printf("Description: %s\n", *desc)
 		}
+		if owner != nil {
+			fmt.Printf("Owner: %s\n", *owner)
+		}
 
 		// Concept assignments
 		rows, _ := pool.Query(ctx, `
@@ -189,10 +262,347 @@ var regionShowCmd = &cobra.Command{
 	},
 }
 
+var regionConceptsCmd = &cobra.Command{
+	Use:   "concepts [path]",
+	Short: "Show the concepts covering a region, direct and inherited",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		regionPath := args[0]
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		assignments, err := validator.New(pool, projectRoot()).ConceptAssignments(ctx, regionPath)
+		if err != nil {
+			return fmt.Errorf("concept lookup: %w", err)
+		}
+
+		if asJSON {
+			if assignments == nil {
+				assignments = []validator.RegionConcept{}
+			}
+			out, _ := json.MarshalIndent(assignments, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if len(assignments) == 0 {
+			fmt.Printf("No concepts cover %s.\n", regionPath)
+			return nil
+		}
+		fmt.Printf("Concepts covering %s:\n", regionPath)
+		for _, a := range assignments {
+			source := "direct"
+			if a.Inherited {
+				source = "inherited from " + a.AssignedAt
+			}
+			fmt.Printf("  %-24s [%s] %s\n", a.Concept, a.Role, source)
+		}
+		return nil
+	},
+}
+
+var regionOrphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "List regions with no source markers, or with --concepts no covering concept",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		concepts, _ := cmd.Flags().GetBool("concepts")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if concepts {
+			uncovered, err := validator.New(pool, projectRoot()).RegionsWithoutConcepts(ctx)
+			if err != nil {
+				return fmt.Errorf("concept coverage: %w", err)
+			}
+			if len(uncovered) == 0 {
+				fmt.Println("Every region is covered by at least one concept.")
+				return nil
+			}
+			fmt.Printf("Regions without concepts (%d):\n", len(uncovered))
+			for _, path := range uncovered {
+				printConceptlessWarning(path)
+			}
+			return nil
+		}
+
+		markers, _, err := region.ScanDirectory(projectRoot(), region.ParseGamignore(projectRoot()))
+		if err != nil {
+			return fmt.Errorf("scan source: %w", err)
+		}
+		inSource := make(map[string]bool)
+		for _, m := range markers {
+			inSource[m.Path] = true
+		}
+
+		rows, err := pool.Query(ctx, `
+			SELECT path::text FROM regions
+			WHERE lifecycle_state != 'deprecated'
+			ORDER BY path
+		`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var orphans []string
+		for rows.Next() {
+			var path string
+			if err := rows.Scan(&path); err != nil {
+				return err
+			}
+			if !inSource[path] {
+				orphans = append(orphans, path)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if len(orphans) == 0 {
+			fmt.Println("Every region has source markers.")
+			return nil
+		}
+		fmt.Printf("Regions without source markers (%d):\n", len(orphans))
+		for _, path := range orphans {
+			fmt.Printf("  %s\n", path)
+		}
+		return nil
+	},
+}
+
+var regionLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check region markers for ordering, duplicate closers and placement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		root := projectRoot()
+		issues, err := region.LintDirectory(root, region.ParseGamignore(root))
+		if err != nil {
+			return fmt.Errorf("scan source: %w", err)
+		}
+		for i := range issues {
+			if rel, err := filepath.Rel(root, issues[i].File); err == nil {
+				issues[i].File = rel
+			}
+		}
+
+		if asJSON {
+			if issues == nil {
+				issues = []region.LintIssue{}
+			}
+			data, err := json.MarshalIndent(issues, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		} else if len(issues) == 0 {
+			fmt.Println("No region marker issues.")
+			return nil
+		} else {
+			for _, issue := range issues {
+				fmt.Printf("%s:%d: %s\n", issue.File, issue.Line, issue.Message)
+				fmt.Printf("  fix: %s\n", issue.Fix)
+			}
+		}
+		if len(issues) > 0 {
+			return fmt.Errorf("%d region marker issue(s)", len(issues))
+		}
+		return nil
+	},
+}
+
+var regionOwnerCmd = &cobra.Command{
+	Use:   "owner [path] [owner]",
+	Short: "Show or set the owning team of a region",
+	Long: `Show or set the owning team of a region. Regions without an owner inherit
+the nearest ancestor's owner in gam export owners.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clearOwner, _ := cmd.Flags().GetBool("clear")
+		if clearOwner && len(args) == 2 {
+			return fmt.Errorf("--clear takes no owner argument")
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if len(args) == 1 && !clearOwner {
+			owners, err := memorizer.RegionOwners(ctx, pool)
+			if err != nil {
+				return err
+			}
+			owner := region.ResolveOwner(args[0], owners)
+			switch {
+			case owner == "":
+				fmt.Printf("%s has no owner\n", args[0])
+			case owners[args[0]] == "":
+				fmt.Printf("%s (inherited)\n", owner)
+			default:
+				fmt.Println(owner)
+			}
+			return nil
+		}
+
+		owner := ""
+		if len(args) == 2 {
+			owner = args[1]
+		}
+		if err := memorizer.SetRegionOwner(ctx, pool, args[0], owner); err != nil {
+			return err
+		}
+		if owner == "" {
+			fmt.Printf("Cleared owner of %s\n", args[0])
+		} else {
+			fmt.Printf("%s is owned by %s\n", args[0], owner)
+		}
+		return nil
+	},
+}
+
+var regionDescribeCmd = &cobra.Command{
+	Use:   "describe [path] [description]",
+	Short: "Set a region's one-line description in the database and arch.md",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if err := describeRegion(ctx, pool, projectRoot(), args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Described %s\n", args[0])
+		return nil
+	},
+}
+
+// describeRegion sets a region's description in the regions table and on
+// its arch.md line, collapsing whitespace so both hold the same single line.
+func describeRegion(ctx context.Context, pool *pgxpool.Pool, root, path, desc string) error {
+	desc = strings.Join(strings.Fields(desc), " ")
+	if err := memorizer.SetRegionDescription(ctx, pool, path, desc); err != nil {
+		return err
+	}
+	return region.SetArchDescription(root, path, desc)
+}
+
+var regionHistoryCmd = &cobra.Command{
+	Use:   "history [path]",
+	Short: "Show every turn that touched a region and its descendants",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		regionPath := args[0]
+		limit, _ := cmd.Flags().GetInt("limit")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		var since time.Time
+		if sinceFlag != "" {
+			var err error
+			if since, err = parseSince(sinceFlag, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		entries, err := memorizer.RegionHistory(ctx, pool, regionPath, since, limit)
+		if err != nil {
+			return fmt.Errorf("region history: %w", err)
+		}
+
+		if asJSON {
+			if entries == nil {
+				entries = []memorizer.RegionHistoryEntry{}
+			}
+			out, _ := json.MarshalIndent(entries, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if len(entries) == 0 {
+			fmt.Printf("No recorded turns for %s.\n", regionPath)
+			return nil
+		}
+
+		fmt.Printf("History for %s (%d entries):\n", regionPath, len(entries))
+		for _, e := range entries {
+			role := e.AgentRole
+			if role == "" {
+				role = "-"
+			}
+			fmt.Printf("  %s  %-9s %-30s %s  role=%s  status=%s\n",
+				e.At.Local().Format("2006-01-02 15:04"), e.Action, e.Region, e.TurnID, role, e.Status)
+		}
+		return nil
+	},
+}
+
+// parseSince accepts a duration ago ("36h", "7d") or a date ("2006-01-02",
+// RFC 3339) and returns the corresponding point in time.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration (36h, 7d) or a date (2006-01-02)", s)
+}
+
 func init() {
 	regionTouchCmd.Flags().String("file", "", "Target file for region markers")
+	regionTouchCmd.Flags().String("lang", "", "Comment style to use instead of the file extension's (go, py, sql, html, css, ...)")
+	regionScaffoldAllCmd.Flags().String("file-map", "", "JSON file mapping region paths to target files")
+	regionScaffoldAllCmd.Flags().Bool("json", false, "Output as JSON")
+	regionHistoryCmd.Flags().Int("limit", 0, "Show only the most recent N entries (0 = all)")
+	regionHistoryCmd.Flags().String("since", "", "Only entries since a duration ago (36h, 7d) or a date (2006-01-02)")
+	regionHistoryCmd.Flags().Bool("json", false, "Output as JSON")
+	regionOwnerCmd.Flags().Bool("clear", false, "Remove the region's owner")
+	regionConceptsCmd.Flags().Bool("json", false, "Output as JSON")
+	regionOrphansCmd.Flags().Bool("concepts", false, "List regions no concept covers, directly or inherited")
+	regionLintCmd.Flags().Bool("json", false, "Output as JSON")
 
 	regionCmd.AddCommand(regionTouchCmd)
+	regionCmd.AddCommand(regionScaffoldAllCmd)
 	regionCmd.AddCommand(regionListCmd)
 	regionCmd.AddCommand(regionShowCmd)
+	regionCmd.AddCommand(regionHistoryCmd)
+	regionCmd.AddCommand(regionOwnerCmd)
+	regionCmd.AddCommand(regionDescribeCmd)
+	regionCmd.AddCommand(regionConceptsCmd)
+	regionCmd.AddCommand(regionOrphansCmd)
+	regionCmd.AddCommand(regionLintCmd)
 }
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"36h", now.Add(-36 * time.Hour)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2026-03-01T08:00:00Z", time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.in, now)
+		if err != nil {
+			t.Errorf("parseSince(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	if got, err := parseSince("2026-03-01", now); err != nil || got.Format("2006-01-02") != "2026-03-01" {
+		t.Errorf("parseSince(date) = %v, %v", got, err)
+	}
+	if _, err := parseSince("last week", now); err == nil {
+		t.Error("expected error for unparseable --since")
+	}
+}
@@ -3,8 +3,13 @@ package cli
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"net/url"
 	"os"
+	"os/user"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
@@ -40,6 +45,7 @@ func init() {
 
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(turnCmd)
+	rootCmd.AddCommand(memoryCmd)
 	rootCmd.AddCommand(regionCmd)
 	rootCmd.AddCommand(conceptCmd)
 	rootCmd.AddCommand(syncCmd)
@@ -52,24 +58,54 @@ func init() {
 	rootCmd.AddCommand(gardenerCmd)
 	rootCmd.AddCommand(archCmd)
 	rootCmd.AddCommand(queueCmd)
+	rootCmd.AddCommand(proposalCmd)
 	rootCmd.AddCommand(memorizerCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(skillCmd)
+	rootCmd.AddCommand(hookCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(watchCmd)
+
+	rootCmd.PersistentFlags().String("profile", "", "Config profile from gam.json to use (overrides GAM_PROFILE)")
+	rootCmd.PersistentFlags().String("log-level", "", "Log level: debug, info, warn, error (overrides GAM_LOG_LEVEL)")
 }
 
 func initConfig() {
 	var err error
-	cfg, err = config.Load()
+	profile, _ := rootCmd.PersistentFlags().GetString("profile")
+	cfg, err = config.LoadProfile(profile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
+	if level, _ := rootCmd.PersistentFlags().GetString("log-level"); level != "" {
+		cfg.LogLevel = level
+	}
+
+	logger, err := newLogger(os.Stderr, cfg.LogLevel, isTerminal(os.Stderr))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(logger)
 }
 
 func connectDB(ctx context.Context) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	return connectDBPool(ctx, 0)
+}
+
+// connectDBPool is connectDB with the pool's MaxConns raised to at least
+// minConns.
+func connectDBPool(ctx context.Context, minConns int32) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(cfg.DatabaseURL)
 	if err != nil {
-		return nil, fmt.Errorf("connect to database: %w\nSet GAM_DATABASE_URL environment variable", err)
+		return nil, fmt.Errorf("connect to database: %w\nSet GAM_DATABASE_URL environment variable", redactError(err, cfg.DatabaseURL))
+	}
+	config.MaxConns = max(config.MaxConns, minConns)
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w\nSet GAM_DATABASE_URL environment variable", redactError(err, cfg.DatabaseURL))
 	}
 	return pool, nil
 }
@@ -77,11 +113,72 @@ func connectDB(ctx context.Context) (*pgxpool.Pool, error) {
 func connectRedis() (*redis.Client, error) {
 	opts, err := redis.ParseURL(cfg.RedisURL)
 	if err != nil {
-		return nil, fmt.Errorf("parse redis URL: %w\nSet GAM_REDIS_URL environment variable", err)
+		return nil, fmt.Errorf("parse redis URL: %w\nSet GAM_REDIS_URL environment variable", redactError(err, cfg.RedisURL))
 	}
 	return redis.NewClient(opts), nil
 }
 
+// dsnPassword matches the password in a key=value connection string and
+// userinfoPassword the one in a URL too malformed for url.Parse.
+var (
+	dsnPassword      = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+	userinfoPassword = regexp.MustCompile(`(://[^:/@\s]*:)([^@\s]*)@`)
+)
+
+// redactURL masks the password in a database or Redis URL, or in a
+// key=value connection string, so it can appear in errors and logs.
+func redactURL(raw string) string {
+	if u, err := url.Parse(raw); err == nil && u.Scheme != "" && u.Host != "" {
+		return u.Redacted()
+	}
+	raw = userinfoPassword.ReplaceAllString(raw, "${1}xxxxx@")
+	return dsnPassword.ReplaceAllString(raw, "${1}xxxxx")
+}
+
+// urlPassword returns the password embedded in raw, or "".
+func urlPassword(raw string) string {
+	if u, err := url.Parse(raw); err == nil && u.User != nil {
+		pw, _ := u.User.Password()
+		return pw
+	}
+	if m := userinfoPassword.FindStringSubmatch(raw); m != nil {
+		return m[2]
+	}
+	if m := dsnPassword.FindStringSubmatch(raw); m != nil {
+		return strings.Trim(m[2], "'")
+	}
+	return ""
+}
+
+// redactedError is an error whose message has a connection URL's password
+// masked; Unwrap still returns the original for errors.Is and errors.As.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactError masks rawURL and its password wherever they appear in err's
+// message. It returns err unchanged when there is nothing to mask.
+func redactError(err error, rawURL string) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	redacted := strings.ReplaceAll(msg, rawURL, redactURL(rawURL))
+	// Very short passwords are left alone: masking every occurrence of one
+	// or two characters would garble the message without hiding anything.
+	if pw := urlPassword(rawURL); len(pw) >= 3 {
+		redacted = strings.ReplaceAll(redacted, pw, "xxxxx")
+	}
+	if redacted == msg {
+		return err
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
 func projectRoot() string {
 	return cfg.ProjectRoot
 }
@@ -89,3 +186,15 @@ func projectRoot() string {
 func migrationsDir() string {
 	return filepath.Join(projectRoot(), "migrations")
 }
+
+// currentActor names the local user for audit records, falling back to
+// $USER and then "unknown".
+func currentActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
@@ -2,7 +2,11 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/spf13/cobra"
@@ -17,8 +21,10 @@ var memorizerRunCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Run Memorizer: process proposals, create turns, manage plans",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		pool, err := connectDB(ctx)
+		ctx, stop := signalContext()
+		defer stop()
+		workers := proposalWorkers(cmd)
+		pool, err := connectDBPool(ctx, memorizer.ProposalPoolConns(workers))
 		if err != nil {
 			return err
 		}
@@ -31,9 +37,12 @@ var memorizerRunCmd = &cobra.Command{
 		defer rdb.Close()
 
 		m := memorizer.New(pool, rdb, projectRoot())
+		m.SetProposalWorkers(workers)
+		m.SetExternalValidator(cfg.Tier2Command, cfg.Tier2Timeout)
+		m.SetMaxReviewIterations(cfg.MaxReviewIterations)
 
 		fmt.Println("Memorizer running. Consuming proposals from Redis...")
-		return m.ConsumeProposals(ctx)
+		return consumeUntilSignal(ctx, m)
 	},
 }
 
@@ -44,8 +53,10 @@ var runCmd = &cobra.Command{
 		auto, _ := cmd.Flags().GetBool("auto")
 		withGardener, _ := cmd.Flags().GetBool("gardener")
 
-		ctx := context.Background()
-		pool, err := connectDB(ctx)
+		ctx, stop := signalContext()
+		defer stop()
+		workers := proposalWorkers(cmd)
+		pool, err := connectDBPool(ctx, memorizer.ProposalPoolConns(workers))
 		if err != nil {
 			return err
 		}
@@ -58,6 +69,10 @@ var runCmd = &cobra.Command{
 		defer rdb.Close()
 
 		m := memorizer.New(pool, rdb, projectRoot())
+		m.SetProposalWorkers(workers)
+		m.SetExternalValidator(cfg.Tier2Command, cfg.Tier2Timeout)
+		m.SetMaxReviewIterations(cfg.MaxReviewIterations)
+		m.SetGardenerWindows(cfg.GardenerTodoAge, cfg.GardenerDriftWindow)
 
 		if withGardener {
 			fmt.Println("Running gardener sweep...")
@@ -72,7 +87,7 @@ var runCmd = &cobra.Command{
 		if auto {
 			fmt.Println("Running automated Memorizer loop...")
 			fmt.Println("(Press Ctrl+C to stop)")
-			return m.ConsumeProposals(ctx)
+			return consumeUntilSignal(ctx, m)
 		}
 
 		fmt.Println("Sequential mode: run 'gam memorizer run' and 'gam researcher run' separately.")
@@ -81,9 +96,36 @@ var runCmd = &cobra.Command{
 	},
 }
 
+// signalContext returns a context cancelled on SIGINT or SIGTERM.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// consumeUntilSignal runs the proposal loop until ctx is cancelled by a
+// signal, treating that as a clean exit once in-flight proposals finish.
+func consumeUntilSignal(ctx context.Context, m *memorizer.Memorizer) error {
+	err := m.ConsumeProposals(ctx)
+	if errors.Is(err, context.Canceled) {
+		fmt.Println("Memorizer stopped.")
+		return nil
+	}
+	return err
+}
+
+// proposalWorkers returns --workers if set, else GAM_PROPOSAL_WORKERS.
+func proposalWorkers(cmd *cobra.Command) int {
+	if cmd.Flags().Changed("workers") {
+		n, _ := cmd.Flags().GetInt("workers")
+		return n
+	}
+	return cfg.ProposalWorkers
+}
+
 func init() {
 	runCmd.Flags().Bool("auto", false, "Automated loop until queues empty")
 	runCmd.Flags().Bool("gardener", false, "Include gardener sweeps")
+	runCmd.Flags().Int("workers", 1, "Proposal workers, each a separate consumer in the memorizer group")
+	memorizerRunCmd.Flags().Int("workers", 1, "Proposal workers, each a separate consumer in the memorizer group")
 
 	memorizerCmd.AddCommand(memorizerRunCmd)
 }
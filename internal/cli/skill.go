@@ -2,10 +2,12 @@ package cli
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/sbenjam1n/gamsync/skills"
 	"github.com/spf13/cobra"
 )
 
@@ -23,7 +25,12 @@ Available skills:
 Usage:
   gam skill memorizer              Print the Memorizer skill prompt
   gam skill researcher --full      Print base-agent + Researcher skill combined
-  gam skill list                   List available skills`,
+  gam skill researcher --full --output .agents/r1.md --agent-id r1
+                                   Write the composed prompt to a file
+  gam skill list                   List available skills
+
+Project skill templates may contain {{agent_id}}, replaced by --agent-id.
+The shipped prompts do not use it.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
@@ -36,7 +43,18 @@ Usage:
 		}
 
 		full, _ := cmd.Flags().GetBool("full")
-		return printSkill(name, full)
+		output, _ := cmd.Flags().GetString("output")
+		agentID, _ := cmd.Flags().GetString("agent-id")
+
+		if output == "" {
+			return printSkill(name, full, agentID)
+		}
+
+		if err := writeSkill(name, full, agentID, output); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote skill '%s' to %s\n", name, output)
+		return nil
 	},
 }
 
@@ -49,10 +67,10 @@ var skillListCmd = &cobra.Command{
 }
 
 func listSkills() error {
-	skillsDir := findSkillsDir()
-	entries, err := os.ReadDir(skillsDir)
+	skillsFS := findSkillsFS()
+	entries, err := fs.ReadDir(skillsFS, ".")
 	if err != nil {
-		return fmt.Errorf("read skills directory: %w\nExpected skills/ in project root", err)
+		return fmt.Errorf("read skills directory: %w", err)
 	}
 
 	fmt.Println("Available skills:")
@@ -64,7 +82,7 @@ func listSkills() error {
 		name := strings.TrimSuffix(e.Name(), ".md")
 
 		// Read first non-empty, non-heading line as description
-		data, _ := os.ReadFile(filepath.Join(skillsDir, e.Name()))
+		data, _ := fs.ReadFile(skillsFS, e.Name())
 		desc := extractDescription(string(data))
 
 		fmt.Printf("  %-15s %s\n", name, desc)
@@ -77,32 +95,73 @@ func listSkills() error {
 	return nil
 }
 
-func printSkill(name string, full bool) error {
-	skillsDir := findSkillsDir()
+// agentIDPlaceholder is replaced by --agent-id when composing a skill.
+const agentIDPlaceholder = "{{agent_id}}"
+
+func printSkill(name string, full bool, agentID string) error {
+	content, err := composeSkill(name, full, agentID)
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
+
+// writeSkill composes a skill and writes it to path, creating parent directories.
+func writeSkill(name string, full bool, agentID, path string) error {
+	content, err := composeSkill(name, full, agentID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write skill: %w", err)
+	}
+	return nil
+}
+
+// composeSkill returns the skill prompt, prefixed by base-agent when full is
+// set. When agentID is non-empty, {{agent_id}} placeholders are substituted.
+func composeSkill(name string, full bool, agentID string) (string, error) {
+	skillsFS := findSkillsFS()
 
+	var b strings.Builder
 	if full && name != "base-agent" {
-		// Print base-agent first, then the requested skill
-		basePath := filepath.Join(skillsDir, "base-agent.md")
-		baseContent, err := os.ReadFile(basePath)
+		// base-agent first, then the requested skill
+		baseContent, err := fs.ReadFile(skillsFS, "base-agent.md")
 		if err != nil {
-			return fmt.Errorf("read base-agent skill: %w", err)
+			return "", fmt.Errorf("read base-agent skill: %w", err)
 		}
-		fmt.Println(string(baseContent))
-		fmt.Println()
-		fmt.Println("---")
-		fmt.Println()
+		b.Write(baseContent)
+		b.WriteString("\n\n---\n\n")
 	}
 
-	skillPath := filepath.Join(skillsDir, name+".md")
-	content, err := os.ReadFile(skillPath)
+	content, err := fs.ReadFile(skillsFS, name+".md")
 	if err != nil {
-		return fmt.Errorf("skill '%s' not found. Run 'gam skill list' to see available skills", name)
+		return "", fmt.Errorf("skill '%s' not found. Run 'gam skill list' to see available skills", name)
 	}
+	b.Write(content)
+	b.WriteString("\n")
 
-	fmt.Println(string(content))
-	return nil
+	out := b.String()
+	if agentID != "" {
+		out = strings.ReplaceAll(out, agentIDPlaceholder, agentID)
+	}
+	return out, nil
+}
+
+// findSkillsFS returns the project's skills/ directory, or one next to the
+// executable, falling back to the skill prompts embedded in the binary.
+func findSkillsFS() fs.FS {
+	if dir := findSkillsDir(); dir != "" {
+		return os.DirFS(dir)
+	}
+	return skills.FS
 }
 
+// findSkillsDir returns the on-disk skills directory, or "" if there is none.
 func findSkillsDir() string {
 	// Check project root first
 	dir := filepath.Join(projectRoot(), "skills")
@@ -117,8 +176,7 @@ func findSkillsDir() string {
 		return dir
 	}
 
-	// Fallback to project root
-	return filepath.Join(projectRoot(), "skills")
+	return ""
 }
 
 func extractDescription(content string) string {
@@ -138,6 +196,8 @@ func extractDescription(content string) string {
 
 func init() {
 	skillCmd.Flags().Bool("full", false, "Combine base-agent prompt with the requested skill")
+	skillCmd.Flags().String("output", "", "Write the skill to a file instead of stdout")
+	skillCmd.Flags().String("agent-id", "", "Substitute {{agent_id}} placeholders in the skill")
 
 	skillCmd.AddCommand(skillListCmd)
 }
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/config"
+)
+
+func TestSkillListAfterInit(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("GAM_PROJECT_ROOT", root)
+	defer func() { minimal = false }()
+
+	rootCmd.SetArgs([]string{"init", "--minimal"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("gam init --minimal: %v", err)
+	}
+
+	for _, name := range []string{"base-agent", "memorizer", "researcher", "gardener"} {
+		if _, err := os.Stat(filepath.Join(root, "skills", name+".md")); err != nil {
+			t.Errorf("skills/%s.md not scaffolded: %v", name, err)
+		}
+	}
+
+	rootCmd.SetArgs([]string{"skill", "list"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("gam skill list: %v", err)
+	}
+}
+
+func TestScaffoldSkillsKeepsExistingFiles(t *testing.T) {
+	root := t.TempDir()
+	custom := filepath.Join(root, "skills", "memorizer.md")
+	os.MkdirAll(filepath.Dir(custom), 0755)
+	os.WriteFile(custom, []byte("# Custom\n\nProject-specific memorizer.\n"), 0644)
+
+	created, err := scaffoldSkills(root)
+	if err != nil {
+		t.Fatalf("scaffoldSkills: %v", err)
+	}
+	if created == 0 {
+		t.Error("expected default skills to be created")
+	}
+
+	data, _ := os.ReadFile(custom)
+	if string(data) != "# Custom\n\nProject-specific memorizer.\n" {
+		t.Errorf("existing skill overwritten: %q", data)
+	}
+}
+
+// setSkillRoot points cfg at root for the duration of the test.
+func setSkillRoot(t *testing.T, root string) {
+	t.Helper()
+	oldCfg := cfg
+	t.Cleanup(func() { cfg = oldCfg })
+	cfg = &config.Config{ProjectRoot: root}
+}
+
+func TestEmbeddedSkillsWithoutSkillsDir(t *testing.T) {
+	setSkillRoot(t, t.TempDir())
+
+	if dir := findSkillsDir(); dir != "" {
+		t.Fatalf("findSkillsDir = %q, want none", dir)
+	}
+
+	data, err := fs.ReadFile(findSkillsFS(), "memorizer.md")
+	if err != nil || !strings.Contains(string(data), "Memorizer") {
+		t.Errorf("embedded memorizer.md not readable: %v", err)
+	}
+
+	if err := printSkill("researcher", true, ""); err != nil {
+		t.Errorf("printSkill(researcher, full): %v", err)
+	}
+	if err := listSkills(); err != nil {
+		t.Errorf("listSkills: %v", err)
+	}
+}
+
+func TestProjectSkillsOverrideEmbedded(t *testing.T) {
+	root := t.TempDir()
+	setSkillRoot(t, root)
+	os.MkdirAll(filepath.Join(root, "skills"), 0755)
+	os.WriteFile(filepath.Join(root, "skills", "memorizer.md"), []byte("# Custom memorizer\n"), 0644)
+
+	data, err := fs.ReadFile(findSkillsFS(), "memorizer.md")
+	if err != nil {
+		t.Fatalf("read memorizer.md: %v", err)
+	}
+	if string(data) != "# Custom memorizer\n" {
+		t.Errorf("got embedded skill, want project override: %q", data)
+	}
+}
+
+func TestWriteSkillFullComposesBaseAndSkill(t *testing.T) {
+	root := t.TempDir()
+	setSkillRoot(t, root)
+	os.MkdirAll(filepath.Join(root, "skills"), 0755)
+	os.WriteFile(filepath.Join(root, "skills", "base-agent.md"), []byte("# Base\n\nYou are {{agent_id}}.\n"), 0644)
+	os.WriteFile(filepath.Join(root, "skills", "researcher.md"), []byte("# Researcher\n\nWrite code.\n"), 0644)
+
+	out := filepath.Join(root, "prompts", "nested", "researcher.md")
+	if err := writeSkill("researcher", true, "researcher-7", out); err != nil {
+		t.Fatalf("writeSkill: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{"# Base", "You are researcher-7.", "---", "# Researcher", "Write code."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "# Base") > strings.Index(got, "# Researcher") {
+		t.Error("base-agent should precede the requested skill")
+	}
+	if strings.Contains(got, agentIDPlaceholder) {
+		t.Error("agent_id placeholder was not substituted")
+	}
+}
+
+func TestEmbeddedSkillsHaveNoAgentIDPlaceholder(t *testing.T) {
+	setSkillRoot(t, t.TempDir())
+
+	got, err := composeSkill("researcher", true, "")
+	if err != nil {
+		t.Fatalf("composeSkill: %v", err)
+	}
+	if strings.Contains(got, agentIDPlaceholder) {
+		t.Errorf("shipped skill prompts contain a literal %s:\n%.300s", agentIDPlaceholder, got)
+	}
+}
@@ -4,9 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/config"
 	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/spf13/cobra"
 )
 
@@ -46,65 +52,104 @@ var syncAddCmd = &cobra.Command{
 			}
 		}
 
-		whenJSON, _ := json.Marshal(sync.WhenClause)
-		whereJSON, _ := json.Marshal(sync.WhereClause)
-		thenJSON, _ := json.Marshal(sync.ThenClause)
+		tx, err := pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
 
-		_, err = pool.Exec(ctx, `
-			INSERT INTO synchronizations (name, when_clause, where_clause, then_clause, description, enabled)
-			VALUES ($1, $2, $3, $4, $5, true)
-			ON CONFLICT (name) DO UPDATE
-			SET when_clause = $2, where_clause = $3, then_clause = $4,
-			    description = $5, updated_at = NOW()
-		`, sync.Name, whenJSON, whereJSON, thenJSON, sync.Description)
+		exists, err := memorizer.SyncExists(ctx, tx, sync.Name)
 		if err != nil {
-			return fmt.Errorf("insert sync: %w", err)
+			return err
+		}
+		if err := memorizer.UpsertSync(ctx, tx, sync); err != nil {
+			return err
+		}
+		action := memorizer.SyncAuditAdd
+		if exists {
+			action = memorizer.SyncAuditUpdate
+		}
+		if err := memorizer.RecordSyncAudit(ctx, tx, sync.Name, action, currentActor(), specFile); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("commit sync: %w", err)
 		}
 
-		// Build sync_refs index
-		var syncID string
-		pool.QueryRow(ctx, "SELECT id FROM synchronizations WHERE name = $1", sync.Name).Scan(&syncID)
+		fmt.Printf("Sync '%s' registered.\n", name)
+		return nil
+	},
+}
 
-		if syncID != "" {
-			// Clear old refs
-			pool.Exec(ctx, "DELETE FROM sync_refs WHERE sync_id = $1", syncID)
+var syncImportCmd = &cobra.Command{
+	Use:   "import [dir]",
+	Short: "Import every *.json sync spec in a directory",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
 
-			// Index when clause
-			for _, w := range sync.WhenClause {
-				pool.Exec(ctx, `
-					INSERT INTO sync_refs (sync_id, concept_name, action_name, clause_type)
-					VALUES ($1, $2, $3, 'when')
-					ON CONFLICT DO NOTHING
-				`, syncID, w.Concept, w.Action)
-			}
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
 
-			// Index then clause
-			for _, t := range sync.ThenClause {
-				pool.Exec(ctx, `
-					INSERT INTO sync_refs (sync_id, concept_name, action_name, clause_type)
-					VALUES ($1, $2, $3, 'then')
-					ON CONFLICT DO NOTHING
-				`, syncID, t.Concept, t.Action)
+		known := make(map[string]bool)
+		rows, err := pool.Query(ctx, `SELECT name FROM concepts`)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				rows.Close()
+				return err
 			}
+			known[name] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("load concepts: %w", err)
+		}
 
-			// Index where clause
-			for _, w := range sync.WhereClause {
-				for _, patternVal := range w.Pattern {
-					if fields, ok := patternVal.(map[string]any); ok {
-						for fieldName := range fields {
-							pool.Exec(ctx, `
-								INSERT INTO sync_refs (sync_id, concept_name, state_field, clause_type)
-								VALUES ($1, $2, $3, 'where')
-								ON CONFLICT DO NOTHING
-							`, syncID, w.Concept, fieldName)
-						}
-					}
-				}
+		tx, err := pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		results, err := memorizer.ImportSyncs(ctx, tx, args[0], known, force)
+		if err != nil {
+			return err
+		}
+		actor := currentActor()
+		for _, r := range results {
+			if r.Err != nil {
+				continue
 			}
+			if err := memorizer.RecordSyncAudit(ctx, tx, r.Name, memorizer.SyncAuditImport, actor, r.File); err != nil {
+				return err
+			}
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("commit import: %w", err)
 		}
+		return reportImport("sync", results)
+	},
+}
 
-		fmt.Printf("Sync '%s' registered.\n", name)
-		return nil
+var syncTemplateCmd = &cobra.Command{
+	Use:   "template [name]",
+	Short: "Print a sync spec skeleton with example clauses to start from",
+	Long: `Print a synchronization JSON skeleton with one example when, where and then
+clause using placeholder concepts and ?variables. Redirect it to a file,
+replace the placeholders, then register it with gam sync add --spec.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := os.Stdout.Write(gam.SyncTemplate(args[0]))
+		return err
 	},
 }
 
@@ -113,6 +158,15 @@ var syncListCmd = &cobra.Command{
 	Short: "List all synchronizations",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		conceptFilter, _ := cmd.Flags().GetString("concept")
+		onlyEnabled, _ := cmd.Flags().GetBool("enabled")
+		onlyDisabled, _ := cmd.Flags().GetBool("disabled")
+		if onlyEnabled && onlyDisabled {
+			return fmt.Errorf("--enabled and --disabled are mutually exclusive")
+		}
+		var enabled *bool
+		if onlyEnabled || onlyDisabled {
+			enabled = &onlyEnabled
+		}
 
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
@@ -121,50 +175,63 @@ var syncListCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		var query string
-		var queryArgs []any
 		if conceptFilter != "" {
-			query = `
-				SELECT DISTINCT s.name, s.description, s.enabled
-				FROM synchronizations s
-				JOIN sync_refs sr ON sr.sync_id = s.id
-				WHERE sr.concept_name = $1
-				ORDER BY s.name
-			`
-			queryArgs = []any{conceptFilter}
+			fmt.Printf("Syncs referencing concept '%s':\n", conceptFilter)
 		} else {
-			query = `SELECT name, description, enabled FROM synchronizations ORDER BY name`
+			fmt.Println("Synchronizations:")
 		}
+		return listSyncs(ctx, pool, os.Stdout, conceptFilter, enabled)
+	},
+}
 
-		rows, err := pool.Query(ctx, query, queryArgs...)
-		if err != nil {
+// syncListQuery builds the sync list query, filtered by referenced concept
+// when concept is set and by enabled state when enabled is non-nil.
+func syncListQuery(concept string, enabled *bool) (string, []any) {
+	query := `SELECT DISTINCT s.name, s.description, s.enabled FROM synchronizations s`
+	var where []string
+	var args []any
+	if concept != "" {
+		query += ` JOIN sync_refs sr ON sr.sync_id = s.id`
+		args = append(args, concept)
+		where = append(where, fmt.Sprintf("sr.concept_name = $%d", len(args)))
+	}
+	if enabled != nil {
+		args = append(args, *enabled)
+		where = append(where, fmt.Sprintf("s.enabled = $%d", len(args)))
+	}
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	return query + " ORDER BY s.name", args
+}
+
+// listSyncs writes one line per sync matching the filters.
+func listSyncs(ctx context.Context, pool *pgxpool.Pool, w io.Writer, concept string, enabled *bool) error {
+	query, queryArgs := syncListQuery(concept, enabled)
+	rows, err := pool.Query(ctx, query, queryArgs...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var desc *string
+		var enabled bool
+		if err := rows.Scan(&name, &desc, &enabled); err != nil {
 			return err
 		}
-		defer rows.Close()
-
-		if conceptFilter != "" {
-			fmt.Printf("Syncs referencing concept '%s':\n", conceptFilter)
-		} else {
-			fmt.Println("Synchronizations:")
+		status := "enabled"
+		if !enabled {
+			status = "disabled"
 		}
-
-		for rows.Next() {
-			var name string
-			var desc *string
-			var enabled bool
-			rows.Scan(&name, &desc, &enabled)
-			status := "enabled"
-			if !enabled {
-				status = "disabled"
-			}
-			descStr := ""
-			if desc != nil {
-				descStr = *desc
-			}
-			fmt.Printf("  %-30s [%s] %s\n", name, status, descStr)
+		descStr := ""
+		if desc != nil {
+			descStr = *desc
 		}
-		return nil
-	},
+		fmt.Fprintf(w, "  %-30s [%s] %s\n", name, status, descStr)
+	}
+	return rows.Err()
 }
 
 var syncShowCmd = &cobra.Command{
@@ -173,6 +240,15 @@ var syncShowCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
+		trace, _ := cmd.Flags().GetBool("trace")
+		window := cfg.GardenerDriftWindow
+		if cmd.Flags().Changed("window") {
+			v, _ := cmd.Flags().GetString("window")
+			var err error
+			if window, err = config.ParseWindow(v); err != nil {
+				return fmt.Errorf("--window: %w", err)
+			}
+		}
 
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
@@ -241,10 +317,41 @@ var syncShowCmd = &cobra.Command{
 			rows.Close()
 		}
 
+		if trace {
+			firings, err := memorizer.RecentSyncFirings(ctx, pool, name, window)
+			if err != nil {
+				return fmt.Errorf("sync trace: %w", err)
+			}
+			writeSyncTrace(os.Stdout, firings, enabled)
+		}
+
 		return nil
 	},
 }
 
+// formatWindow renders whole-day windows as "Nd" and others as a duration.
+func formatWindow(d time.Duration) string {
+	if d > 0 && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
+
+// writeSyncTrace prints a sync's recent firings for sync show --trace. An
+// enabled sync that has not fired in the window gets a drift hint.
+func writeSyncTrace(w io.Writer, f memorizer.SyncFirings, enabled bool) {
+	fmt.Fprintf(w, "\nRuntime (last %s):\n", formatWindow(f.Window))
+	fmt.Fprintf(w, "  Fired: %d time(s)\n", f.Count)
+	if f.LastFired != nil {
+		fmt.Fprintf(w, "  Last fired: %s\n", f.LastFired.Format(time.RFC3339))
+	} else {
+		fmt.Fprintln(w, "  Last fired: never")
+	}
+	if enabled && f.Count == 0 {
+		fmt.Fprintln(w, "  No firings in window; if its when-actions are completing, check the where clause (see gardener sync_drift).")
+	}
+}
+
 var syncCheckCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Verify all sync references are valid",
@@ -298,12 +405,215 @@ var syncCheckCmd = &cobra.Command{
 	},
 }
 
+var syncReindexCmd = &cobra.Command{
+	Use:   "reindex [name]",
+	Short: "Rebuild sync_refs from stored sync clauses (all syncs if no name given)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		n, err := memorizer.ReindexSyncRefs(ctx, pool, name)
+		if err != nil {
+			return fmt.Errorf("reindex sync refs: %w", err)
+		}
+
+		fmt.Printf("Reindexed sync_refs for %d sync(s).\n", n)
+		return nil
+	},
+}
+
+var syncGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Render the synchronization network (DOT or Mermaid)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		conceptFilter, _ := cmd.Flags().GetString("concept")
+		if format != "dot" && format != "mermaid" {
+			return fmt.Errorf("--format must be dot or mermaid")
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		rows, err := pool.Query(ctx, `
+			SELECT s.name, s.enabled, sr.concept_name, sr.action_name, sr.clause_type
+			FROM sync_refs sr
+			JOIN synchronizations s ON s.id = sr.sync_id
+			WHERE sr.clause_type IN ('when', 'then') AND sr.action_name IS NOT NULL
+			ORDER BY s.name
+		`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var syncs []gam.Synchronization
+		bySync := make(map[string]int)
+		for rows.Next() {
+			var name, concept, action, clause string
+			var enabled bool
+			if err := rows.Scan(&name, &enabled, &concept, &action, &clause); err != nil {
+				return err
+			}
+			i, ok := bySync[name]
+			if !ok {
+				i = len(syncs)
+				bySync[name] = i
+				syncs = append(syncs, gam.Synchronization{Name: name, Enabled: enabled})
+			}
+			if clause == "when" {
+				syncs[i].WhenClause = append(syncs[i].WhenClause, gam.WhenPattern{Concept: concept, Action: action})
+			} else {
+				syncs[i].ThenClause = append(syncs[i].ThenClause, gam.ThenAction{Concept: concept, Action: action})
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		graph := gam.BuildSyncGraph(syncs)
+		if conceptFilter != "" {
+			graph = graph.Neighborhood(conceptFilter)
+		}
+
+		if format == "mermaid" {
+			fmt.Print(graph.Mermaid())
+		} else {
+			fmt.Print(graph.DOT())
+		}
+		return nil
+	},
+}
+
+var syncEnableCmd = &cobra.Command{
+	Use:   "enable [name]",
+	Short: "Enable a synchronization",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSyncEnabled(args[0], true)
+	},
+}
+
+var syncDisableCmd = &cobra.Command{
+	Use:   "disable [name]",
+	Short: "Disable a synchronization",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSyncEnabled(args[0], false)
+	},
+}
+
+func setSyncEnabled(name string, enabled bool) error {
+	ctx := context.Background()
+	pool, err := connectDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	changed, err := memorizer.SetSyncEnabled(ctx, pool, name, enabled, currentActor())
+	if err != nil {
+		return err
+	}
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	if !changed {
+		fmt.Printf("Sync '%s' already %s.\n", name, state)
+		return nil
+	}
+	fmt.Printf("Sync '%s' %s.\n", name, state)
+	return nil
+}
+
+var syncAuditCmd = &cobra.Command{
+	Use:   "audit [name]",
+	Short: "Show who added, changed, enabled or disabled syncs",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		var name string
+		if len(args) == 1 {
+			name = args[0]
+		}
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		entries, err := memorizer.SyncAudit(ctx, pool, name, limit)
+		if err != nil {
+			return err
+		}
+		if asJSON {
+			if entries == nil {
+				entries = []memorizer.SyncAuditEntry{}
+			}
+			out, _ := json.MarshalIndent(entries, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+		writeSyncAudit(os.Stdout, entries)
+		return nil
+	},
+}
+
+// writeSyncAudit prints audit entries one per line, newest first as given.
+func writeSyncAudit(w io.Writer, entries []memorizer.SyncAuditEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No sync changes recorded.")
+		return
+	}
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s  %-8s %-30s by %s", e.CreatedAt.Format("2006-01-02 15:04:05"), e.Action, e.SyncName, e.Actor)
+		if e.Detail != "" {
+			fmt.Fprintf(w, " (%s)", e.Detail)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
 func init() {
 	syncAddCmd.Flags().String("spec", "", "Path to sync spec JSON file")
+	syncImportCmd.Flags().Bool("force", false, "Import syncs that reference unknown concepts")
 	syncListCmd.Flags().String("concept", "", "Filter syncs by concept name")
+	syncListCmd.Flags().Bool("enabled", false, "List only enabled syncs")
+	syncListCmd.Flags().Bool("disabled", false, "List only disabled syncs")
+	syncShowCmd.Flags().Bool("trace", false, "Show recent firings from flow_log")
+	syncShowCmd.Flags().String("window", "", "Lookback for --trace (e.g. 24h, 7d; defaults to GAM_GARDENER_DRIFT_WINDOW)")
+	syncGraphCmd.Flags().String("format", "dot", "Output format: dot|mermaid")
+	syncGraphCmd.Flags().String("concept", "", "Only show syncs touching this concept")
+	syncAuditCmd.Flags().Int("limit", 50, "Maximum entries to show (0 for all)")
+	syncAuditCmd.Flags().Bool("json", false, "Output as JSON")
 
 	syncCmd.AddCommand(syncAddCmd)
+	syncCmd.AddCommand(syncImportCmd)
+	syncCmd.AddCommand(syncTemplateCmd)
 	syncCmd.AddCommand(syncListCmd)
 	syncCmd.AddCommand(syncShowCmd)
 	syncCmd.AddCommand(syncCheckCmd)
+	syncCmd.AddCommand(syncReindexCmd)
+	syncCmd.AddCommand(syncGraphCmd)
+	syncCmd.AddCommand(syncEnableCmd)
+	syncCmd.AddCommand(syncDisableCmd)
+	syncCmd.AddCommand(syncAuditCmd)
 }
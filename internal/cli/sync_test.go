@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestSyncListQuery(t *testing.T) {
+	disabled := false
+	query, args := syncListQuery("Search", &disabled)
+	if !strings.Contains(query, "sr.concept_name = $1 AND s.enabled = $2") {
+		t.Errorf("query = %s", query)
+	}
+	if !reflect.DeepEqual(args, []any{"Search", false}) {
+		t.Errorf("args = %v", args)
+	}
+
+	query, args = syncListQuery("", nil)
+	if strings.Contains(query, "WHERE") || len(args) != 0 {
+		t.Errorf("unfiltered query = %s %v", query, args)
+	}
+}
+
+func TestListSyncsEnabledFilter(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	suffix := time.Now().UnixNano()
+	on := fmt.Sprintf("ListOn%d", suffix)
+	off := fmt.Sprintf("ListOff%d", suffix)
+	for name, enabled := range map[string]bool{on: true, off: false} {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO synchronizations (name, when_clause, then_clause, enabled)
+			VALUES ($1, '[]', '[]', $2)
+		`, name, enabled); err != nil {
+			t.Fatalf("insert sync: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), "DELETE FROM synchronizations WHERE name IN ($1, $2)", on, off)
+	})
+
+	list := func(enabled *bool) string {
+		var buf bytes.Buffer
+		if err := listSyncs(ctx, pool, &buf, "", enabled); err != nil {
+			t.Fatalf("listSyncs: %v", err)
+		}
+		return buf.String()
+	}
+	yes, no := true, false
+
+	if out := list(&no); !strings.Contains(out, off) || strings.Contains(out, on) {
+		t.Errorf("--disabled output:\n%s", out)
+	}
+	if out := list(&yes); strings.Contains(out, off) || !strings.Contains(out, on) {
+		t.Errorf("--enabled output:\n%s", out)
+	}
+	if out := list(nil); !strings.Contains(out, off) || !strings.Contains(out, on) {
+		t.Errorf("unfiltered output:\n%s", out)
+	}
+}
+
+func TestWriteSyncTrace(t *testing.T) {
+	last := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	writeSyncTrace(&buf, memorizer.SyncFirings{Sync: "s", Window: 7 * 24 * time.Hour, Count: 4, LastFired: &last}, true)
+	for _, want := range []string{"Runtime (last 7d):", "Fired: 4 time(s)", "Last fired: 2026-03-01T09:00:00Z"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("trace missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	writeSyncTrace(&buf, memorizer.SyncFirings{Sync: "s", Window: 36 * time.Hour}, true)
+	if !strings.Contains(buf.String(), "last 36h0m0s") || !strings.Contains(buf.String(), "never") || !strings.Contains(buf.String(), "No firings in window") {
+		t.Errorf("unexpected trace for silent sync:\n%s", buf.String())
+	}
+}
+
+func TestWriteSyncAudit(t *testing.T) {
+	at := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	var buf bytes.Buffer
+	writeSyncAudit(&buf, []memorizer.SyncAuditEntry{
+		{SyncName: "FanOut", Action: "disable", Actor: "alice", CreatedAt: at},
+		{SyncName: "FanOut", Action: "import", Actor: "bob", Detail: "syncs/fan_out.json", CreatedAt: at},
+	})
+	out := buf.String()
+	for _, want := range []string{
+		"2026-03-04 05:06:07  disable  FanOut",
+		"by alice\n",
+		"by bob (syncs/fan_out.json)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	writeSyncAudit(&buf, nil)
+	if !strings.Contains(buf.String(), "No sync changes recorded") {
+		t.Errorf("empty output = %q", buf.String())
+	}
+}
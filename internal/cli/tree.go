@@ -1,8 +1,15 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 
+	"github.com/charmbracelet/x/term"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/sbenjam1n/gamsync/internal/region"
 	"github.com/spf13/cobra"
 )
@@ -12,6 +19,18 @@ var treeCmd = &cobra.Command{
 	Short: "Generate tree view from region markers in source files",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := treeOpts(cmd)
+		if fromDB, _ := cmd.Flags().GetBool("db"); fromDB {
+			return printDBTree(opts)
+		}
+		if annotate, _ := cmd.Flags().GetBool("annotate"); annotate {
+			notes, err := dbRegionNotes()
+			if err != nil {
+				return err
+			}
+			opts.Annotate = func(path string) string { return notes[path] }
+		}
+
 		dir := projectRoot()
 		if len(args) > 0 {
 			dir = args[0]
@@ -24,8 +43,21 @@ var treeCmd = &cobra.Command{
 			return fmt.Errorf("scan directory: %w", err)
 		}
 
+		if changed, _ := cmd.Flags().GetBool("changed"); changed {
+			files, err := gitChangedFiles(dir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "--changed ignored: %v\n", err)
+			} else {
+				markers = region.FilterChanged(dir, markers, files)
+				if len(markers) == 0 {
+					fmt.Println("No regions touched by working tree changes.")
+					return nil
+				}
+			}
+		}
+
 		tree := region.BuildTree(markers)
-		fmt.Print(region.FormatTree(tree, "", true))
+		fmt.Print(region.FormatTreeWithOpts(tree, opts))
 
 		if len(warnings) > 0 {
 			fmt.Println("\nWarnings:")
@@ -68,3 +100,118 @@ var treeCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// gitChangedFiles returns the absolute paths of files git status reports as
+// changed or untracked in the repository containing dir. It fails outside a
+// git work tree or when git is not installed.
+func gitChangedFiles(dir string) ([]string, error) {
+	top, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository: %s", dir)
+	}
+	out, err := exec.Command("git", "-C", dir, "status", "--porcelain", "-z", "--untracked-files=all").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w", err)
+	}
+	root := strings.TrimSpace(string(top))
+	var files []string
+	for _, f := range parsePorcelain(out) {
+		files = append(files, filepath.Join(root, filepath.FromSlash(f)))
+	}
+	return files, nil
+}
+
+// parsePorcelain extracts paths from `git status --porcelain -z` output. For
+// renames and copies only the new path is kept; deleted files are skipped
+// since they can no longer hold markers.
+func parsePorcelain(out []byte) []string {
+	var files []string
+	entries := strings.Split(string(out), "\x00")
+	for i := 0; i < len(entries); i++ {
+		e := entries[i]
+		if len(e) < 4 {
+			continue
+		}
+		status, path := e[:2], e[3:]
+		if status[0] == 'R' || status[0] == 'C' {
+			i++ // the next entry is the original path
+		}
+		if status[0] == 'D' || status[1] == 'D' {
+			continue
+		}
+		files = append(files, path)
+	}
+	return files
+}
+
+// treeOpts resolves color and width for tree output. Both default on only
+// when stdout is a terminal, so piped output stays plain.
+func treeOpts(cmd *cobra.Command) region.FormatTreeOpts {
+	fd := os.Stdout.Fd()
+	tty := term.IsTerminal(fd)
+
+	var opts region.FormatTreeOpts
+	opts.Color = tty && os.Getenv("NO_COLOR") == ""
+	if noColor, _ := cmd.Flags().GetBool("no-color"); noColor {
+		opts.Color = false
+	}
+
+	opts.Width, _ = cmd.Flags().GetInt("width")
+	if !cmd.Flags().Changed("width") && tty {
+		if w, _, err := term.GetSize(fd); err == nil {
+			opts.Width = w
+		}
+	}
+	return opts
+}
+
+// dbRegionNotes loads lifecycle and concept count annotations keyed by path.
+func dbRegionNotes() (map[string]string, error) {
+	ctx := context.Background()
+	pool, err := connectDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Close()
+
+	markers, err := memorizer.DBRegionMarkers(ctx, pool)
+	if err != nil {
+		return nil, fmt.Errorf("load regions: %w", err)
+	}
+	notes := make(map[string]string, len(markers))
+	for _, m := range markers {
+		notes[m.Path] = m.Note
+	}
+	return notes, nil
+}
+
+// printDBTree renders the region hierarchy stored in the database, annotated
+// with lifecycle state and concept counts.
+func printDBTree(opts region.FormatTreeOpts) error {
+	ctx := context.Background()
+	pool, err := connectDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	markers, err := memorizer.DBRegionMarkers(ctx, pool)
+	if err != nil {
+		return fmt.Errorf("load regions: %w", err)
+	}
+	if len(markers) == 0 {
+		fmt.Println("No regions in the database.")
+		return nil
+	}
+
+	fmt.Print(region.FormatTreeWithOpts(region.BuildTree(markers), opts))
+	return nil
+}
+
+func init() {
+	treeCmd.Flags().Bool("db", false, "Render the region hierarchy from the database instead of source markers")
+	treeCmd.Flags().Bool("annotate", false, "Annotate source regions with lifecycle state and concept counts from the database")
+	treeCmd.Flags().Bool("changed", false, "Only show regions in files changed in the git working tree (with their ancestors)")
+	treeCmd.Flags().Bool("no-color", false, "Disable lifecycle coloring")
+	treeCmd.Flags().Int("width", 0, "Truncate lines to this many columns (default: terminal width, unlimited when piped)")
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/region"
+)
+
+func TestParsePorcelain(t *testing.T) {
+	out := []byte(" M a.go\x00?? dir/b.py\x00R  new.go\x00old.go\x00 D gone.go\x00")
+	got := parsePorcelain(out)
+	want := []string{"a.go", "dir/b.py", "new.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePorcelain = %v, want %v", got, want)
+	}
+}
+
+func TestTreeChangedFiltersToTouchedRegion(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a.go", "// @region:app.a\npackage a\n// @endregion:app.a\n")
+	write("b.go", "// @region:app.b\npackage b\n// @endregion:app.b\n")
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+	write("b.go", "// @region:app.b\npackage b\n\nvar x = 1\n// @endregion:app.b\n")
+
+	files, err := gitChangedFiles(dir)
+	if err != nil {
+		t.Fatalf("gitChangedFiles: %v", err)
+	}
+	markers, _, err := region.ScanDirectory(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filtered := region.FilterChanged(dir, markers, files)
+	if len(filtered) != 1 || filtered[0].Path != "app.b" {
+		t.Fatalf("expected only app.b, got %v", filtered)
+	}
+}
+
+func TestGitChangedFilesOutsideRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+	if _, err := gitChangedFiles(t.TempDir()); err == nil {
+		t.Error("expected an error outside a git repository")
+	}
+}
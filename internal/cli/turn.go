@@ -4,8 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/config"
 	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/sbenjam1n/gamsync/internal/region"
 	"github.com/sbenjam1n/gamsync/internal/validator"
@@ -18,7 +23,9 @@ var turnCmd = &cobra.Command{
 }
 
 // captureTreeSnapshot scans source for region markers and returns a JSON-encoded
-// map of region path -> list of "file:startLine-endLine" locations.
+// map of region path -> list of "file:startLine-endLine" locations. Files are
+// root-relative with forward slashes, so snapshots taken on different machines
+// or operating systems compare equal.
 func captureTreeSnapshot(root string) ([]byte, map[string][]string) {
 	gamignore := region.ParseGamignore(root)
 	markers, _, _ := region.ScanDirectory(root, gamignore)
@@ -66,6 +73,15 @@ var turnStartCmd = &cobra.Command{
 		fmt.Printf("Turn started: %s\n", turnID)
 		fmt.Printf("Region: %s\n", regionPath)
 
+		hooks := memorizer.NewHookRegistry(pool, root)
+		if err := hooks.Fire(ctx, memorizer.HookContext{
+			Event:      memorizer.EventTurnStart,
+			TurnID:     turnID,
+			RegionPath: regionPath,
+		}); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+
 		// --- Full memory search (3 strategies) ---
 
 		// Strategy 1: Region-scoped scratchpads (ancestors + descendants)
@@ -190,15 +206,207 @@ var turnStartCmd = &cobra.Command{
 	},
 }
 
+// regionChange is a turn_regions row turn end records for a region.
+type regionChange struct {
+	Path   string
+	Action string // created, modified, deleted
+}
+
+// diffTurnRegions compares the region snapshots taken at turn start and end.
+// Regions present at end are created or modified; regions only present at
+// start are deleted. A nil before snapshot marks every region created.
+func diffTurnRegions(before, after map[string][]string) []regionChange {
+	var changes []regionChange
+	for path := range after {
+		action := "modified"
+		if before == nil || before[path] == nil {
+			action = "created"
+		}
+		changes = append(changes, regionChange{Path: path, Action: action})
+	}
+	for path := range before {
+		if after[path] == nil {
+			changes = append(changes, regionChange{Path: path, Action: "deleted"})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// registeredRegionID returns the id of the region at path, or "" when the
+// region is not registered.
+func registeredRegionID(ctx context.Context, pool *pgxpool.Pool, path string) string {
+	var regionID string
+	pool.QueryRow(ctx, "SELECT id FROM regions WHERE path = $1", path).Scan(&regionID)
+	return regionID
+}
+
+// recordTurnRegions writes a turn_regions row for each change whose region is
+// registered and returns how many were written.
+func recordTurnRegions(ctx context.Context, pool *pgxpool.Pool, turnID string, changes []regionChange) int {
+	written := 0
+	for _, c := range changes {
+		regionID := registeredRegionID(ctx, pool, c.Path)
+		if regionID == "" {
+			continue
+		}
+		_, err := pool.Exec(ctx, `
+			INSERT INTO turn_regions (turn_id, region_id, action)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (turn_id, region_id) DO UPDATE SET action = $3
+		`, turnID, regionID, c.Action)
+		if err == nil {
+			written++
+		}
+	}
+	return written
+}
+
+// backfilledTurn reports the region changes reconstructed for one turn.
+type backfilledTurn struct {
+	ID      string
+	Changes []regionChange
+	Written int
+}
+
+// backfillTurnRegions reconstructs turn_regions for COMPLETED turns that have
+// a tree_after snapshot but no region rows, diffing the snapshots the same
+// way turn end does. With dryRun set nothing is written.
+func backfillTurnRegions(ctx context.Context, pool *pgxpool.Pool, dryRun bool) ([]backfilledTurn, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT t.id, t.tree_before, t.tree_after
+		FROM turns t
+		WHERE t.status = 'COMPLETED'
+		  AND t.tree_after IS NOT NULL
+		  AND NOT EXISTS (SELECT 1 FROM turn_regions tr WHERE tr.turn_id = t.id)
+		ORDER BY t.created_at, t.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	var turns []backfilledTurn
+	for rows.Next() {
+		var id string
+		var beforeJSON, afterJSON []byte
+		if err := rows.Scan(&id, &beforeJSON, &afterJSON); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		var before, after map[string][]string
+		if beforeJSON != nil {
+			json.Unmarshal(beforeJSON, &before)
+		}
+		if err := json.Unmarshal(afterJSON, &after); err != nil {
+			continue
+		}
+		turns = append(turns, backfilledTurn{ID: id, Changes: diffTurnRegions(before, after)})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		for i := range turns {
+			turns[i].Written = recordTurnRegions(ctx, pool, turns[i].ID, turns[i].Changes)
+		}
+	}
+	return turns, nil
+}
+
+var turnBackfillRegionsCmd = &cobra.Command{
+	Use:   "backfill-regions",
+	Short: "Reconstruct turn_regions for completed turns recorded before region diffing",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		turns, err := backfillTurnRegions(ctx, pool, dryRun)
+		if err != nil {
+			return fmt.Errorf("backfill turn regions: %w", err)
+		}
+		if len(turns) == 0 {
+			fmt.Println("No completed turns are missing region rows.")
+			return nil
+		}
+
+		total := 0
+		for _, t := range turns {
+			if dryRun {
+				fmt.Printf("%s: %d region change(s)\n", t.ID, len(t.Changes))
+				for _, c := range t.Changes {
+					fmt.Printf("  %-8s %s\n", c.Action, c.Path)
+				}
+				continue
+			}
+			fmt.Printf("%s: recorded %d of %d region change(s)\n", t.ID, t.Written, len(t.Changes))
+			total += t.Written
+		}
+		if dryRun {
+			fmt.Printf("Dry run: %d turn(s) would be backfilled. No changes written.\n", len(turns))
+			return nil
+		}
+		fmt.Printf("Backfilled %d turn_regions row(s) across %d turn(s).\n", total, len(turns))
+		return nil
+	},
+}
+
+// principleGate prints golden principle findings and fails when any has block
+// severity. In strict mode a lint error also fails instead of only warning.
+func principleGate(w io.Writer, findings []memorizer.GardenFinding, lintErr error, strict bool) error {
+	if lintErr != nil {
+		if strict {
+			fmt.Fprintf(w, "\nVALIDATION FAILED: golden principles not checked: %v\n", lintErr)
+			return fmt.Errorf("validation failed: golden principles not checked: %w", lintErr)
+		}
+		fmt.Fprintf(w, "  Warning: golden principles not checked: %v\n", lintErr)
+	}
+	for _, f := range findings {
+		if f.Severity != memorizer.SeverityBlock {
+			fmt.Fprintf(w, "  Warning: %s\n    Fix: %s\n", f.Description, f.Fix)
+		}
+	}
+	if blocking := memorizer.BlockingFindings(findings); len(blocking) > 0 {
+		fmt.Fprintln(w, "\nVALIDATION FAILED: blocking golden principle violations")
+		for _, f := range blocking {
+			fmt.Fprintf(w, "  %s\n    Fix: %s\n", f.Description, f.Fix)
+		}
+		fmt.Fprintln(w, "\nTurn end blocked. Apply the fixes above and retry.")
+		return fmt.Errorf("validation failed: %d blocking golden principle violations", len(blocking))
+	}
+	return nil
+}
+
 var turnEndCmd = &cobra.Command{
 	Use:   "end",
 	Short: "End a turn: validate (blocks on failure), save memory, record structural diff",
+	Long: `End the most recent active turn. Unless --skip-validation is given, turn end
+fails on arch.md alignment issues, region marker problems, source regions
+missing from arch.md, and violations of block-severity golden principles in
+the turn's scope; warn-severity violations are only printed.
+
+--strict adds two things: it also fails when the golden principles cannot be
+checked (for example, the scope has no source files), and it prints gardener
+findings in scope without blocking. Block principles fail turn end with or
+without --strict.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		scratchpad, _ := cmd.Flags().GetString("scratchpad")
 		if scratchpad == "" {
 			return fmt.Errorf("--scratchpad is required")
 		}
 		skipValidation, _ := cmd.Flags().GetBool("skip-validation")
+		strict, _ := cmd.Flags().GetBool("strict")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if strict && skipValidation {
+			return fmt.Errorf("--strict and --skip-validation are mutually exclusive")
+		}
 
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
@@ -274,6 +482,23 @@ var turnEndCmd = &cobra.Command{
 				return fmt.Errorf("validation failed: %d unregistered regions", len(unregistered))
 			}
 
+			// Check 4: Golden principles (block severity stops the turn, warn only prints)
+			findings, lintErr := memorizer.LintGoldenPrinciples(ctx, pool, root, scopePath)
+			if err := principleGate(os.Stdout, findings, lintErr, strict); err != nil {
+				return err
+			}
+
+			// Check 5 (--strict): surface entropy in scope without blocking
+			if strict {
+				garden, err := memorizer.ScopedGardenFindings(ctx, pool, root, scopePath, cfg.GardenerTodoAge, cfg.GardenerDriftWindow)
+				if err != nil {
+					fmt.Printf("  Warning: gardener not run: %v\n", err)
+				}
+				for _, f := range garden {
+					fmt.Printf("  Gardener [%s] %s: %s\n", f.Category, f.RegionPath, f.Description)
+				}
+			}
+
 			fmt.Println("  Validation passed.")
 		}
 
@@ -284,38 +509,30 @@ var turnEndCmd = &cobra.Command{
 		if treeBeforeJSON != nil {
 			json.Unmarshal(treeBeforeJSON, &beforeSnapshot)
 		}
-
-		for path := range afterSnapshot {
-			action := "modified"
-			if beforeSnapshot == nil || beforeSnapshot[path] == nil {
-				action = "created"
+		changes := diffTurnRegions(beforeSnapshot, afterSnapshot)
+
+		if dryRun {
+			var recorded, skipped []regionChange
+			for _, c := range changes {
+				if registeredRegionID(ctx, pool, c.Path) == "" {
+					skipped = append(skipped, c)
+				} else {
+					recorded = append(recorded, c)
+				}
 			}
-			var regionID string
-			pool.QueryRow(ctx, "SELECT id FROM regions WHERE path = $1", path).Scan(&regionID)
-			if regionID != "" {
-				pool.Exec(ctx, `
-					INSERT INTO turn_regions (turn_id, region_id, action)
-					VALUES ($1, $2, $3)
-					ON CONFLICT (turn_id, region_id) DO UPDATE SET action = $3
-				`, turnID, regionID, action)
+			fmt.Printf("Dry run: turn %s would record %d region change(s):\n", turnID, len(recorded))
+			for _, c := range recorded {
+				fmt.Printf("  %-8s %s\n", c.Action, c.Path)
 			}
-		}
-		if beforeSnapshot != nil {
-			for path := range beforeSnapshot {
-				if afterSnapshot[path] == nil {
-					var regionID string
-					pool.QueryRow(ctx, "SELECT id FROM regions WHERE path = $1", path).Scan(&regionID)
-					if regionID != "" {
-						pool.Exec(ctx, `
-							INSERT INTO turn_regions (turn_id, region_id, action)
-							VALUES ($1, $2, 'deleted')
-							ON CONFLICT (turn_id, region_id) DO UPDATE SET action = 'deleted'
-						`, turnID, regionID)
-					}
-				}
+			for _, c := range skipped {
+				fmt.Printf("  %-8s %s (skipped: region not registered)\n", c.Action, c.Path)
 			}
+			fmt.Println("No changes written.")
+			return nil
 		}
 
+		recordTurnRegions(ctx, pool, turnID, changes)
+
 		// Complete the turn with scratchpad and tree_after
 		now := time.Now()
 		_, err = pool.Exec(ctx, `
@@ -329,6 +546,16 @@ var turnEndCmd = &cobra.Command{
 
 		fmt.Printf("Turn ended: %s\n", turnID)
 		fmt.Printf("Scratchpad saved.\n")
+
+		hooks := memorizer.NewHookRegistry(pool, root)
+		if err := hooks.Fire(ctx, memorizer.HookContext{
+			Event:      memorizer.EventTurnEnd,
+			TurnID:     turnID,
+			RegionPath: scopePath,
+			Scratchpad: scratchpad,
+		}); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
 		return nil
 	},
 }
@@ -337,6 +564,24 @@ var turnStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show active turns",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var f turnFilter
+		f.Role, _ = cmd.Flags().GetString("role")
+		f.Region, _ = cmd.Flags().GetString("region")
+		f.Plan, _ = cmd.Flags().GetString("plan")
+		f.Limit, _ = cmd.Flags().GetInt("limit")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		staleAfter := cfg.TurnStaleAfter
+		if cmd.Flags().Changed("stale-after") {
+			v, _ := cmd.Flags().GetString("stale-after")
+			var err error
+			if staleAfter, err = config.ParseWindow(v); err != nil {
+				return fmt.Errorf("--stale-after: %w", err)
+			}
+		}
+		if staleAfter <= 0 {
+			staleAfter = defaultTurnStaleAfter
+		}
+
 		ctx := context.Background()
 		pool, err := connectDB(ctx)
 		if err != nil {
@@ -344,47 +589,212 @@ var turnStatusCmd = &cobra.Command{
 		}
 		defer pool.Close()
 
-		rows, err := pool.Query(ctx, `
-			SELECT t.id, t.scope_path, t.task_type, t.agent_role, t.created_at,
-			       ep.name as plan_name
-			FROM turns t
-			LEFT JOIN plan_turns pt ON pt.turn_id = t.id
-			LEFT JOIN execution_plans ep ON ep.id = pt.plan_id
-			WHERE t.status = 'ACTIVE'
-			ORDER BY t.created_at DESC
-		`)
+		turns, err := listActiveTurns(ctx, pool, f)
 		if err != nil {
 			return err
 		}
-		defer rows.Close()
+		markStaleTurns(turns, time.Now(), staleAfter)
 
-		fmt.Println("Active Turns:")
-		found := false
-		for rows.Next() {
-			found = true
-			var id, scope, taskType string
-			var agentRole *string
-			var createdAt time.Time
-			var planName *string
-			rows.Scan(&id, &scope, &taskType, &agentRole, &createdAt, &planName)
-			role := "unknown"
-			if agentRole != nil {
-				role = *agentRole
+		if asJSON {
+			if turns == nil {
+				turns = []activeTurn{}
 			}
-			fmt.Printf("  %s  scope=%s  type=%s  role=%s  started=%s",
-				id, scope, taskType, role, createdAt.Format(time.RFC3339))
-			if planName != nil {
-				fmt.Printf("  plan=%s", *planName)
+			out, _ := json.MarshalIndent(turns, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		}
+		writeTurnStatus(os.Stdout, turns, staleAfter)
+		return nil
+	},
+}
+
+// defaultTurnStaleAfter is how long a turn may stay ACTIVE before turn status
+// flags it when GAM_TURN_STALE_AFTER is unset.
+const defaultTurnStaleAfter = 4 * time.Hour
+
+// markStaleTurns sets each turn's elapsed time as of now and flags turns
+// running longer than staleAfter.
+func markStaleTurns(turns []activeTurn, now time.Time, staleAfter time.Duration) {
+	for i := range turns {
+		elapsed := now.Sub(turns[i].CreatedAt)
+		turns[i].Elapsed = elapsed.Truncate(time.Second).String()
+		turns[i].Stale = elapsed > staleAfter
+	}
+}
+
+// writeTurnStatus prints one line per active turn with its elapsed time,
+// marking stale turns and suggesting how to abort them.
+func writeTurnStatus(w io.Writer, turns []activeTurn, staleAfter time.Duration) {
+	fmt.Fprintln(w, "Active Turns:")
+	stale := 0
+	for _, t := range turns {
+		role := t.Role
+		if role == "" {
+			role = "unknown"
+		}
+		fmt.Fprintf(w, "  %s  scope=%s  type=%s  role=%s  started=%s  elapsed=%s",
+			t.ID, t.Scope, t.TaskType, role, t.CreatedAt.Format(time.RFC3339), t.Elapsed)
+		if t.Plan != "" {
+			fmt.Fprintf(w, "  plan=%s", t.Plan)
+		}
+		if t.Stale {
+			fmt.Fprint(w, "  STALE")
+			stale++
+		}
+		fmt.Fprintln(w)
+	}
+	if len(turns) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	if stale > 0 {
+		fmt.Fprintf(w, "\n%d turn(s) active longer than %s may be stuck. Abort with: gam turn abort <id>\n", stale, staleAfter)
+	}
+}
+
+var turnConflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "List active turns whose scopes overlap (ancestor/descendant)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		// Conflict detection only reads turns; no Redis client is needed.
+		m := memorizer.New(pool, nil, projectRoot())
+		conflicts, err := m.DetectScopeConflicts(ctx)
+		if err != nil {
+			return fmt.Errorf("detect scope conflicts: %w", err)
+		}
+
+		if asJSON {
+			if conflicts == nil {
+				conflicts = []memorizer.Conflict{}
 			}
-			fmt.Println()
+			out, _ := json.MarshalIndent(conflicts, "", "  ")
+			fmt.Println(string(out))
+			return nil
 		}
-		if !found {
-			fmt.Println("  (none)")
+		writeTurnConflicts(os.Stdout, conflicts)
+		return nil
+	},
+}
+
+// writeTurnConflicts prints one line per overlapping pair of active turns.
+func writeTurnConflicts(w io.Writer, conflicts []memorizer.Conflict) {
+	if len(conflicts) == 0 {
+		fmt.Fprintln(w, "No overlapping active turns.")
+		return
+	}
+	fmt.Fprintln(w, "Overlapping active turns:")
+	for _, c := range conflicts {
+		fmt.Fprintf(w, "  %s (%s)  contains  %s (%s)\n",
+			c.Ancestor.ID, c.Ancestor.Scope, c.Descendant.ID, c.Descendant.Scope)
+	}
+	fmt.Fprintf(w, "\n%d conflict(s). Proposals from these turns will serialize on the region lock; coordinate or abort one with: gam turn abort <id>\n", len(conflicts))
+}
+
+var turnAbortCmd = &cobra.Command{
+	Use:   "abort [id]",
+	Short: "Abandon an active turn without validating or saving it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		pool, err := connectDB(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		tag, err := pool.Exec(ctx, `
+			UPDATE turns SET status = 'ABANDONED', completed_at = NOW()
+			WHERE id = $1 AND status = 'ACTIVE'
+		`, args[0])
+		if err != nil {
+			return err
 		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("no active turn %s", args[0])
+		}
+		fmt.Printf("Turn %s abandoned.\n", args[0])
 		return nil
 	},
 }
 
+// turnFilter narrows turn status. Region matches the scope path and its
+// descendants; Plan matches a plan's name or id; Limit 0 means no limit.
+type turnFilter struct {
+	Role   string
+	Region string
+	Plan   string
+	Limit  int
+}
+
+// activeTurn is one row of turn status.
+type activeTurn struct {
+	ID        string    `json:"id"`
+	Scope     string    `json:"scope_path"`
+	TaskType  string    `json:"task_type"`
+	Role      string    `json:"agent_role,omitempty"`
+	Plan      string    `json:"plan,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Elapsed   string    `json:"elapsed"`
+	Stale     bool      `json:"stale"`
+}
+
+// activeTurnsQuery builds the turn status query for f, newest first.
+func activeTurnsQuery(f turnFilter) (string, []any) {
+	query := `
+		SELECT t.id, COALESCE(t.scope_path::text, ''), COALESCE(t.task_type, ''),
+		       COALESCE(t.agent_role, ''), COALESCE(ep.name, ''), t.created_at
+		FROM turns t
+		LEFT JOIN plan_turns pt ON pt.turn_id = t.id
+		LEFT JOIN execution_plans ep ON ep.id = pt.plan_id
+		WHERE t.status = 'ACTIVE'`
+	var args []any
+	if f.Role != "" {
+		args = append(args, f.Role)
+		query += fmt.Sprintf(" AND t.agent_role = $%d", len(args))
+	}
+	if f.Region != "" {
+		args = append(args, f.Region)
+		query += fmt.Sprintf(" AND t.scope_path <@ $%d::ltree", len(args))
+	}
+	if f.Plan != "" {
+		args = append(args, f.Plan)
+		query += fmt.Sprintf(" AND (ep.name = $%d OR ep.id::text = $%d)", len(args), len(args))
+	}
+	query += " ORDER BY t.created_at DESC"
+	if f.Limit > 0 {
+		args = append(args, f.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	return query, args
+}
+
+func listActiveTurns(ctx context.Context, pool *pgxpool.Pool, f turnFilter) ([]activeTurn, error) {
+	query, args := activeTurnsQuery(f)
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var turns []activeTurn
+	for rows.Next() {
+		var t activeTurn
+		if err := rows.Scan(&t.ID, &t.Scope, &t.TaskType, &t.Role, &t.Plan, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		turns = append(turns, t)
+	}
+	return turns, rows.Err()
+}
+
 var turnMemoryCmd = &cobra.Command{
 	Use:   "memory [region]",
 	Short: "Query scratchpads from turns that touched a region",
@@ -518,11 +928,25 @@ func init() {
 	turnEndCmd.Flags().String("scratchpad", "", "What you did and what's next")
 	turnEndCmd.MarkFlagRequired("scratchpad")
 	turnEndCmd.Flags().Bool("skip-validation", false, "Skip validation gate (not recommended)")
+	turnEndCmd.Flags().Bool("dry-run", false, "Validate and print the region changes without writing anything")
+	turnEndCmd.Flags().Bool("strict", false, "Also fail if golden principles cannot be checked, and report gardener findings in scope")
 
 	turnCmd.AddCommand(turnStartCmd)
 	turnCmd.AddCommand(turnEndCmd)
+	turnStatusCmd.Flags().String("role", "", "Only turns with this agent role")
+	turnStatusCmd.Flags().String("region", "", "Only turns scoped to this region or beneath it")
+	turnStatusCmd.Flags().String("plan", "", "Only turns in this execution plan (name or id)")
+	turnStatusCmd.Flags().Int("limit", 0, "Show at most N turns, newest first (0 = all)")
+	turnStatusCmd.Flags().Bool("json", false, "Output as JSON")
+	turnStatusCmd.Flags().String("stale-after", "", "Flag turns active longer than this (e.g. 2h, 1d; overrides GAM_TURN_STALE_AFTER)")
 	turnCmd.AddCommand(turnStatusCmd)
+	turnCmd.AddCommand(turnAbortCmd)
+	turnConflictsCmd.Flags().Bool("json", false, "Output as JSON")
+	turnCmd.AddCommand(turnConflictsCmd)
 	turnCmd.AddCommand(turnMemoryCmd)
 	turnCmd.AddCommand(turnSearchCmd)
 	turnCmd.AddCommand(turnDiffCmd)
+
+	turnBackfillRegionsCmd.Flags().Bool("dry-run", false, "Print the reconstructed region changes without writing them")
+	turnCmd.AddCommand(turnBackfillRegionsCmd)
 }
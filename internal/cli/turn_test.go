@@ -0,0 +1,330 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/config"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestPrincipleGateStrict(t *testing.T) {
+	blocking := []memorizer.GardenFinding{{
+		RegionPath:  "app.auth",
+		Category:    "golden_principle",
+		Description: "Golden principle no-println violated",
+		Fix:         "Replace fmt.Println with log calls.",
+		Severity:    memorizer.SeverityBlock,
+	}}
+	warnOnly := []memorizer.GardenFinding{{Category: "golden_principle", Severity: memorizer.SeverityWarn}}
+	lintErr := errors.New("no source files found for region app.auth")
+
+	tests := []struct {
+		name     string
+		findings []memorizer.GardenFinding
+		lintErr  error
+		strict   bool
+		wantErr  bool
+	}{
+		{"strict blocks on block severity", blocking, nil, true, true},
+		{"default blocks on block severity", blocking, nil, false, true},
+		{"warn severity passes strict", warnOnly, nil, true, false},
+		{"lint error only warns by default", nil, lintErr, false, false},
+		{"lint error fails strict", nil, lintErr, true, true},
+	}
+	for _, tt := range tests {
+		err := principleGate(io.Discard, tt.findings, tt.lintErr, tt.strict)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDiffTurnRegions(t *testing.T) {
+	before := map[string][]string{"app.a": {"a.go:1-5"}, "app.gone": {"g.go:1-2"}}
+	after := map[string][]string{"app.a": {"a.go:1-9"}, "app.new": {"n.go:1-3"}}
+	got := diffTurnRegions(before, after)
+	want := []regionChange{{"app.a", "modified"}, {"app.gone", "deleted"}, {"app.new", "created"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffTurnRegions = %v, want %v", got, want)
+	}
+}
+
+func TestTurnEndDryRunWritesNothing(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "arch.md"), []byte("# @region:dryrun\n# @endregion:dryrun\n"), 0644)
+	os.WriteFile(filepath.Join(root, "dry.go"), []byte("// @region:dryrun\npackage dry\n// @endregion:dryrun\n"), 0644)
+	// Not registered, so the real run would skip it.
+	os.WriteFile(filepath.Join(root, "extra.go"), []byte("// @region:dryrunextra\npackage extra\n// @endregion:dryrunextra\n"), 0644)
+
+	oldCfg := cfg
+	cfg = &config.Config{DatabaseURL: pool.Config().ConnString(), ProjectRoot: root}
+	defer func() { cfg = oldCfg }()
+
+	const turnID = "dryrun-turn"
+	pool.Exec(ctx, `INSERT INTO regions (path) VALUES ('dryrun') ON CONFLICT (path) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'dryrun'`)
+	// Created in the future so it is the most recent active turn.
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO turns (id, agent_role, scope_path, status, created_at)
+		VALUES ($1, 'implementer', 'dryrun', 'ACTIVE', NOW() + INTERVAL '1 day')
+	`, turnID); err != nil {
+		t.Fatalf("insert turn: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = $1`, turnID)
+	defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = $1`, turnID)
+
+	turnEndCmd.Flags().Set("scratchpad", "preview only")
+	turnEndCmd.Flags().Set("dry-run", "true")
+	turnEndCmd.Flags().Set("skip-validation", "true")
+	defer func() {
+		turnEndCmd.Flags().Set("scratchpad", "")
+		turnEndCmd.Flags().Set("dry-run", "false")
+		turnEndCmd.Flags().Set("skip-validation", "false")
+	}()
+	stdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	runErr := turnEndCmd.RunE(turnEndCmd, nil)
+	w.Close()
+	os.Stdout = stdout
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatalf("turn end --dry-run: %v", runErr)
+	}
+	if !strings.Contains(string(out), "would record 1 region change(s)") ||
+		!strings.Contains(string(out), "dryrunextra (skipped: region not registered)") {
+		t.Errorf("preview should record dryrun and skip the unregistered region:\n%s", out)
+	}
+
+	var status string
+	var scratchpad *string
+	pool.QueryRow(ctx, `SELECT status::text, scratchpad FROM turns WHERE id = $1`, turnID).Scan(&status, &scratchpad)
+	if status != "ACTIVE" || scratchpad != nil {
+		t.Errorf("turn changed: status=%s scratchpad=%v", status, scratchpad)
+	}
+	var regions int
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM turn_regions WHERE turn_id = $1`, turnID).Scan(&regions)
+	if regions != 0 {
+		t.Errorf("dry run wrote %d turn_regions row(s)", regions)
+	}
+}
+
+func TestTurnEndPrincipleGates(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "arch.md"), []byte("# @region:gatetest\n# @endregion:gatetest\n"), 0644)
+	os.WriteFile(filepath.Join(root, "gate.go"), []byte("// @region:gatetest\npackage gate\n// @endregion:gatetest\n"), 0644)
+
+	oldCfg := cfg
+	cfg = &config.Config{DatabaseURL: pool.Config().ConnString(), ProjectRoot: root}
+	defer func() { cfg = oldCfg }()
+
+	const turnID = "gatetest-turn"
+	pool.Exec(ctx, `INSERT INTO regions (path) VALUES ('gatetest') ON CONFLICT (path) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'gatetest'`)
+	// Created in the future so it is the most recent active turn.
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO turns (id, agent_role, scope_path, status, created_at)
+		VALUES ($1, 'implementer', 'gatetest', 'ACTIVE', NOW() + INTERVAL '1 day')
+	`, turnID); err != nil {
+		t.Fatalf("insert turn: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = $1`, turnID)
+
+	const principle = "gatetest-block"
+	pool.Exec(ctx, `
+		INSERT INTO golden_principles (name, rule, lint_check, remediation, severity, enabled)
+		VALUES ($1, 'always fails', 'false', 'remove the violation', 'block', true)
+		ON CONFLICT (name) DO UPDATE SET lint_check = 'false', severity = 'block', enabled = true
+	`, principle)
+	defer pool.Exec(ctx, `DELETE FROM golden_principles WHERE name = $1`, principle)
+
+	run := func(strict bool) error {
+		turnEndCmd.Flags().Set("scratchpad", "gate test")
+		turnEndCmd.Flags().Set("dry-run", "true")
+		turnEndCmd.Flags().Set("strict", strconv.FormatBool(strict))
+		defer func() {
+			turnEndCmd.Flags().Set("scratchpad", "")
+			turnEndCmd.Flags().Set("dry-run", "false")
+			turnEndCmd.Flags().Set("strict", "false")
+		}()
+		return turnEndCmd.RunE(turnEndCmd, nil)
+	}
+
+	// A block principle fails turn end whether or not --strict is given.
+	for _, strict := range []bool{false, true} {
+		if err := run(strict); err == nil || !strings.Contains(err.Error(), "blocking golden principle") {
+			t.Errorf("strict=%t: err = %v, want the block principle to fail turn end", strict, err)
+		}
+	}
+
+	var status string
+	pool.QueryRow(ctx, `SELECT status FROM turns WHERE id = $1`, turnID).Scan(&status)
+	if status != "ACTIVE" {
+		t.Errorf("turn status = %q after a blocked turn end, want ACTIVE", status)
+	}
+
+	// A scope with no source files cannot be linted: a warning by default,
+	// a failure with --strict.
+	pool.Exec(ctx, `DELETE FROM golden_principles WHERE name = $1`, principle)
+	pool.Exec(ctx, `UPDATE turns SET scope_path = 'gatetest_nosource' WHERE id = $1`, turnID)
+	if err := run(false); err != nil {
+		t.Errorf("unlintable scope without --strict: %v, want a warning only", err)
+	}
+	if err := run(true); err == nil || !strings.Contains(err.Error(), "golden principles not checked") {
+		t.Errorf("unlintable scope with --strict: err = %v, want a failure", err)
+	}
+}
+
+func TestActiveTurnsQuery(t *testing.T) {
+	query, args := activeTurnsQuery(turnFilter{Role: "researcher", Region: "app", Plan: "p", Limit: 5})
+	for _, want := range []string{"t.agent_role = $1", "t.scope_path <@ $2::ltree", "ep.name = $3 OR ep.id::text = $3", "LIMIT $4"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query missing %q:\n%s", want, query)
+		}
+	}
+	if !reflect.DeepEqual(args, []any{"researcher", "app", "p", 5}) {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestListActiveTurnsRoleFilter(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	pool.Exec(ctx, `
+		INSERT INTO turns (id, agent_role, scope_path, status) VALUES
+		  ('statustest-impl', 'implementer', 'statustest.a', 'ACTIVE'),
+		  ('statustest-res', 'researcher', 'statustest.b', 'ACTIVE')
+	`)
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id LIKE 'statustest-%'`)
+
+	ids := func(f turnFilter) []string {
+		f.Region = "statustest"
+		turns, err := listActiveTurns(ctx, pool, f)
+		if err != nil {
+			t.Fatalf("listActiveTurns: %v", err)
+		}
+		var out []string
+		for _, turn := range turns {
+			out = append(out, turn.ID)
+		}
+		return out
+	}
+
+	if got := ids(turnFilter{}); len(got) != 2 {
+		t.Errorf("unfiltered = %v, want both turns", got)
+	}
+	if got := ids(turnFilter{Role: "researcher"}); !reflect.DeepEqual(got, []string{"statustest-res"}) {
+		t.Errorf("role filter = %v, want only the researcher turn", got)
+	}
+	if got := ids(turnFilter{Limit: 1}); len(got) != 1 {
+		t.Errorf("limit 1 = %v", got)
+	}
+}
+
+func TestTurnStatusFlagsStaleTurns(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	turns := []activeTurn{
+		{ID: "turn-old", Scope: "app.auth", TaskType: "implement", Role: "implementer", CreatedAt: now.Add(-26 * time.Hour)},
+		{ID: "turn-new", Scope: "app.search", TaskType: "implement", CreatedAt: now.Add(-90 * time.Minute)},
+	}
+	markStaleTurns(turns, now, 4*time.Hour)
+	if !turns[0].Stale || turns[1].Stale {
+		t.Errorf("stale flags = %v, %v; want only the old turn", turns[0].Stale, turns[1].Stale)
+	}
+
+	var buf bytes.Buffer
+	writeTurnStatus(&buf, turns, 4*time.Hour)
+	out := buf.String()
+	for _, want := range []string{
+		"turn-old  scope=app.auth  type=implement  role=implementer  started=2026-02-28T10:00:00Z  elapsed=26h0m0s  STALE",
+		"turn-new  scope=app.search  type=implement  role=unknown  started=2026-03-01T10:30:00Z  elapsed=1h30m0s\n",
+		"1 turn(s) active longer than 4h0m0s may be stuck. Abort with: gam turn abort <id>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestBackfillTurnRegions(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	pool.Exec(ctx, `INSERT INTO regions (path) VALUES ('backfill.kept'), ('backfill.added') ON CONFLICT (path) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'backfill'`)
+
+	const turnID = "backfill-turn"
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO turns (id, agent_role, scope_path, status, tree_before, tree_after, completed_at)
+		VALUES ($1, 'implementer', 'backfill', 'COMPLETED',
+		        '{"backfill.kept": ["a.go"]}',
+		        '{"backfill.kept": ["a.go"], "backfill.added": ["b.go"]}',
+		        NOW())
+	`, turnID); err != nil {
+		t.Fatalf("insert turn: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = $1`, turnID)
+	defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = $1`, turnID)
+
+	if _, err := backfillTurnRegions(ctx, pool, false); err != nil {
+		t.Fatalf("backfillTurnRegions: %v", err)
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT r.path::text, tr.action FROM turn_regions tr
+		JOIN regions r ON r.id = tr.region_id
+		WHERE tr.turn_id = $1 ORDER BY r.path
+	`, turnID)
+	if err != nil {
+		t.Fatalf("query turn_regions: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var path, action string
+		rows.Scan(&path, &action)
+		got = append(got, action+" "+path)
+	}
+	want := []string{"created backfill.added", "modified backfill.kept"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("turn_regions = %v, want %v", got, want)
+	}
+}
+
+func TestWriteTurnConflicts(t *testing.T) {
+	var buf bytes.Buffer
+	writeTurnConflicts(&buf, []memorizer.Conflict{{
+		Ancestor:   memorizer.ConflictTurn{ID: "turn-a", Scope: "app"},
+		Descendant: memorizer.ConflictTurn{ID: "turn-b", Scope: "app.search"},
+	}})
+	out := buf.String()
+	for _, want := range []string{"turn-a (app)  contains  turn-b (app.search)", "1 conflict(s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	writeTurnConflicts(&buf, nil)
+	if !strings.Contains(buf.String(), "No overlapping active turns.") {
+		t.Errorf("unexpected empty output: %q", buf.String())
+	}
+}
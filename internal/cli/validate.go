@@ -2,9 +2,15 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/memorizer"
 	"github.com/sbenjam1n/gamsync/internal/region"
 	"github.com/sbenjam1n/gamsync/internal/validator"
 	"github.com/spf13/cobra"
@@ -17,10 +23,43 @@ var validateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		all, _ := cmd.Flags().GetBool("all")
 		archOnly, _ := cmd.Flags().GetBool("arch")
+		fix, _ := cmd.Flags().GetBool("fix")
+		apply, _ := cmd.Flags().GetBool("apply")
+		fileMap, _ := cmd.Flags().GetStringToString("file-map")
+		explain, _ := cmd.Flags().GetBool("explain")
+		scope, _ := cmd.Flags().GetString("scope")
+		turnScope, _ := cmd.Flags().GetBool("turn-scope")
+		if (scope != "" || turnScope) && !all {
+			return fmt.Errorf("--scope and --turn-scope require --all")
+		}
+		sinceTurn, _ := cmd.Flags().GetString("since-turn")
+		reportPath, _ := cmd.Flags().GetString("report")
+		if reportPath != "" && !all {
+			return fmt.Errorf("--report requires --all")
+		}
+		if sinceTurn != "" && (all || len(args) > 0) {
+			return fmt.Errorf("--since-turn cannot be combined with --all or a region path")
+		}
+		watchTurn, _ := cmd.Flags().GetString("watch-region")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if watchTurn != "" && (all || archOnly || sinceTurn != "" || len(args) > 0) {
+			return fmt.Errorf("--watch-region cannot be combined with --all, --arch, --since-turn or a region path")
+		}
+		if watchTurn != "" && interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
 		ctx := context.Background()
 
 		root := projectRoot()
 
+		// Arch fix mode: plan (and optionally apply) alignment fixes
+		if fix {
+			if !archOnly {
+				return fmt.Errorf("--fix is only supported with --arch")
+			}
+			return runArchFix(root, fileMap, apply)
+		}
+
 		// Arch-only mode: validate arch.md without DB
 		if archOnly {
 			fmt.Println("Validating arch.md namespace alignment...")
@@ -74,10 +113,34 @@ var validateCmd = &cobra.Command{
 
 		v := validator.New(pool, root)
 
+		if watchTurn != "" {
+			ctx, stop := signalContext()
+			defer stop()
+			return runWatchRegion(ctx, os.Stdout, pool, v, root, watchTurn, interval)
+		}
+
+		if sinceTurn != "" {
+			paths, err := regionsChangedSinceTurn(ctx, pool, sinceTurn)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("=== Regions changed since %s ===\n", sinceTurn)
+			if len(paths) == 0 {
+				fmt.Println("  No regions touched by later turns.")
+				return nil
+			}
+			if failed := validateRegions(ctx, os.Stdout, v, paths); failed > 0 {
+				return fmt.Errorf("validation failed: %d region(s)", failed)
+			}
+			return nil
+		}
+
 		if all {
 			// Full project validation
+			var report validationReport
 			fmt.Println("=== arch.md alignment ===")
 			archIssues := v.ValidateArchAlignment(ctx, root)
+			report.ArchIssues = archIssues
 			archFailed := 0
 			for _, issue := range archIssues {
 				fmt.Printf("  %s\n", issue)
@@ -102,8 +165,15 @@ var validateCmd = &cobra.Command{
 
 				proposal := &gam.Proposal{
 					RegionPath: path,
+					ScopePath:  scope,
+				}
+				if turnScope && scope == "" {
+					proposal.TurnID = latestRegionTurn(ctx, pool, path)
 				}
 				result := v.Tier0Structural(ctx, proposal)
+				report.Regions = append(report.Regions, regionReport{
+					Path: path, Passed: result.Passed, Tiers: []*gam.ValidationResult{result},
+				})
 				if result.Passed {
 					passed++
 				} else {
@@ -119,6 +189,33 @@ var validateCmd = &cobra.Command{
 
 			fmt.Printf("\n  %d passed, %d failed\n", passed, failed)
 
+			fmt.Println("\n=== Concept coverage ===")
+			uncovered, err := v.RegionsWithoutConcepts(ctx)
+			if err != nil {
+				return fmt.Errorf("concept coverage: %w", err)
+			}
+			for _, path := range uncovered {
+				printConceptlessWarning(path)
+			}
+			if len(uncovered) == 0 {
+				fmt.Println("  PASSED")
+			} else {
+				fmt.Printf("\n  %d region(s) without concepts (warning)\n", len(uncovered))
+			}
+			report.Conceptless = uncovered
+
+			if reportPath != "" {
+				report.Unregioned, err = region.FindUnregionedCode(root, region.ParseGamignore(root))
+				if err != nil {
+					return fmt.Errorf("find unregioned code: %w", err)
+				}
+				report.summarize()
+				if err := writeValidationReport(reportPath, report); err != nil {
+					return fmt.Errorf("write report: %w", err)
+				}
+				fmt.Printf("\nReport written to %s\n", reportPath)
+			}
+
 			total := archFailed + failed
 			if total > 0 {
 				return fmt.Errorf("validation failed: %d total issues", total)
@@ -135,9 +232,17 @@ var validateCmd = &cobra.Command{
 		// Structural validation
 		fmt.Printf("Validating %s...\n", regionPath)
 
-		// Check region markers in source files
+		// Check region markers in source files, reading only the files the
+		// last turn snapshot recorded for this region when they are known
 		gamignore := region.ParseGamignore(root)
-		markers, warnings, _ := region.ScanDirectory(root, gamignore)
+		knownFiles, err := memorizer.KnownRegionFiles(ctx, pool, regionPath)
+		if err != nil {
+			fmt.Printf("  Warning: %v (scanning all files)\n", err)
+		}
+		markers, warnings, scoped, _ := region.ScanRegion(root, regionPath, knownFiles, gamignore)
+		if scoped {
+			fmt.Printf("  Scanned %d known file(s) for %s\n", len(knownFiles), regionPath)
+		}
 
 		found := false
 		for _, m := range markers {
@@ -171,10 +276,160 @@ var validateCmd = &cobra.Command{
 			}
 		}
 
+		if explain {
+			explanation, err := v.Explain(ctx, regionPath)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\n%s", explanation)
+		}
+
 		return nil
 	},
 }
 
+// validationReport is the JSON artifact written by validate --all --report
+// for CI: every region's tier results, the project-wide checks, and a summary
+// whose Pass matches the command's exit status.
+type validationReport struct {
+	Regions     []regionReport `json:"regions"`
+	ArchIssues  []string       `json:"arch_issues"`
+	Unregioned  []string       `json:"unregioned"`
+	Conceptless []string       `json:"conceptless"`
+	Summary     reportSummary  `json:"summary"`
+}
+
+// regionReport holds the results of the tiers run for one region.
+type regionReport struct {
+	Path   string                  `json:"path"`
+	Passed bool                    `json:"passed"`
+	Tiers  []*gam.ValidationResult `json:"tiers"`
+}
+
+type reportSummary struct {
+	Pass          bool `json:"pass"`
+	Regions       int  `json:"regions"`
+	RegionsPassed int  `json:"regions_passed"`
+	RegionsFailed int  `json:"regions_failed"`
+	ArchIssues    int  `json:"arch_issues"`
+	Unregioned    int  `json:"unregioned_files"`
+	Conceptless   int  `json:"conceptless_regions"`
+}
+
+// summarize fills in Summary from the collected results. Only region and
+// arch.md failures fail the run; unregioned code and regions without
+// concepts are warnings.
+func (r *validationReport) summarize() {
+	s := reportSummary{
+		Regions:     len(r.Regions),
+		ArchIssues:  len(r.ArchIssues),
+		Unregioned:  len(r.Unregioned),
+		Conceptless: len(r.Conceptless),
+	}
+	for _, reg := range r.Regions {
+		if reg.Passed {
+			s.RegionsPassed++
+		} else {
+			s.RegionsFailed++
+		}
+	}
+	s.Pass = s.RegionsFailed == 0 && s.ArchIssues == 0
+	r.Summary = s
+}
+
+// writeValidationReport writes report to path as indented JSON, with empty
+// lists rather than nulls so consumers can iterate unconditionally.
+func writeValidationReport(path string, report validationReport) error {
+	if report.Regions == nil {
+		report.Regions = []regionReport{}
+	}
+	for _, l := range []*[]string{&report.ArchIssues, &report.Unregioned, &report.Conceptless} {
+		if *l == nil {
+			*l = []string{}
+		}
+	}
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(out, '\n'), 0644)
+}
+
+// regionsChangedSinceTurn returns the regions that turns created after
+// turnID recorded as created or modified, sorted by path.
+func regionsChangedSinceTurn(ctx context.Context, pool *pgxpool.Pool, turnID string) ([]string, error) {
+	var exists bool
+	pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM turns WHERE id = $1)`, turnID).Scan(&exists)
+	if !exists {
+		return nil, fmt.Errorf("turn %s not found", turnID)
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT DISTINCT r.path::text
+		FROM turns ref, turns t
+		JOIN turn_regions tr ON tr.turn_id = t.id
+		JOIN regions r ON r.id = tr.region_id
+		WHERE ref.id = $1
+		  AND (t.created_at, t.id) > (ref.created_at, ref.id)
+		  AND tr.action != 'deleted'
+		ORDER BY 1
+	`, turnID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
+
+// validateRegions runs Tier 0 and, when it passes, Tier 1 on each region,
+// printing one line per region and a summary. It returns the failure count.
+func validateRegions(ctx context.Context, w io.Writer, v *validator.Validator, paths []string) int {
+	passed, failed := 0, 0
+	for _, path := range paths {
+		proposal := &gam.Proposal{RegionPath: path}
+		result := v.Tier0Structural(ctx, proposal)
+		if result.Passed {
+			var err error
+			if result, err = v.Tier1StateMachine(ctx, proposal); err != nil {
+				result = &gam.ValidationResult{Passed: false, Tier: 1, Message: err.Error()}
+			}
+		}
+		if result.Passed {
+			passed++
+			fmt.Fprintf(w, "  PASS %s\n", path)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, "  FAIL %s: %s\n", path, formatValidationResult(result))
+	}
+	fmt.Fprintf(w, "\n  %d region(s) validated: %d passed, %d failed\n", len(paths), passed, failed)
+	return failed
+}
+
+// latestRegionTurn returns the id of the most recent turn that touched
+// regionPath, or "" if none has.
+func latestRegionTurn(ctx context.Context, pool *pgxpool.Pool, regionPath string) string {
+	var turnID string
+	pool.QueryRow(ctx, `
+		SELECT t.id FROM turns t
+		JOIN turn_regions tr ON tr.turn_id = t.id
+		JOIN regions r ON r.id = tr.region_id
+		WHERE r.path = $1
+		ORDER BY t.created_at DESC
+		LIMIT 1
+	`, regionPath).Scan(&turnID)
+	return turnID
+}
+
 func formatValidationResult(r *gam.ValidationResult) string {
 	if r.Passed {
 		return "PASSED"
@@ -188,7 +443,54 @@ func formatValidationResult(r *gam.ValidationResult) string {
 	return result
 }
 
+// printConceptlessWarning reports a region no concept covers, which Tier 1
+// cannot check.
+func printConceptlessWarning(path string) {
+	fmt.Printf("  WARN %s: no concept covers this region or its ancestors; Tier 1 checks are skipped\n", path)
+	fmt.Printf("    Fix: gam concept assign <concept> %s --role implementation\n", path)
+}
+
 func init() {
-	validateCmd.Flags().Bool("all", false, "Validate entire project")
+	validateCmd.Flags().Bool("all", false, "Validate entire project (the Tier 0 scope check is skipped unless --scope or --turn-scope is set)")
+	validateCmd.Flags().String("scope", "", "With --all: check every region against this turn scope")
+	validateCmd.Flags().Bool("turn-scope", false, "With --all: check each region against the scope of the last turn that touched it")
 	validateCmd.Flags().Bool("arch", false, "Validate arch.md alignment only (no database required)")
+	validateCmd.Flags().Bool("fix", false, "With --arch: plan fixes for misaligned regions (dry run)")
+	validateCmd.Flags().Bool("apply", false, "With --fix: write the planned fixes")
+	validateCmd.Flags().StringToString("file-map", nil, "With --fix: region=file targets for scaffolding markers")
+	validateCmd.Flags().String("since-turn", "", "Run Tier 0/1 only on regions touched by turns after this one")
+	validateCmd.Flags().String("report", "", "With --all: also write a JSON report of all results to this file (for CI)")
+	validateCmd.Flags().String("watch-region", "", "Re-validate this turn's scope every --interval, printing only changes")
+	validateCmd.Flags().Duration("interval", 2*time.Second, "With --watch-region: time between checks")
+	validateCmd.Flags().Bool("explain", false, "Print the concepts, transitions, and invariants enforced for the region")
+}
+
+func runArchFix(root string, fileMap map[string]string, apply bool) error {
+	fixes, unresolved, err := region.PlanArchFixes(root, fileMap)
+	if err != nil {
+		return err
+	}
+
+	if len(fixes) == 0 && len(unresolved) == 0 {
+		fmt.Println("arch.md and source markers are aligned. Nothing to fix.")
+		return nil
+	}
+
+	for _, f := range fixes {
+		fmt.Printf("  %s\n", f)
+	}
+	for _, p := range unresolved {
+		fmt.Printf("  skip %s: no source markers and no target file (use --file-map %s=<file>)\n", p, p)
+	}
+
+	if !apply {
+		fmt.Printf("\n%d fix(es) planned (dry run). Re-run with --apply to write them.\n", len(fixes))
+		return nil
+	}
+
+	if err := region.ApplyArchFixes(root, fixes); err != nil {
+		return fmt.Errorf("apply fixes: %w", err)
+	}
+	fmt.Printf("\nApplied %d fix(es).\n", len(fixes))
+	return nil
 }
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+	"github.com/sbenjam1n/gamsync/internal/validator"
+)
+
+func TestValidateSinceTurnOnlyLaterRegions(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	pool.Exec(ctx, `INSERT INTO regions (path) VALUES ('sinceturn.before'), ('sinceturn.after') ON CONFLICT (path) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'sinceturn'`)
+	pool.Exec(ctx, `
+		INSERT INTO turns (id, agent_role, scope_path, status, created_at) VALUES
+		  ('sinceturn-1', 'implementer', 'sinceturn', 'COMPLETED', NOW() - INTERVAL '3 hours'),
+		  ('sinceturn-ref', 'implementer', 'sinceturn', 'COMPLETED', NOW() - INTERVAL '2 hours'),
+		  ('sinceturn-3', 'implementer', 'sinceturn', 'COMPLETED', NOW() - INTERVAL '1 hour')
+	`)
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id LIKE 'sinceturn-%'`)
+	pool.Exec(ctx, `
+		INSERT INTO turn_regions (turn_id, region_id, action)
+		SELECT v.turn, r.id, 'modified'
+		FROM (VALUES ('sinceturn-1', 'sinceturn.before'), ('sinceturn-3', 'sinceturn.after')) AS v(turn, p)
+		JOIN regions r ON r.path = v.p::ltree
+	`)
+	defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id LIKE 'sinceturn-%'`)
+
+	paths, err := regionsChangedSinceTurn(ctx, pool, "sinceturn-ref")
+	if err != nil {
+		t.Fatalf("regionsChangedSinceTurn: %v", err)
+	}
+	if !reflect.DeepEqual(paths, []string{"sinceturn.after"}) {
+		t.Fatalf("paths = %v, want [sinceturn.after]", paths)
+	}
+
+	var buf bytes.Buffer
+	validateRegions(ctx, &buf, validator.New(pool, t.TempDir()), paths)
+	if strings.Contains(buf.String(), "sinceturn.before") || !strings.Contains(buf.String(), "1 region(s) validated") {
+		t.Errorf("unexpected validation output:\n%s", buf.String())
+	}
+
+	if _, err := regionsChangedSinceTurn(ctx, pool, "sinceturn-missing"); err == nil {
+		t.Error("expected an error for an unknown turn")
+	}
+}
+
+func TestWriteValidationReport(t *testing.T) {
+	report := validationReport{
+		Regions: []regionReport{
+			{Path: "app.auth", Passed: true, Tiers: []*gam.ValidationResult{{Tier: 0, Passed: true, Message: "ok"}}},
+			{Path: "app.search", Passed: false, Tiers: []*gam.ValidationResult{{
+				Tier: 0, Code: 5, Message: "region markers missing",
+				Details: []gam.ValidationDetail{{Check: "markers", Fix: "gam region touch app.search --file <f>"}},
+			}}},
+		},
+		Unregioned:  []string{"scripts/tool.py"},
+		Conceptless: []string{"app.auth"},
+	}
+	report.summarize()
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := writeValidationReport(path, report); err != nil {
+		t.Fatalf("writeValidationReport: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Regions []struct {
+			Path   string `json:"path"`
+			Passed bool   `json:"passed"`
+			Tiers  []struct {
+				Tier    int    `json:"tier"`
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			} `json:"tiers"`
+		} `json:"regions"`
+		ArchIssues []string       `json:"arch_issues"`
+		Summary    map[string]any `json:"summary"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("report is not valid JSON: %v\n%s", err, data)
+	}
+	if len(got.Regions) != 2 || got.Regions[1].Path != "app.search" || got.Regions[1].Passed ||
+		len(got.Regions[1].Tiers) != 1 || got.Regions[1].Tiers[0].Code != 5 {
+		t.Errorf("regions = %+v", got.Regions)
+	}
+	if got.ArchIssues == nil {
+		t.Error("arch_issues should be an empty list, not null")
+	}
+	want := map[string]any{
+		"pass": false, "regions": 2.0, "regions_passed": 1.0, "regions_failed": 1.0,
+		"arch_issues": 0.0, "unregioned_files": 1.0, "conceptless_regions": 1.0,
+	}
+	if !reflect.DeepEqual(got.Summary, want) {
+		t.Errorf("summary = %v, want %v", got.Summary, want)
+	}
+
+	// Warnings alone do not fail the run.
+	report.Regions = report.Regions[:1]
+	report.summarize()
+	if !report.Summary.Pass {
+		t.Errorf("summary with only warnings should pass: %+v", report.Summary)
+	}
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/region"
+)
+
+// structuralChecker runs Tier 0 checks; *validator.Validator satisfies it.
+type structuralChecker interface {
+	Tier0Structural(ctx context.Context, p *gam.Proposal) *gam.ValidationResult
+}
+
+// scopeWatch re-validates the regions under a turn's scope and reports only
+// what changed since the previous check.
+type scopeWatch struct {
+	checker structuralChecker
+	root    string
+	turnID  string
+	scope   string
+
+	prev    []string
+	checked bool
+}
+
+// issues scans the source tree and returns the structural problems under the
+// scope, sorted: Tier 0 failures for every region with markers in scope, and
+// arch.md regions in scope that have no markers.
+func (s *scopeWatch) issues(ctx context.Context) ([]string, error) {
+	markers, _, err := region.ScanDirectory(s.root, region.ParseGamignore(s.root))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]string)
+	for _, m := range markers {
+		if !inScope(m.Path, s.scope) {
+			continue
+		}
+		files[m.Path] = append(files[m.Path], m.File)
+	}
+
+	var issues []string
+	for path, fs := range files {
+		p := &gam.Proposal{TurnID: s.turnID, RegionPath: path}
+		for _, f := range fs {
+			p.Evidence.ModifiedRegions = append(p.Evidence.ModifiedRegions, gam.ModifiedRegion{
+				Path: path, File: filepath.Join(s.root, filepath.FromSlash(f)),
+			})
+		}
+		result := s.checker.Tier0Structural(ctx, p)
+		if result.Passed {
+			continue
+		}
+		reported := false
+		for _, d := range result.Details {
+			if !d.Passed {
+				issues = append(issues, fmt.Sprintf("%s: %s: %s", path, d.Check, d.Got))
+				reported = true
+			}
+		}
+		if !reported {
+			issues = append(issues, fmt.Sprintf("%s: %s", path, result.Message))
+		}
+	}
+
+	archPaths, _ := region.ParseArchMd(s.root)
+	for _, path := range archPaths {
+		if inScope(path, s.scope) && files[path] == nil {
+			issues = append(issues, fmt.Sprintf("%s: declared in arch.md but has no source markers", path))
+		}
+	}
+
+	sort.Strings(issues)
+	return issues, nil
+}
+
+// check runs one validation pass and writes the delta since the last pass.
+// The first pass reports every issue.
+func (s *scopeWatch) check(ctx context.Context, w io.Writer) error {
+	issues, err := s.issues(ctx)
+	if err != nil {
+		return err
+	}
+	added, resolved := diffIssues(s.prev, issues)
+	stamp := time.Now().Format("15:04:05")
+	for _, issue := range resolved {
+		fmt.Fprintf(w, "[%s] fixed: %s\n", stamp, issue)
+	}
+	for _, issue := range added {
+		fmt.Fprintf(w, "[%s] %s\n", stamp, issue)
+	}
+	if len(issues) == 0 && (!s.checked || len(resolved) > 0) {
+		fmt.Fprintf(w, "[%s] scope %s passed Tier 0.\n", stamp, s.scope)
+	}
+	s.prev = issues
+	s.checked = true
+	return nil
+}
+
+// inScope reports whether path is scope or one of its descendants.
+func inScope(path, scope string) bool {
+	return path == scope || strings.HasPrefix(path, scope+".")
+}
+
+// runWatchRegion re-validates the scope of turnID every interval until ctx is
+// cancelled.
+func runWatchRegion(ctx context.Context, w io.Writer, pool *pgxpool.Pool, checker structuralChecker, root, turnID string, interval time.Duration) error {
+	var scope string
+	err := pool.QueryRow(ctx, `SELECT scope_path::text FROM turns WHERE id = $1`, turnID).Scan(&scope)
+	if err != nil {
+		return fmt.Errorf("turn %s not found: %w", turnID, err)
+	}
+
+	s := &scopeWatch{checker: checker, root: root, turnID: turnID, scope: scope}
+	fmt.Fprintf(w, "Watching scope %s of turn %s every %s (Ctrl-C to stop)...\n", scope, turnID, interval)
+	if err := s.check(ctx, w); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.check(ctx, w); err != nil {
+				fmt.Fprintf(w, "  scan failed: %v\n", err)
+			}
+		}
+	}
+}
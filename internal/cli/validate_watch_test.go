@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/region"
+)
+
+// archChecker fails Tier 0 for regions missing from arch.md and for files
+// without markers, like the real check without a database.
+type archChecker struct{ root string }
+
+func (c archChecker) Tier0Structural(ctx context.Context, p *gam.Proposal) *gam.ValidationResult {
+	result := &gam.ValidationResult{Tier: 0, Passed: true}
+	declared, _ := region.ParseArchMd(c.root)
+	found := false
+	for _, d := range declared {
+		found = found || d == p.RegionPath
+	}
+	if !found {
+		result.Passed = false
+		result.Details = append(result.Details, gam.ValidationDetail{Check: "region_exists", Got: "not found"})
+	}
+	return result
+}
+
+func TestScopeWatchReportsDeltas(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "arch.md"), []byte("# @region:app\n# @region:app.search\n# @endregion:app.search\n# @endregion:app\n"), 0644)
+	src := filepath.Join(root, "search.go")
+	os.WriteFile(src, []byte("// @region:app.search\npackage app\n// @endregion:app.search\n"), 0644)
+	os.WriteFile(filepath.Join(root, "other.go"), []byte("// @region:billing\npackage billing\n// @endregion:billing\n"), 0644)
+
+	s := &scopeWatch{checker: archChecker{root}, root: root, turnID: "t1", scope: "app.search"}
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := s.check(ctx, &buf); err != nil {
+		t.Fatalf("first check: %v", err)
+	}
+	if !strings.Contains(buf.String(), "scope app.search passed Tier 0") {
+		t.Errorf("first check output = %q", buf.String())
+	}
+
+	// Rename the region to one arch.md does not declare.
+	os.WriteFile(src, []byte("// @region:app.search.cache\npackage app\n// @endregion:app.search.cache\n"), 0644)
+	buf.Reset()
+	if err := s.check(ctx, &buf); err != nil {
+		t.Fatalf("second check: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"app.search.cache: region_exists: not found",
+		"app.search: declared in arch.md but has no source markers",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("second check missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "billing") || strings.Contains(out, "passed") {
+		t.Errorf("second check reported out-of-scope or stale output:\n%s", out)
+	}
+
+	// An unchanged tree prints nothing.
+	buf.Reset()
+	s.check(ctx, &buf)
+	if buf.Len() != 0 {
+		t.Errorf("unchanged check printed %q", buf.String())
+	}
+}
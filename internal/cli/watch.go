@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/sbenjam1n/gamsync/internal/validator"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Re-validate region markers against arch.md whenever source files change",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		poll, _ := cmd.Flags().GetBool("poll")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+
+		root := projectRoot()
+		patterns := region.ParseGamignore(root)
+
+		var w region.Watcher
+		if poll {
+			w = region.NewPollWatcher(root, patterns, interval)
+		} else {
+			var err error
+			if w, err = region.NewWatcher(root, patterns, interval); err != nil {
+				return err
+			}
+		}
+		defer w.Close()
+
+		ctx, stop := signalContext()
+		defer stop()
+
+		v := validator.New(nil, root)
+		var prev []string
+		check := func() {
+			issues := v.ValidateArchAlignment(ctx, root)
+			added, resolved := diffIssues(prev, issues)
+			for _, issue := range resolved {
+				fmt.Printf("  fixed: %s\n", issue)
+			}
+			for _, issue := range added {
+				fmt.Printf("  %s\n", issue)
+			}
+			if len(issues) == 0 && (prev == nil || len(resolved) > 0) {
+				fmt.Println("  arch.md alignment passed.")
+			}
+			prev = issues
+		}
+
+		fmt.Printf("Watching %s for region changes (Ctrl-C to stop)...\n", root)
+		check()
+		return watchLoop(ctx, w, debounce, check)
+	},
+}
+
+// watchLoop calls rescan once events from w have been quiet for debounce, so
+// a burst of saves triggers a single rescan. It returns nil when ctx is
+// cancelled or the watcher closes.
+func watchLoop(ctx context.Context, w region.Watcher, debounce time.Duration, rescan func()) error {
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-w.Events():
+			if !ok {
+				return nil
+			}
+			timer.Reset(debounce)
+		case <-timer.C:
+			rescan()
+		}
+	}
+}
+
+// diffIssues returns the issues in cur that were not in prev, and those in
+// prev that are no longer reported.
+func diffIssues(prev, cur []string) (added, resolved []string) {
+	prevSet := make(map[string]bool, len(prev))
+	for _, p := range prev {
+		prevSet[p] = true
+	}
+	curSet := make(map[string]bool, len(cur))
+	for _, c := range cur {
+		curSet[c] = true
+		if !prevSet[c] {
+			added = append(added, c)
+		}
+	}
+	for _, p := range prev {
+		if !curSet[p] {
+			resolved = append(resolved, p)
+		}
+	}
+	return added, resolved
+}
+
+func init() {
+	watchCmd.Flags().Bool("poll", false, "Poll for changes instead of using native file notifications")
+	watchCmd.Flags().Duration("interval", time.Second, "Polling interval")
+	watchCmd.Flags().Duration("debounce", 300*time.Millisecond, "Wait for saves to settle before rescanning")
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/validator"
+)
+
+type fakeWatcher struct{ events chan string }
+
+func (w *fakeWatcher) Events() <-chan string { return w.events }
+func (w *fakeWatcher) Close() error          { return nil }
+
+func TestWatchLoopRescansAfterChange(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "arch.md"), []byte("# @region:app\n# @endregion:app\n"), 0644)
+	src := filepath.Join(root, "app.go")
+	os.WriteFile(src, []byte("// @region:app\npackage app\n// @endregion:app\n"), 0644)
+
+	v := validator.New(nil, root)
+	results := make(chan []string, 4)
+	rescan := func() { results <- v.ValidateArchAlignment(context.Background(), root) }
+
+	w := &fakeWatcher{events: make(chan string)}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- watchLoop(ctx, w, 20*time.Millisecond, rescan) }()
+
+	// A burst of saves adding an undeclared region triggers one rescan.
+	os.WriteFile(src, []byte("// @region:app.extra\npackage app\n// @endregion:app.extra\n"), 0644)
+	for i := 0; i < 3; i++ {
+		w.events <- src
+	}
+
+	select {
+	case issues := <-results:
+		if len(issues) == 0 {
+			t.Error("rescan after change reported no issues, want app.extra missing from arch.md")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no rescan after file change")
+	}
+	select {
+	case <-results:
+		t.Error("burst of events triggered more than one rescan")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watchLoop: %v", err)
+	}
+}
+
+func TestDiffIssues(t *testing.T) {
+	added, resolved := diffIssues([]string{"a", "b"}, []string{"b", "c"})
+	if !reflect.DeepEqual(added, []string{"c"}) || !reflect.DeepEqual(resolved, []string{"a"}) {
+		t.Errorf("diffIssues = %v, %v; want [c], [a]", added, resolved)
+	}
+}
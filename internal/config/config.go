@@ -1,8 +1,15 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds all configuration for the gam CLI.
@@ -10,26 +17,236 @@ type Config struct {
 	DatabaseURL string
 	RedisURL    string
 	ProjectRoot string
+
+	// arch.md shape limits for `gam arch lint`; zero disables a check.
+	ArchMaxDepth      int
+	ArchMaxChildren   int
+	ArchRequiredRoots []string
+
+	// Proposals the Memorizer processes concurrently; zero means one.
+	ProposalWorkers int
+
+	// Gardener staleness windows; zero means the memorizer default (7 days).
+	GardenerTodoAge     time.Duration
+	GardenerDriftWindow time.Duration
+
+	// Tier 2 external validator; an empty command skips the tier.
+	Tier2Command string
+	Tier2Timeout time.Duration
+
+	// Age after which an ACTIVE turn is flagged as possibly stuck in turn
+	// status; zero means four hours.
+	TurnStaleAfter time.Duration
+
+	// Tier 3 review rounds before a proposal escalates; zero means three.
+	MaxReviewIterations int
+
+	// Minimum log level: debug, info, warn, or error.
+	LogLevel string
+
+	// Profile is the config file profile in effect, or "" for none.
+	Profile string
 }
 
-// Load reads configuration from environment variables with sensible defaults.
+// DefaultConfigFile is the profile file read from the detected project root
+// when GAM_CONFIG does not name one.
+const DefaultConfigFile = "gam.json"
+
+// Profile is one named environment in the config file. Empty fields fall
+// back to the built-in defaults.
+type Profile struct {
+	DatabaseURL string `json:"database_url"`
+	RedisURL    string `json:"redis_url"`
+	ProjectRoot string `json:"project_root"`
+}
+
+// fileConfig is the layout of the config file.
+type fileConfig struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// Load reads configuration from environment variables with sensible defaults,
+// applying the profile named by GAM_PROFILE if set.
 func Load() (*Config, error) {
-	projectRoot, err := os.Getwd()
+	return LoadProfile("")
+}
+
+// LoadProfile is Load with an explicit profile, e.g. from --profile; an empty
+// name falls back to GAM_PROFILE. A profile's values replace the built-in
+// defaults, and GAM_* environment variables override both.
+func LoadProfile(name string) (*Config, error) {
+	cwd, err := os.Getwd()
 	if err != nil {
 		return nil, fmt.Errorf("get working directory: %w", err)
 	}
+	projectRoot := FindProjectRoot(cwd)
+
+	if name == "" {
+		name = os.Getenv("GAM_PROFILE")
+	}
+	var prof Profile
+	if name != "" {
+		if prof, err = loadProfile(projectRoot, name); err != nil {
+			return nil, err
+		}
+	}
 
 	cfg := &Config{
-		DatabaseURL: getEnv("GAM_DATABASE_URL", "postgres://localhost:5432/gamsync?sslmode=disable"),
-		RedisURL:    getEnv("GAM_REDIS_URL", "redis://localhost:6379/0"),
-		ProjectRoot: getEnv("GAM_PROJECT_ROOT", projectRoot),
+		DatabaseURL:  getEnv("GAM_DATABASE_URL", orDefault(prof.DatabaseURL, "postgres://localhost:5432/gamsync?sslmode=disable")),
+		RedisURL:     getEnv("GAM_REDIS_URL", orDefault(prof.RedisURL, "redis://localhost:6379/0")),
+		ProjectRoot:  getEnv("GAM_PROJECT_ROOT", orDefault(prof.ProjectRoot, projectRoot)),
+		Profile:      name,
+		Tier2Command: os.Getenv("GAM_TIER2_COMMAND"),
+		LogLevel:     getEnv("GAM_LOG_LEVEL", "info"),
+	}
+
+	if cfg.ArchMaxDepth, err = getEnvInt("GAM_ARCH_MAX_DEPTH"); err != nil {
+		return nil, err
+	}
+	if cfg.ArchMaxChildren, err = getEnvInt("GAM_ARCH_MAX_CHILDREN"); err != nil {
+		return nil, err
+	}
+	if cfg.ProposalWorkers, err = getEnvInt("GAM_PROPOSAL_WORKERS"); err != nil {
+		return nil, err
+	}
+	if cfg.GardenerTodoAge, err = getEnvDuration("GAM_GARDENER_TODO_AGE"); err != nil {
+		return nil, err
+	}
+	if cfg.GardenerDriftWindow, err = getEnvDuration("GAM_GARDENER_DRIFT_WINDOW"); err != nil {
+		return nil, err
+	}
+	if cfg.Tier2Timeout, err = getEnvDuration("GAM_TIER2_TIMEOUT"); err != nil {
+		return nil, err
+	}
+	if cfg.TurnStaleAfter, err = getEnvDuration("GAM_TURN_STALE_AFTER"); err != nil {
+		return nil, err
+	}
+	if cfg.MaxReviewIterations, err = getEnvInt("GAM_MAX_REVIEW_ITERATIONS"); err != nil {
+		return nil, err
+	}
+	if roots := os.Getenv("GAM_ARCH_REQUIRED_ROOTS"); roots != "" {
+		for _, r := range strings.Split(roots, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				cfg.ArchRequiredRoots = append(cfg.ArchRequiredRoots, r)
+			}
+		}
 	}
 	return cfg, nil
 }
 
+// ProjectMarkers are the files or directories whose presence marks a project
+// root, checked in order in each directory FindProjectRoot visits.
+var ProjectMarkers = []string{"arch.md", ".gam"}
+
+// FindProjectRoot walks up from dir to the nearest directory containing one
+// of ProjectMarkers, so gam run from a subdirectory still finds arch.md,
+// .gamignore and migrations. It returns dir itself when no ancestor has a
+// marker.
+func FindProjectRoot(dir string) string {
+	for d := dir; ; {
+		for _, m := range ProjectMarkers {
+			if _, err := os.Stat(filepath.Join(d, m)); err == nil {
+				return d
+			}
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// loadProfile reads the named profile from GAM_CONFIG, or gam.json in dir.
+// A relative project_root is resolved against the config file's directory.
+func loadProfile(dir, name string) (Profile, error) {
+	path := os.Getenv("GAM_CONFIG")
+	if path == "" {
+		path = filepath.Join(dir, DefaultConfigFile)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Profile{}, fmt.Errorf("profile %q: config file %s not found", name, path)
+	}
+	if err != nil {
+		return Profile{}, fmt.Errorf("read config file: %w", err)
+	}
+
+	var fc fileConfig
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return Profile{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	prof, ok := fc.Profiles[name]
+	if !ok {
+		var names []string
+		for n := range fc.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Profile{}, fmt.Errorf("profile %q not found in %s (available: %s)", name, path, strings.Join(names, ", "))
+	}
+	if prof.ProjectRoot != "" && !filepath.IsAbs(prof.ProjectRoot) {
+		prof.ProjectRoot = filepath.Join(filepath.Dir(path), prof.ProjectRoot)
+	}
+	return prof, nil
+}
+
+func orDefault(v, fallback string) string {
+	if v != "" {
+		return v
+	}
+	return fallback
+}
+
 func getEnv(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
 	}
 	return fallback
 }
+
+func getEnvInt(key string) (int, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return n, nil
+}
+
+func getEnvDuration(key string) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, nil
+	}
+	d, err := ParseWindow(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return d, nil
+}
+
+// ParseWindow parses a positive duration such as "36h" or "7d". A "d" suffix
+// counts whole days.
+func ParseWindow(s string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", s)
+	}
+	return d, nil
+}
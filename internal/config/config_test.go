@@ -0,0 +1,162 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profilesJSON = `{
+  "profiles": {
+    "staging": {"database_url": "postgres://staging/gam", "redis_url": "redis://staging:6379/0", "project_root": "app"},
+    "ci": {"database_url": "postgres://ci/gam"}
+  }
+}`
+
+// profileEnv writes a config file and clears the env vars profiles interact with.
+func profileEnv(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gam.json")
+	if err := os.WriteFile(path, []byte(profilesJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GAM_CONFIG", path)
+	for _, key := range []string{"GAM_PROFILE", "GAM_DATABASE_URL", "GAM_REDIS_URL", "GAM_PROJECT_ROOT"} {
+		t.Setenv(key, "")
+	}
+	return dir
+}
+
+func TestLoadProfileSelection(t *testing.T) {
+	dir := profileEnv(t)
+
+	cfg, err := LoadProfile("staging")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if cfg.DatabaseURL != "postgres://staging/gam" || cfg.RedisURL != "redis://staging:6379/0" {
+		t.Errorf("staging urls = %s, %s", cfg.DatabaseURL, cfg.RedisURL)
+	}
+	if cfg.ProjectRoot != filepath.Join(dir, "app") || cfg.Profile != "staging" {
+		t.Errorf("project root = %s, profile = %s", cfg.ProjectRoot, cfg.Profile)
+	}
+
+	// GAM_PROFILE selects a profile when no flag is given; unset fields
+	// keep their defaults.
+	t.Setenv("GAM_PROFILE", "ci")
+	cfg, err = LoadProfile("")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if cfg.DatabaseURL != "postgres://ci/gam" || cfg.RedisURL != "redis://localhost:6379/0" {
+		t.Errorf("ci urls = %s, %s", cfg.DatabaseURL, cfg.RedisURL)
+	}
+
+	// The flag wins over GAM_PROFILE.
+	if cfg, _ = LoadProfile("staging"); cfg.Profile != "staging" {
+		t.Errorf("profile = %s, want staging", cfg.Profile)
+	}
+}
+
+func TestLoadProfileEnvPrecedence(t *testing.T) {
+	profileEnv(t)
+	t.Setenv("GAM_DATABASE_URL", "postgres://override/gam")
+
+	cfg, err := LoadProfile("staging")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if cfg.DatabaseURL != "postgres://override/gam" {
+		t.Errorf("database url = %s, want env override", cfg.DatabaseURL)
+	}
+	if cfg.RedisURL != "redis://staging:6379/0" {
+		t.Errorf("redis url = %s, want profile value", cfg.RedisURL)
+	}
+}
+
+func TestLoadWithoutProfile(t *testing.T) {
+	profileEnv(t)
+	t.Setenv("GAM_CONFIG", filepath.Join(t.TempDir(), "missing.json"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load without a profile should not read the config file: %v", err)
+	}
+	if cfg.DatabaseURL != "postgres://localhost:5432/gamsync?sslmode=disable" || cfg.Profile != "" {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+
+	if _, err := LoadProfile("staging"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected missing config file error, got %v", err)
+	}
+}
+
+func TestLoadProfileUnknown(t *testing.T) {
+	profileEnv(t)
+	_, err := LoadProfile("prod")
+	if err == nil || !strings.Contains(err.Error(), "available: ci, staging") {
+		t.Errorf("expected unknown profile error listing profiles, got %v", err)
+	}
+}
+
+func TestFindProjectRootFromNestedDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "arch.md"), []byte("# app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "internal", "search", "sources")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := FindProjectRoot(nested); got != root {
+		t.Errorf("FindProjectRoot(%s) = %s, want %s", nested, got, root)
+	}
+
+	// A .gam/ marker in a closer ancestor wins over arch.md further up.
+	inner := filepath.Join(root, "internal")
+	if err := os.Mkdir(filepath.Join(inner, ".gam"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindProjectRoot(nested); got != inner {
+		t.Errorf("FindProjectRoot with .gam = %s, want %s", got, inner)
+	}
+
+	// Load from the nested directory uses the detected root unless
+	// GAM_PROJECT_ROOT overrides it.
+	profileEnv(t)
+	t.Chdir(nested)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.ProjectRoot != inner {
+		t.Errorf("ProjectRoot = %s, want %s", cfg.ProjectRoot, inner)
+	}
+	t.Setenv("GAM_PROJECT_ROOT", "/elsewhere")
+	if cfg, _ = Load(); cfg.ProjectRoot != "/elsewhere" {
+		t.Errorf("ProjectRoot = %s, want GAM_PROJECT_ROOT override", cfg.ProjectRoot)
+	}
+}
+
+func TestFindProjectRootNoMarker(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "a", "b")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Only meaningful if no ancestor of the temp dir has a marker.
+	if got := FindProjectRoot(dir); got != dir && !hasMarker(got) {
+		t.Errorf("FindProjectRoot(%s) = %s, want the start dir", dir, got)
+	}
+}
+
+func hasMarker(dir string) bool {
+	for _, m := range ProjectMarkers {
+		if _, err := os.Stat(filepath.Join(dir, m)); err == nil {
+			return true
+		}
+	}
+	return false
+}
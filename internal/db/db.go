@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -22,15 +24,144 @@ func Connect(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
-// Migrate runs the SQL migration files against the database.
+// Extensions lists the PostgreSQL extensions the schema depends on.
+var Extensions = []string{"ltree", "pg_trgm"}
+
+// EnsureExtensions creates any missing extensions required by the schema.
+func EnsureExtensions(ctx context.Context, pool *pgxpool.Pool) error {
+	for _, ext := range Extensions {
+		if _, err := pool.Exec(ctx, fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s", ext)); err != nil {
+			return fmt.Errorf("create extension %s: %w", ext, err)
+		}
+	}
+	return nil
+}
+
+// Migrate applies all pending migrations in migrationsDir.
 func Migrate(ctx context.Context, pool *pgxpool.Pool, migrationsDir string) error {
-	sqlFile := filepath.Join(migrationsDir, "001_initial.sql")
-	sql, err := os.ReadFile(sqlFile)
+	_, err := MigrateUp(ctx, pool, migrationsDir)
+	return err
+}
+
+// MigrateUp applies each NNN_name.sql file in migrationsDir that is not yet
+// recorded in schema_migrations, in filename order, and returns the versions
+// it applied. Each migration runs in its own transaction unless it starts
+// with a -- gam:no-transaction directive (see SplitStatements).
+func MigrateUp(ctx context.Context, pool *pgxpool.Pool, migrationsDir string) ([]string, error) {
+	if _, err := pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+		  version    VARCHAR(255) PRIMARY KEY,
+		  applied_at TIMESTAMPTZ DEFAULT NOW()
+		)
+	`); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	versions, err := MigrationVersions(migrationsDir)
 	if err != nil {
-		return fmt.Errorf("read migration file: %w", err)
+		return nil, err
 	}
-	if _, err := pool.Exec(ctx, string(sql)); err != nil {
-		return fmt.Errorf("execute migration: %w", err)
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no migration files found in %s", migrationsDir)
+	}
+
+	applied := make(map[string]bool)
+	rows, err := pool.Query(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("read schema_migrations: %w", err)
+		}
+		applied[v] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	var ran []string
+	for _, version := range versions {
+		if applied[version] {
+			continue
+		}
+		sql, err := os.ReadFile(filepath.Join(migrationsDir, version+".sql"))
+		if err != nil {
+			return ran, fmt.Errorf("read migration %s: %w", version, err)
+		}
+
+		if err := applyMigration(ctx, pool, version, string(sql)); err != nil {
+			return ran, err
+		}
+		ran = append(ran, version)
+	}
+	return ran, nil
+}
+
+// applyMigration runs one migration and records its version. By default the
+// whole file is one Exec in a transaction. With -- gam:split its statements
+// run one at a time in that transaction; with -- gam:no-transaction they run
+// one at a time without one, and the version is recorded only after all
+// succeed, so a failed migration is retried from the start and its
+// statements should be idempotent.
+func applyMigration(ctx context.Context, pool *pgxpool.Pool, version, sql string) error {
+	split, noTx := migrationOptions(sql)
+	stmts := []string{sql}
+	if split {
+		stmts = SplitStatements(sql)
+	}
+
+	if noTx {
+		for i, stmt := range stmts {
+			if _, err := pool.Exec(ctx, stmt); err != nil {
+				return fmt.Errorf("execute migration %s (statement %d): %w", version, i+1, err)
+			}
+		}
+		if _, err := pool.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+			return fmt.Errorf("record migration %s: %w", version, err)
+		}
+		return nil
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	for i, stmt := range stmts {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			if split {
+				return fmt.Errorf("execute migration %s (statement %d): %w", version, i+1, err)
+			}
+			return fmt.Errorf("execute migration %s: %w", version, err)
+		}
+	}
+	if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+		return fmt.Errorf("record migration %s: %w", version, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit migration %s: %w", version, err)
 	}
 	return nil
 }
+
+// MigrationVersions returns the versions (file names without .sql) of the
+// migrations in dir, sorted so that 001_x runs before 002_y.
+func MigrationVersions(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+	var versions []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		versions = append(versions, strings.TrimSuffix(e.Name(), ".sql"))
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
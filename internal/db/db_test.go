@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMigrationVersions(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"002_add_column.sql", "001_initial.sql", "010_later.sql", "README.md"} {
+		os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0644)
+	}
+	os.Mkdir(filepath.Join(dir, "003_dir.sql"), 0755)
+
+	got, err := MigrationVersions(dir)
+	if err != nil {
+		t.Fatalf("MigrationVersions: %v", err)
+	}
+	want := []string{"001_initial", "002_add_column", "010_later"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("versions = %v, want %v", got, want)
+	}
+}
+
+func TestMigrateUpAppliesNewMigration(t *testing.T) {
+	url := os.Getenv("GAM_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("GAM_TEST_DATABASE_URL not set")
+	}
+	ctx := context.Background()
+	pool, err := Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer pool.Close()
+
+	dir := t.TempDir()
+	initial, err := os.ReadFile(filepath.Join("..", "..", "migrations", "001_initial.sql"))
+	if err != nil {
+		t.Fatalf("read initial migration: %v", err)
+	}
+	os.WriteFile(filepath.Join(dir, "001_initial.sql"), initial, 0644)
+
+	// Bring the DB to an initialized state.
+	if err := Migrate(ctx, pool, dir); err != nil {
+		t.Fatalf("initial migrate: %v", err)
+	}
+
+	const version = "999_upgrade_test"
+	cleanup := func() {
+		pool.Exec(ctx, "DROP TABLE IF EXISTS upgrade_test")
+		pool.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", version)
+	}
+	cleanup()
+	defer cleanup()
+
+	os.WriteFile(filepath.Join(dir, version+".sql"), []byte("CREATE TABLE upgrade_test (id INT);"), 0644)
+
+	applied, err := MigrateUp(ctx, pool, dir)
+	if err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+	if !reflect.DeepEqual(applied, []string{version}) {
+		t.Errorf("applied = %v, want only %s", applied, version)
+	}
+
+	var exists bool
+	pool.QueryRow(ctx, "SELECT to_regclass('upgrade_test') IS NOT NULL").Scan(&exists)
+	if !exists {
+		t.Error("upgrade_test table was not created")
+	}
+
+	applied, err = MigrateUp(ctx, pool, dir)
+	if err != nil {
+		t.Fatalf("second MigrateUp: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("second run applied %v, want nothing", applied)
+	}
+}
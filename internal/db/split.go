@@ -0,0 +1,141 @@
+package db
+
+import (
+	"bufio"
+	"strings"
+)
+
+// Migration directives, written as comment lines before the first statement:
+//
+//	-- gam:no-transaction   run each statement on its own, outside a
+//	                        transaction (e.g. CREATE INDEX CONCURRENTLY)
+//	-- gam:split            run statements one at a time inside the
+//	                        migration's transaction
+const (
+	directiveNoTransaction = "gam:no-transaction"
+	directiveSplit         = "gam:split"
+)
+
+// migrationOptions reads the directives from the leading comment lines of a
+// migration. noTx implies split.
+func migrationOptions(sql string) (split, noTx bool) {
+	sc := bufio.NewScanner(strings.NewReader(sql))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		comment, ok := strings.CutPrefix(line, "--")
+		if !ok {
+			break
+		}
+		switch strings.TrimSpace(comment) {
+		case directiveNoTransaction:
+			split, noTx = true, true
+		case directiveSplit:
+			split = true
+		}
+	}
+	return split, noTx
+}
+
+// SplitStatements splits sql into statements at top-level semicolons. It
+// does not split inside quoted strings and identifiers, comments, or
+// dollar-quoted bodies ($$...$$ or $tag$...$tag$), so function definitions
+// stay whole. Statements that are empty or only comments are dropped.
+func SplitStatements(sql string) []string {
+	var stmts []string
+	start := 0
+	hasCode := false
+	flush := func(end int) {
+		if hasCode {
+			stmts = append(stmts, strings.TrimSpace(sql[start:end]))
+		}
+		start = end + 1
+		hasCode = false
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == ';':
+			flush(i)
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			if nl := strings.IndexByte(sql[i:], '\n'); nl >= 0 {
+				i += nl
+			} else {
+				i = len(sql)
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			i = skipBlockComment(sql, i)
+		case c == '\'' || c == '"':
+			hasCode = true
+			i = skipQuoted(sql, i, c)
+		case c == '$':
+			hasCode = true
+			if tag := dollarTag(sql[i:]); tag != "" {
+				if end := strings.Index(sql[i+len(tag):], tag); end >= 0 {
+					i += len(tag) + end + len(tag) - 1
+				} else {
+					i = len(sql)
+				}
+			}
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			hasCode = true
+		}
+	}
+	flush(len(sql))
+	return stmts
+}
+
+// skipQuoted returns the index of the quote closing the string or identifier
+// opened at i. A doubled quote is an escaped quote, not the end.
+func skipQuoted(sql string, i int, quote byte) int {
+	for j := i + 1; j < len(sql); j++ {
+		if sql[j] == quote {
+			if j+1 < len(sql) && sql[j+1] == quote {
+				j++
+				continue
+			}
+			return j
+		}
+	}
+	return len(sql)
+}
+
+// skipBlockComment returns the index of the final '/' of the block comment
+// opened at i. PostgreSQL block comments nest.
+func skipBlockComment(sql string, i int) int {
+	depth := 0
+	for j := i; j < len(sql)-1; j++ {
+		switch {
+		case sql[j] == '/' && sql[j+1] == '*':
+			depth++
+			j++
+		case sql[j] == '*' && sql[j+1] == '/':
+			depth--
+			j++
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return len(sql)
+}
+
+// dollarTag returns the opening dollar quote at the start of s ("$$" or
+// "$tag$"), or "" when s starts with a positional parameter like $1.
+func dollarTag(s string) string {
+	for j := 1; j < len(s); j++ {
+		c := s[j]
+		switch {
+		case c == '$':
+			return s[:j+1]
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && j > 1:
+		default:
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const concurrentMigration = `-- Adds a trigger function and a concurrent index.
+-- gam:no-transaction
+
+CREATE OR REPLACE FUNCTION split_test_touch() RETURNS trigger AS $$
+BEGIN
+  NEW.updated_at := NOW(); -- semicolons inside the body stay put
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE IF NOT EXISTS split_test (id INT, note TEXT DEFAULT 'a;b', updated_at TIMESTAMPTZ);
+/* block; comment /* nested; */ still comment */
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_split_test_id ON split_test (id);
+DO $body$ BEGIN PERFORM 1; END $body$;
+`
+
+func TestSplitStatements(t *testing.T) {
+	got := SplitStatements(concurrentMigration)
+	if len(got) != 4 {
+		t.Fatalf("got %d statements, want 4:\n%q", len(got), got)
+	}
+	if want := "NEW.updated_at := NOW(); -- semicolons inside the body stay put"; !strings.Contains(got[0], want) || !strings.Contains(got[0], "LANGUAGE plpgsql") {
+		t.Errorf("function body split apart:\n%s", got[0])
+	}
+	if !strings.Contains(got[1], "'a;b'") {
+		t.Errorf("quoted semicolon split the table statement:\n%s", got[1])
+	}
+	if !strings.Contains(got[2], "CREATE INDEX CONCURRENTLY") {
+		t.Errorf("statement 3 = %q", got[2])
+	}
+	if got[3] != "DO $body$ BEGIN PERFORM 1; END $body$" {
+		t.Errorf("tagged dollar quote = %q", got[3])
+	}
+
+	if got := SplitStatements("SELECT $1::int; -- trailing\n"); !reflect.DeepEqual(got, []string{"SELECT $1::int"}) {
+		t.Errorf("positional parameter = %q", got)
+	}
+}
+
+func TestMigrationOptions(t *testing.T) {
+	if split, noTx := migrationOptions(concurrentMigration); !split || !noTx {
+		t.Errorf("no-transaction migration: split=%v noTx=%v", split, noTx)
+	}
+	if split, noTx := migrationOptions("-- gam:split\nSELECT 1;"); !split || noTx {
+		t.Errorf("split migration: split=%v noTx=%v", split, noTx)
+	}
+	// Directives after the first statement are ignored.
+	if split, noTx := migrationOptions("SELECT 1;\n-- gam:no-transaction\n"); split || noTx {
+		t.Errorf("late directive honored: split=%v noTx=%v", split, noTx)
+	}
+}
+
+func TestMigrateUpNoTransaction(t *testing.T) {
+	url := os.Getenv("GAM_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("GAM_TEST_DATABASE_URL not set")
+	}
+	ctx := context.Background()
+	pool, err := Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer pool.Close()
+
+	const version = "998_split_test"
+	cleanup := func() {
+		pool.Exec(ctx, "DROP TABLE IF EXISTS split_test")
+		pool.Exec(ctx, "DROP FUNCTION IF EXISTS split_test_touch()")
+		pool.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", version)
+	}
+	cleanup()
+	defer cleanup()
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, version+".sql"), []byte(concurrentMigration), 0644)
+	applied, err := MigrateUp(ctx, pool, dir)
+	if err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+	if !reflect.DeepEqual(applied, []string{version}) {
+		t.Errorf("applied = %v", applied)
+	}
+
+	var hasIndex, hasFunc bool
+	pool.QueryRow(ctx, "SELECT to_regclass('idx_split_test_id') IS NOT NULL").Scan(&hasIndex)
+	pool.QueryRow(ctx, "SELECT to_regproc('split_test_touch') IS NOT NULL").Scan(&hasFunc)
+	if !hasIndex || !hasFunc {
+		t.Errorf("index created = %v, function created = %v", hasIndex, hasFunc)
+	}
+}
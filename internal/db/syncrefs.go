@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+// Execer is the subset of the pgx API shared by *pgxpool.Pool and pgx.Tx.
+type Execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// BuildSyncRefs replaces the sync_refs index rows for a synchronization with
+// the entries returned by sc.Refs(). The synchronization row must already exist.
+func BuildSyncRefs(ctx context.Context, exec Execer, sc gam.Synchronization) error {
+	var syncID string
+	if err := exec.QueryRow(ctx, "SELECT id FROM synchronizations WHERE name = $1", sc.Name).Scan(&syncID); err != nil {
+		return fmt.Errorf("look up sync %s: %w", sc.Name, err)
+	}
+
+	if _, err := exec.Exec(ctx, "DELETE FROM sync_refs WHERE sync_id = $1", syncID); err != nil {
+		return fmt.Errorf("clear refs for sync %s: %w", sc.Name, err)
+	}
+
+	for _, ref := range sc.Refs() {
+		if _, err := exec.Exec(ctx, `
+			INSERT INTO sync_refs (sync_id, concept_name, action_name, state_field, clause_type)
+			VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5)
+			ON CONFLICT DO NOTHING
+		`, syncID, ref.ConceptName, ref.ActionName, ref.StateField, ref.ClauseType); err != nil {
+			return fmt.Errorf("index %s clause of sync %s: %w", ref.ClauseType, sc.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+// recordingExecer is an Execer that records statements instead of running them.
+type recordingExecer struct {
+	syncID string
+	execs  [][]any
+}
+
+func (r *recordingExecer) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	r.execs = append(r.execs, append([]any{sql}, args...))
+	return pgconn.CommandTag{}, nil
+}
+
+func (r *recordingExecer) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return idRow{id: r.syncID}
+}
+
+type idRow struct{ id string }
+
+func (r idRow) Scan(dest ...any) error {
+	*dest[0].(*string) = r.id
+	return nil
+}
+
+func TestBuildSyncRefs(t *testing.T) {
+	sc := gam.Synchronization{
+		Name: "FanOutSearch",
+		WhenClause: []gam.WhenPattern{
+			{Concept: "Web", Action: "request"},
+		},
+		WhereClause: []gam.WherePattern{
+			{Concept: "SearchSource", Pattern: map[string]any{"?s": map[string]any{"enabled": true}}},
+		},
+		ThenClause: []gam.ThenAction{
+			{Concept: "SearchSource", Action: "query"},
+			{Concept: "SearchSource", Action: "query"},
+		},
+	}
+
+	exec := &recordingExecer{syncID: "sync-1"}
+	if err := BuildSyncRefs(context.Background(), exec, sc); err != nil {
+		t.Fatalf("BuildSyncRefs: %v", err)
+	}
+
+	if len(exec.execs) == 0 || !strings.HasPrefix(exec.execs[0][0].(string), "DELETE FROM sync_refs") {
+		t.Fatalf("first statement should clear existing refs, got %v", exec.execs)
+	}
+	if exec.execs[0][1] != "sync-1" {
+		t.Errorf("delete sync_id: want sync-1, got %v", exec.execs[0][1])
+	}
+
+	var got []string
+	for _, e := range exec.execs[1:] {
+		// args: sql, sync_id, concept, action, field, clause
+		got = append(got, e[5].(string)+":"+e[2].(string)+"/"+e[3].(string)+"."+e[4].(string))
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"then:SearchSource/query.",
+		"when:Web/request.",
+		"where:SearchSource/.enabled",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("inserted refs: want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ref %d: want %s, got %s", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package gam
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// opActionPattern matches action invocations in an operational principle,
+// written either as name(args) or name[args].
+var opActionPattern = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*[\[(]`)
+
+// CheckConcept runs the structural checks on a concept definition: the spec
+// shape, state-machine coherence, and the actions its operational principle
+// invokes. It returns only failed details, in a stable order.
+func CheckConcept(c Concept) []ValidationDetail {
+	var issues []ValidationDetail
+	fail := func(check, expected, got, fix string) {
+		issues = append(issues, ValidationDetail{Check: check, Passed: false, Expected: expected, Got: got, Fix: fix})
+	}
+
+	if c.Name == "" {
+		fail("concept_name", "a concept name", "empty", "Set \"name\" in the spec file or pass a name.")
+	}
+	if c.Purpose == "" {
+		fail("concept_purpose", "a one-line purpose", "empty", "Add a \"purpose\" describing the value the concept provides.")
+	}
+
+	for _, field := range sortedKeys(c.Spec.State) {
+		sc := c.Spec.State[field]
+		switch {
+		case sc.Type == "set" && sc.Of == "":
+			fail("state_"+field, "set state names its element type", "missing \"of\"",
+				fmt.Sprintf("Set \"of\" on state field %s, e.g. {\"type\": \"set\", \"of\": \"User\"}.", field))
+		case sc.Type == "map" && (sc.From == "" || sc.To == ""):
+			fail("state_"+field, "map state names key and value types", "missing \"from\" or \"to\"",
+				fmt.Sprintf("Set \"from\" and \"to\" on state field %s.", field))
+		case sc.Type != "set" && sc.Type != "map":
+			fail("state_"+field, "state type set or map", fmt.Sprintf("%q", sc.Type),
+				fmt.Sprintf("Change state field %s to {\"type\": \"set\"} or {\"type\": \"map\"}.", field))
+		}
+	}
+
+	for _, name := range sortedKeys(c.Spec.Actions) {
+		if len(c.Spec.Actions[name].Cases) == 0 {
+			fail("action_"+name, "at least one case", "none",
+				fmt.Sprintf("Add a case with input and output to action %s.", name))
+		}
+	}
+
+	sm := c.StateMachine
+	states := make(map[string]bool)
+	for _, s := range sm.States {
+		if states[s] {
+			fail("state_machine_states", "unique states", "duplicate "+s, fmt.Sprintf("Remove the duplicate state %s.", s))
+		}
+		states[s] = true
+	}
+	for _, t := range sm.Transitions {
+		label := fmt.Sprintf("%s -> %s (%s)", t.From, t.To, t.Action)
+		for _, s := range []string{t.From, t.To} {
+			if !states[s] {
+				fail("transition_state", "transition endpoints are declared states", label+" uses undeclared state "+s,
+					fmt.Sprintf("Add %s to state_machine.states or fix the transition %s.", s, label))
+			}
+		}
+		if _, ok := c.Spec.Actions[t.Action]; !ok {
+			fail("transition_action", "transition actions are defined", label+" uses undefined action "+t.Action,
+				fmt.Sprintf("Define action %s in the spec or fix the transition %s.", t.Action, label))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range opActionPattern.FindAllStringSubmatch(c.Spec.OperationalPrinciple, -1) {
+		action := m[1]
+		if seen[action] {
+			continue
+		}
+		seen[action] = true
+		if _, ok := c.Spec.Actions[action]; !ok {
+			fail("operational_principle", "operational principle invokes defined actions", "undefined action "+action,
+				fmt.Sprintf("Define action %s or rewrite the operational principle to use existing actions.", action))
+		}
+	}
+
+	return issues
+}
+
+// CheckActionCases warns about actions with two cases whose Input maps are
+// identical: nothing distinguishes which case applies, so the outputs are
+// ambiguous. It is a non-blocking companion to CheckConcept; cases that
+// differ in any input name or type are not reported.
+func CheckActionCases(c Concept) []ValidationDetail {
+	var warnings []ValidationDetail
+	for _, name := range sortedKeys(c.Spec.Actions) {
+		cases := c.Spec.Actions[name].Cases
+		first := make(map[string]int) // input signature -> first case index
+		for i, ac := range cases {
+			sig := inputSignature(ac.Input)
+			j, dup := first[sig]
+			if !dup {
+				first[sig] = i
+				continue
+			}
+			kind := "different outputs"
+			if inputSignature(cases[j].Output) == inputSignature(ac.Output) {
+				kind = "the same output (duplicate case)"
+			}
+			warnings = append(warnings, ValidationDetail{
+				Check:    "action_case_ambiguous",
+				Passed:   false,
+				Expected: "action cases with distinct inputs",
+				Got:      fmt.Sprintf("action %s cases %d and %d have identical input %s and %s", name, j+1, i+1, sig, kind),
+				Fix:      fmt.Sprintf("Give case %d of action %s a distinguishing input, or merge it into case %d.", i+1, name, j+1),
+			})
+		}
+	}
+	return warnings
+}
+
+// inputSignature renders a case's input or output map in a canonical form,
+// e.g. {session: Session, user: User}; nil and empty maps render alike.
+func inputSignature(m map[string]string) string {
+	parts := make([]string, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		parts = append(parts, k+": "+m[k])
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// opWordPattern splits an operational principle into word tokens.
+var opWordPattern = regexp.MustCompile(`[A-Za-z_]\w*`)
+
+// opSequenceWords introduce the action a step of an operational principle
+// performs, as in "after register, then query".
+var opSequenceWords = map[string]bool{"after": true, "then": true, "before": true, "once": true}
+
+// opFillerWords may sit between a sequence word and the action it introduces.
+var opFillerWords = map[string]bool{"a": true, "an": true, "the": true, "calling": true, "call": true}
+
+// LintOperationalPrinciple is a best-effort, non-blocking companion to
+// CheckConcept for operational principles written in prose. Words that read
+// like action references - identifier-style words (snake_case or camelCase)
+// and the word following after/then/before/once - are reported when they
+// name no declared action, state field, or type, which usually means an
+// action was renamed or removed without updating the principle. Call-style
+// references such as name(args) are left to CheckConcept.
+func LintOperationalPrinciple(c Concept) []ValidationDetail {
+	op := c.Spec.OperationalPrinciple
+	known := map[string]bool{strings.ToLower(c.Name): true}
+	for name := range c.Spec.Actions {
+		known[strings.ToLower(name)] = true
+	}
+	for field, sc := range c.Spec.State {
+		for _, n := range []string{field, sc.Of, sc.From, sc.To} {
+			known[strings.ToLower(n)] = true
+		}
+	}
+	for _, p := range c.Spec.TypeParams {
+		known[strings.ToLower(p)] = true
+	}
+
+	var warnings []ValidationDetail
+	seen := make(map[string]bool)
+	afterSequence := false
+	for _, loc := range opWordPattern.FindAllStringIndex(op, -1) {
+		word := op[loc[0]:loc[1]]
+		lower := strings.ToLower(word)
+		rest := strings.TrimLeft(op[loc[1]:], " \t")
+		called := strings.HasPrefix(rest, "(") || strings.HasPrefix(rest, "[")
+		candidate := afterSequence || isIdentifierStyle(word)
+
+		switch {
+		case opSequenceWords[lower]:
+			afterSequence = true
+			continue
+		case afterSequence && opFillerWords[lower]:
+			continue
+		}
+		afterSequence = false
+
+		if !candidate || called || known[lower] || seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		fix := fmt.Sprintf("Rename %s in the operational principle to a declared action, or declare it.", word)
+		if near := nearestAction(word, c.Spec.Actions); near != "" {
+			fix = fmt.Sprintf("Did you mean %s? Update the operational principle or declare %s.", near, word)
+		}
+		warnings = append(warnings, ValidationDetail{
+			Check:    "operational_principle_reference",
+			Passed:   false,
+			Expected: "operational principle mentions declared actions",
+			Got:      "unknown action-like word " + word,
+			Fix:      fix,
+		})
+	}
+	return warnings
+}
+
+// isIdentifierStyle reports whether word is written like a code identifier:
+// snake_case, or camelCase with an inner capital.
+func isIdentifierStyle(word string) bool {
+	if strings.Contains(strings.Trim(word, "_"), "_") {
+		return true
+	}
+	for i, r := range word {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(rune(word[i-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestAction returns the declared action closest to word when it is a
+// plausible rename: within edit distance two, or sharing a four-letter prefix.
+func nearestAction(word string, actions map[string]ActionSpec) string {
+	lower := strings.ToLower(word)
+	best, bestDist := "", -1
+	for _, name := range sortedKeys(actions) {
+		n := strings.ToLower(name)
+		d := editDistance(lower, n)
+		related := d <= 2 || (len(n) >= 4 && len(lower) >= 4 && n[:4] == lower[:4])
+		if related && (bestDist < 0 || d < bestDist) {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,137 @@
+package gam
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckConcept(t *testing.T) {
+	c := Concept{
+		Name:    "Session",
+		Purpose: "authenticate requests for a period of time",
+		Spec: ConceptSpec{
+			State: map[string]StateComponent{
+				"active":  {Type: "set", Of: "Session"},
+				"expires": {Type: "map", From: "Session", To: "Time"},
+			},
+			Actions: map[string]ActionSpec{
+				"create":   {Cases: []ActionCase{{Input: map[string]string{"user": "User"}, Output: map[string]string{"session": "Session"}}}},
+				"validate": {Cases: []ActionCase{{Input: map[string]string{"session": "Session"}, Output: map[string]string{"valid": "bool"}}}},
+			},
+			OperationalPrinciple: "After create(user) succeeds, validate(session) returns valid=true until revoke(session) is called.",
+		},
+		StateMachine: StateMachine{
+			States: []string{"active", "expired"},
+			Transitions: []Transition{
+				{From: "active", To: "expired", Action: "validate"},
+				{From: "active", To: "revoked", Action: "create"},
+			},
+		},
+	}
+
+	issues := CheckConcept(c)
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2: %+v", len(issues), issues)
+	}
+	if issues[0].Check != "transition_state" || !strings.Contains(issues[0].Got, "revoked") {
+		t.Errorf("issue 0 = %+v, want dangling transition to revoked", issues[0])
+	}
+	if issues[1].Check != "operational_principle" || !strings.Contains(issues[1].Got, "revoke") {
+		t.Errorf("issue 1 = %+v, want undefined OP action revoke", issues[1])
+	}
+	for _, d := range issues {
+		if d.Passed || d.Fix == "" {
+			t.Errorf("issue %s should fail with a fix: %+v", d.Check, d)
+		}
+	}
+
+	c.StateMachine.States = append(c.StateMachine.States, "revoked")
+	c.Spec.Actions["revoke"] = ActionSpec{Cases: []ActionCase{{Input: map[string]string{"session": "Session"}}}}
+	if issues := CheckConcept(c); len(issues) != 0 {
+		t.Errorf("fixed concept still has issues: %+v", issues)
+	}
+}
+
+func TestCheckActionCasesIdenticalInputs(t *testing.T) {
+	c := Concept{
+		Name: "Session",
+		Spec: ConceptSpec{
+			Actions: map[string]ActionSpec{
+				"create": {Cases: []ActionCase{
+					{Input: map[string]string{"user": "User"}, Output: map[string]string{"session": "Session"}},
+					{Input: map[string]string{"user": "User"}, Output: map[string]string{"error": "string"}},
+				}},
+				"validate": {Cases: []ActionCase{
+					{Input: map[string]string{"session": "Session"}, Output: map[string]string{"valid": "bool"}},
+					{Input: map[string]string{"session": "Session", "now": "Time"}, Output: map[string]string{"error": "string"}},
+				}},
+			},
+		},
+	}
+
+	warnings := CheckActionCases(c)
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1: %+v", len(warnings), warnings)
+	}
+	w := warnings[0]
+	if w.Check != "action_case_ambiguous" ||
+		w.Got != "action create cases 1 and 2 have identical input {user: User} and different outputs" {
+		t.Errorf("warning = %+v", w)
+	}
+	if w.Fix == "" {
+		t.Error("expected a fix hint")
+	}
+}
+
+func TestLintOperationalPrincipleRemovedAction(t *testing.T) {
+	c := Concept{
+		Name: "SearchSource",
+		Spec: ConceptSpec{
+			State: map[string]StateComponent{
+				"sources": {Type: "set", Of: "Source"},
+			},
+			Actions: map[string]ActionSpec{
+				"register": {Cases: []ActionCase{{}}},
+				"query":    {Cases: []ActionCase{{}}},
+			},
+			// unregister was renamed to deregister; registerSource was removed.
+			OperationalPrinciple: "After register, a query over sources returns the source; " +
+				"then unregister and query(term) returns nothing. registerSource(s) is the bulk form.",
+		},
+	}
+	c.Spec.Actions["deregister"] = ActionSpec{Cases: []ActionCase{{}}}
+
+	warnings := LintOperationalPrinciple(c)
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1: %+v", len(warnings), warnings)
+	}
+	w := warnings[0]
+	if w.Check != "operational_principle_reference" || !strings.Contains(w.Got, "unregister") {
+		t.Errorf("warning = %+v, want unknown unregister", w)
+	}
+	if !strings.Contains(w.Fix, "deregister") {
+		t.Errorf("fix = %q, want a deregister suggestion", w.Fix)
+	}
+
+	// Call-style references stay with CheckConcept.
+	for _, issue := range CheckConcept(c) {
+		if strings.Contains(issue.Got, "unregister") {
+			t.Errorf("CheckConcept should not report prose mention: %+v", issue)
+		}
+	}
+}
+
+func TestLintOperationalPrincipleIdentifierStyle(t *testing.T) {
+	c := Concept{
+		Name: "Session",
+		Spec: ConceptSpec{
+			Actions:              map[string]ActionSpec{"create": {}},
+			OperationalPrinciple: "Once the session is created, refresh_token extends it.",
+		},
+	}
+	// "session" follows "once" but is the concept's own name.
+	warnings := LintOperationalPrinciple(c)
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Got, "refresh_token") {
+		t.Fatalf("warnings = %+v, want only refresh_token", warnings)
+	}
+}
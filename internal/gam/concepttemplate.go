@@ -0,0 +1,41 @@
+package gam
+
+import "encoding/json"
+
+// Placeholder text in a concept template. Both pass CheckConcept so a fresh
+// template validates, but are meant to be replaced.
+const (
+	TemplatePurpose              = "TODO: one line on the value this concept provides"
+	TemplateOperationalPrinciple = "TODO: after action_a with x, action_b with x returns y"
+)
+
+// conceptTemplate fixes the field order of a template and leaves out the
+// database-assigned id and timestamps that gam.Concept carries.
+type conceptTemplate struct {
+	Name         string       `json:"name"`
+	Purpose      string       `json:"purpose"`
+	Spec         ConceptSpec  `json:"spec"`
+	StateMachine StateMachine `json:"state_machine"`
+	Invariants   []Invariant  `json:"invariants"`
+}
+
+// ConceptTemplate returns an indented concept JSON skeleton for name, ready
+// for gam concept add --spec: every section is present but empty (state,
+// actions, state machine, invariants), and purpose and operational principle
+// hold placeholders. The result decodes into Concept and passes CheckConcept.
+func ConceptTemplate(name string) []byte {
+	t := conceptTemplate{
+		Name:    name,
+		Purpose: TemplatePurpose,
+		Spec: ConceptSpec{
+			TypeParams:           []string{},
+			State:                map[string]StateComponent{},
+			Actions:              map[string]ActionSpec{},
+			OperationalPrinciple: TemplateOperationalPrinciple,
+		},
+		StateMachine: StateMachine{States: []string{}, Transitions: []Transition{}},
+		Invariants:   []Invariant{},
+	}
+	data, _ := json.MarshalIndent(t, "", "  ")
+	return append(data, '\n')
+}
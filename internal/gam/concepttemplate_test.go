@@ -0,0 +1,43 @@
+package gam
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConceptTemplate(t *testing.T) {
+	data := ConceptTemplate("Session")
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("template is not JSON: %v\n%s", err, data)
+	}
+	for _, key := range []string{"name", "purpose", "spec", "state_machine", "invariants"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("template missing %q", key)
+		}
+	}
+	if _, ok := raw["id"]; ok {
+		t.Error("template includes database id")
+	}
+
+	var c Concept
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatalf("unmarshal into Concept: %v", err)
+	}
+	if c.Name != "Session" || c.Purpose != TemplatePurpose {
+		t.Errorf("name=%q purpose=%q", c.Name, c.Purpose)
+	}
+	if c.Spec.State == nil || len(c.Spec.State) != 0 || c.Spec.Actions == nil || len(c.Spec.Actions) != 0 {
+		t.Errorf("spec sections not empty objects: %+v", c.Spec)
+	}
+	if c.StateMachine.States == nil || len(c.StateMachine.States) != 0 || len(c.StateMachine.Transitions) != 0 {
+		t.Errorf("state machine not empty: %+v", c.StateMachine)
+	}
+	if c.Invariants == nil || len(c.Invariants) != 0 {
+		t.Errorf("invariants = %v, want empty list", c.Invariants)
+	}
+	if issues := CheckConcept(c); len(issues) != 0 {
+		t.Errorf("template fails CheckConcept: %+v", issues)
+	}
+}
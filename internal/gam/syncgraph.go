@@ -0,0 +1,117 @@
+package gam
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SyncEdge connects a when-clause action to a then-clause action through a sync.
+type SyncEdge struct {
+	From    string // "Concept/action" from the when clause
+	To      string // "Concept/action" from the then clause
+	Sync    string
+	Enabled bool
+}
+
+// SyncGraph is the synchronization network: concept/action nodes joined by syncs.
+type SyncGraph struct {
+	Nodes []string
+	Edges []SyncEdge
+}
+
+// BuildSyncGraph creates one edge per (when, then) action pair of every sync.
+func BuildSyncGraph(syncs []Synchronization) *SyncGraph {
+	g := &SyncGraph{}
+	nodes := make(map[string]bool)
+	for _, s := range syncs {
+		for _, w := range s.WhenClause {
+			from := w.Concept + "/" + w.Action
+			nodes[from] = true
+			for _, t := range s.ThenClause {
+				to := t.Concept + "/" + t.Action
+				nodes[to] = true
+				g.Edges = append(g.Edges, SyncEdge{From: from, To: to, Sync: s.Name, Enabled: s.Enabled})
+			}
+		}
+	}
+	for n := range nodes {
+		g.Nodes = append(g.Nodes, n)
+	}
+	sort.Strings(g.Nodes)
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].Sync != g.Edges[j].Sync {
+			return g.Edges[i].Sync < g.Edges[j].Sync
+		}
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g
+}
+
+// Neighborhood returns the subgraph of edges with an endpoint in the given concept.
+func (g *SyncGraph) Neighborhood(concept string) *SyncGraph {
+	sub := &SyncGraph{}
+	nodes := make(map[string]bool)
+	for _, e := range g.Edges {
+		if nodeConcept(e.From) == concept || nodeConcept(e.To) == concept {
+			sub.Edges = append(sub.Edges, e)
+			nodes[e.From] = true
+			nodes[e.To] = true
+		}
+	}
+	for _, n := range g.Nodes {
+		if nodes[n] {
+			sub.Nodes = append(sub.Nodes, n)
+		}
+	}
+	return sub
+}
+
+// DOT renders the graph in Graphviz DOT format. Disabled syncs are dashed.
+func (g *SyncGraph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph syncs {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		sb.WriteString(fmt.Sprintf("  %q;\n", n))
+	}
+	for _, e := range g.Edges {
+		attrs := fmt.Sprintf("label=%q", e.Sync)
+		if !e.Enabled {
+			attrs += ", style=dashed"
+		}
+		sb.WriteString(fmt.Sprintf("  %q -> %q [%s];\n", e.From, e.To, attrs))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Disabled syncs are dotted.
+func (g *SyncGraph) Mermaid() string {
+	ids := make(map[string]string, len(g.Nodes))
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for i, n := range g.Nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[n], n))
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if !e.Enabled {
+			arrow = "-.->"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s|%s| %s\n", ids[e.From], arrow, e.Sync, ids[e.To]))
+	}
+	return sb.String()
+}
+
+func nodeConcept(node string) string {
+	if i := strings.Index(node, "/"); i >= 0 {
+		return node[:i]
+	}
+	return node
+}
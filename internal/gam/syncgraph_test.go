@@ -0,0 +1,50 @@
+package gam
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildSyncGraph(t *testing.T) {
+	syncs := []Synchronization{
+		{
+			Name:       "FanOutSearch",
+			Enabled:    true,
+			WhenClause: []WhenPattern{{Concept: "Web", Action: "request"}},
+			ThenClause: []ThenAction{
+				{Concept: "SearchSource", Action: "query"},
+				{Concept: "Audit", Action: "log"},
+			},
+		},
+		{
+			Name:    "NotifyOnResult",
+			Enabled: false,
+			WhenClause: []WhenPattern{
+				{Concept: "SearchSource", Action: "query"},
+				{Concept: "SearchSource", Action: "fail"},
+			},
+			ThenClause: []ThenAction{{Concept: "Notification", Action: "send"}},
+		},
+	}
+
+	g := BuildSyncGraph(syncs)
+	if len(g.Edges) != 4 {
+		t.Errorf("edge count: want 4, got %d", len(g.Edges))
+	}
+	if len(g.Nodes) != 5 {
+		t.Errorf("node count: want 5, got %d", len(g.Nodes))
+	}
+
+	sub := g.Neighborhood("Notification")
+	if len(sub.Edges) != 2 {
+		t.Errorf("Notification subgraph edge count: want 2, got %d", len(sub.Edges))
+	}
+
+	dot := g.DOT()
+	if !strings.Contains(dot, `"SearchSource/fail" -> "Notification/send" [label="NotifyOnResult", style=dashed];`) {
+		t.Errorf("DOT output missing dashed disabled edge:\n%s", dot)
+	}
+	if strings.Count(g.Mermaid(), "-.->") != 2 {
+		t.Errorf("Mermaid output should have 2 dotted edges:\n%s", g.Mermaid())
+	}
+}
@@ -0,0 +1,103 @@
+package gam
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// fieldRefPattern matches "?var.field" references in where-clause bind
+// expressions and filters, capturing the variable and field names.
+var fieldRefPattern = regexp.MustCompile(`\?([A-Za-z_]\w*)\.([A-Za-z_]\w*)`)
+
+// Refs returns the sync_refs index entries implied by a synchronization's
+// when, where, and then clauses: concept/action pairs for when and then, and
+// concept state fields for where. Duplicate entries are dropped.
+func (s Synchronization) Refs() []SyncRef {
+	var refs []SyncRef
+	seen := make(map[SyncRef]bool)
+	add := func(ref SyncRef) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, w := range s.WhenClause {
+		add(SyncRef{SyncID: s.ID, ConceptName: w.Concept, ActionName: w.Action, ClauseType: "when"})
+	}
+
+	for _, w := range s.WhereClause {
+		for _, field := range w.Fields() {
+			add(SyncRef{SyncID: s.ID, ConceptName: w.Concept, StateField: field, ClauseType: "where"})
+		}
+	}
+
+	for _, t := range s.ThenClause {
+		add(SyncRef{SyncID: s.ID, ConceptName: t.Concept, ActionName: t.Action, ClauseType: "then"})
+	}
+
+	return refs
+}
+
+// Fields returns the concept state fields a where pattern references, sorted.
+// A pattern maps each ?variable to the fields it matches, so the keys
+// directly under a binding are fields; their values are literals or
+// variables and are not searched for further keys. A ?var.field reference
+// in Bind expressions or Filter counts too when ?var is bound by this
+// pattern; variables bound elsewhere belong to other concepts.
+func (w WherePattern) Fields() []string {
+	set := make(map[string]bool)
+	collectPatternFields(w.Pattern, set)
+
+	for _, expr := range w.Bind {
+		w.collectRefFields(expr, set)
+	}
+	w.collectRefFields(w.Filter, set)
+
+	fields := make([]string, 0, len(set))
+	for f := range set {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// collectRefFields records the fields of ?var.field references in expr whose
+// ?var is a binding of w's pattern.
+func (w WherePattern) collectRefFields(expr string, set map[string]bool) {
+	for _, m := range fieldRefPattern.FindAllStringSubmatch(expr, -1) {
+		if _, ok := w.Pattern["?"+m[1]]; ok {
+			set[m[2]] = true
+		}
+	}
+}
+
+// collectPatternFields records the field names matched by each ?variable
+// binding in pattern. A list-valued binding matches each of its element maps.
+func collectPatternFields(pattern map[string]any, set map[string]bool) {
+	for key, binding := range pattern {
+		if !strings.HasPrefix(key, "?") {
+			continue
+		}
+		switch b := binding.(type) {
+		case map[string]any:
+			addBindingFields(b, set)
+		case []any:
+			for _, elem := range b {
+				if fields, ok := elem.(map[string]any); ok {
+					addBindingFields(fields, set)
+				}
+			}
+		}
+	}
+}
+
+// addBindingFields records the non-variable keys of one binding map.
+func addBindingFields(fields map[string]any, set map[string]bool) {
+	for field := range fields {
+		if !strings.HasPrefix(field, "?") {
+			set[field] = true
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package gam
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWherePatternFieldsNested(t *testing.T) {
+	var w WherePattern
+	data := `{
+		"concept": "SearchSource",
+		"pattern": {
+			"?s": {
+				"enabled": true,
+				"config": {"timeout": "?t"},
+				"tags": [{"label": "?l"}, {"?tag": {"weight": 1}}]
+			}
+		},
+		"bind": {"?display": "concat(?s.name, ?s.endpoint)"},
+		"filter": "?s.rate_limit > 0 AND ?t < 30"
+	}`
+	if err := json.Unmarshal([]byte(data), &w); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	// Keys inside field values (timeout, label, weight) are literal data,
+	// not fields of SearchSource.
+	want := []string{"config", "enabled", "endpoint", "name", "rate_limit", "tags"}
+	if got := w.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+}
+
+func TestWherePatternFieldsIgnoresNestedLiteralMap(t *testing.T) {
+	w := WherePattern{
+		Concept: "SearchSource",
+		Pattern: map[string]any{"?s": map[string]any{"config": map[string]any{"timeout": 5}}},
+	}
+	if got := w.Fields(); !reflect.DeepEqual(got, []string{"config"}) {
+		t.Errorf("Fields() = %v, want only the bound field config", got)
+	}
+}
+
+func TestSynchronizationRefs(t *testing.T) {
+	sync := Synchronization{
+		Name:       "FanOutSearch",
+		WhenClause: []WhenPattern{{Concept: "Web", Action: "request"}},
+		WhereClause: []WherePattern{
+			{Concept: "SearchSource", Pattern: map[string]any{"?s": map[string]any{"enabled": true}}},
+			{Concept: "SearchSource", Pattern: map[string]any{"?s": []any{map[string]any{"enabled": false}}}},
+		},
+		ThenClause: []ThenAction{{Concept: "SearchSource", Action: "query"}},
+	}
+
+	want := []SyncRef{
+		{ConceptName: "Web", ActionName: "request", ClauseType: "when"},
+		{ConceptName: "SearchSource", StateField: "enabled", ClauseType: "where"},
+		{ConceptName: "SearchSource", ActionName: "query", ClauseType: "then"},
+	}
+	if got := sync.Refs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Refs() = %v, want %v", got, want)
+	}
+}
+
+func TestSynchronizationRefsListBinding(t *testing.T) {
+	sync := Synchronization{
+		Name: "ListBinding",
+		WhereClause: []WherePattern{
+			{Concept: "SearchSource", Pattern: map[string]any{"?s": []any{
+				map[string]any{"enabled": false},
+				map[string]any{"region": "?r"},
+			}}},
+		},
+	}
+
+	if got := sync.WhereClause[0].Fields(); !reflect.DeepEqual(got, []string{"enabled", "region"}) {
+		t.Errorf("Fields() = %v, want [enabled region]", got)
+	}
+	want := []SyncRef{
+		{ConceptName: "SearchSource", StateField: "enabled", ClauseType: "where"},
+		{ConceptName: "SearchSource", StateField: "region", ClauseType: "where"},
+	}
+	if got := sync.Refs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Refs() = %v, want %v", got, want)
+	}
+}
+
+func TestWherePatternFieldsSkipsForeignVariables(t *testing.T) {
+	w := WherePattern{
+		Concept: "User",
+		Pattern: map[string]any{"?u": map[string]any{"active": true}},
+		Bind:    map[string]string{"?label": "concat(?u.name, ?req.path)"},
+		Filter:  "?req.user == ?u.id",
+	}
+	want := []string{"active", "id", "name"}
+	if got := w.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %v, want %v without fields of ?req", got, want)
+	}
+}
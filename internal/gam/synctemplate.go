@@ -0,0 +1,42 @@
+package gam
+
+import "encoding/json"
+
+// syncTemplate fixes the field order of a template and leaves out the
+// database-assigned id, enabled flag and timestamps.
+type syncTemplate struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	WhenClause  []WhenPattern  `json:"when_clause"`
+	WhereClause []WherePattern `json:"where_clause"`
+	ThenClause  []ThenAction   `json:"then_clause"`
+}
+
+// SyncTemplate returns an indented synchronization JSON skeleton for name,
+// ready for gam sync add --spec. Each clause holds one example pattern with
+// placeholder concepts and actions; the ?variables show how a binding flows
+// from the when and where clauses into the then clause's arguments. The
+// result decodes into Synchronization.
+func SyncTemplate(name string) []byte {
+	t := syncTemplate{
+		Name:        name,
+		Description: "TODO: when TriggerConcept/completed_action finishes, invoke TargetConcept/action",
+		WhenClause: []WhenPattern{{
+			Concept:     "TriggerConcept",
+			Action:      "completed_action",
+			InputMatch:  map[string]string{"input_field": "?input"},
+			OutputMatch: map[string]string{"output_field": "?output"},
+		}},
+		WhereClause: []WherePattern{{
+			Concept: "StateConcept",
+			Pattern: map[string]any{"?item": map[string]any{"state_field": "?value"}},
+		}},
+		ThenClause: []ThenAction{{
+			Concept: "TargetConcept",
+			Action:  "action",
+			Args:    map[string]string{"item": "?item", "input": "?input", "value": "?value"},
+		}},
+	}
+	data, _ := json.MarshalIndent(t, "", "  ")
+	return append(data, '\n')
+}
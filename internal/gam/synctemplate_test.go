@@ -0,0 +1,46 @@
+package gam
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSyncTemplate(t *testing.T) {
+	data := SyncTemplate("NotifyOnResult")
+
+	var sc Synchronization
+	if err := json.Unmarshal(data, &sc); err != nil {
+		t.Fatalf("unmarshal into Synchronization: %v\n%s", err, data)
+	}
+	if sc.Name != "NotifyOnResult" || sc.Description == "" {
+		t.Errorf("name=%q description=%q", sc.Name, sc.Description)
+	}
+	if len(sc.WhenClause) != 1 || len(sc.WhereClause) != 1 || len(sc.ThenClause) != 1 {
+		t.Fatalf("clauses when=%d where=%d then=%d, want one example each",
+			len(sc.WhenClause), len(sc.WhereClause), len(sc.ThenClause))
+	}
+
+	// Every variable passed to the then action is bound by an earlier clause.
+	bound := map[string]bool{}
+	for _, v := range sc.WhenClause[0].InputMatch {
+		bound[v] = true
+	}
+	for _, v := range sc.WhenClause[0].OutputMatch {
+		bound[v] = true
+	}
+	for k, v := range sc.WhereClause[0].Pattern {
+		bound[k] = true
+		for _, inner := range v.(map[string]any) {
+			bound[inner.(string)] = true
+		}
+	}
+	for arg, v := range sc.ThenClause[0].Args {
+		if !bound[v] {
+			t.Errorf("then arg %s uses unbound variable %s", arg, v)
+		}
+	}
+
+	if refs := sc.Refs(); len(refs) == 0 {
+		t.Error("template has no concept references")
+	}
+}
@@ -106,6 +106,7 @@ type Proposal struct {
 	TurnID           string           `json:"turn_id" db:"turn_id"`
 	RegionID         string           `json:"region_id" db:"region_id"`
 	RegionPath       string           `json:"region_path"`
+	ScopePath        string           `json:"scope_path,omitempty"` // overrides the turn's scope in Tier 0
 	ActionTaken      string           `json:"action_taken" db:"action_taken"`
 	CurrentState     string           `json:"current_state"`
 	ProposedState    string           `json:"proposed_state" db:"proposed_state"`
@@ -283,6 +284,7 @@ type GoldenPrinciple struct {
 	Rule        string `json:"rule" db:"rule"`
 	LintCheck   string `json:"lint_check" db:"lint_check"`
 	Remediation string `json:"remediation" db:"remediation"`
+	Severity    string `json:"severity" db:"severity"` // warn, block
 	Enabled     bool   `json:"enabled" db:"enabled"`
 }
 
@@ -302,6 +304,7 @@ type Region struct {
 	Path           string    `json:"path" db:"path"`
 	Description    string    `json:"description" db:"description"`
 	LifecycleState string   `json:"lifecycle_state" db:"lifecycle_state"`
+	Owner          string    `json:"owner,omitempty" db:"owner"`
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 }
 
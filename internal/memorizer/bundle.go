@@ -0,0 +1,469 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+// BundleVersion is the schema version written by ExportBundle. Bump it when
+// the bundle layout changes and teach ReadBundle to upgrade older bundles.
+// Version 2 added plan turns; version 1 bundles import with none.
+const BundleVersion = 2
+
+// Bundle is a portable snapshot of the design state stored in the database.
+// Rows are keyed by name or path rather than database ids so a bundle can be
+// imported into a different environment. Plans keep their ids because plan
+// names are not unique. Turns are runtime history, so only those a plan turn
+// references are exported, without scratchpads or tree snapshots.
+type Bundle struct {
+	Version       int                   `json:"version"`
+	ExportedAt    time.Time             `json:"exported_at"`
+	Concepts      []gam.Concept         `json:"concepts"`
+	Syncs         []gam.Synchronization `json:"syncs"`
+	Regions       []gam.Region          `json:"regions"`
+	Assignments   []BundleAssignment    `json:"assignments"`
+	Plans         []gam.ExecutionPlan   `json:"plans"`
+	Turns         []BundleTurn          `json:"turns"`
+	PlanTurns     []gam.PlanTurn        `json:"plan_turns"`
+	QualityGrades []BundleGrade         `json:"quality_grades"`
+	Principles    []gam.GoldenPrinciple `json:"golden_principles"`
+}
+
+// BundleAssignment is a concept-region assignment keyed by name and path.
+type BundleAssignment struct {
+	Concept string `json:"concept"`
+	Region  string `json:"region"`
+	Role    string `json:"role"`
+}
+
+// BundleTurn is a turn referenced by a plan turn.
+type BundleTurn struct {
+	ID          string     `json:"id"`
+	AgentRole   string     `json:"agent_role,omitempty"`
+	ScopePath   string     `json:"scope_path,omitempty"`
+	PlanID      string     `json:"plan_id,omitempty"`
+	TaskType    string     `json:"task_type,omitempty"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// BundleGrade is a quality grade keyed by region path.
+type BundleGrade struct {
+	Region     string          `json:"region"`
+	Category   string          `json:"category"`
+	Grade      string          `json:"grade"`
+	Details    json.RawMessage `json:"details,omitempty"`
+	AssessedBy string          `json:"assessed_by,omitempty"`
+}
+
+// ReadBundle decodes a bundle and checks that its version is supported.
+func ReadBundle(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse bundle: %w", err)
+	}
+	switch {
+	case b.Version == 0:
+		return nil, fmt.Errorf("not a gam bundle: missing version")
+	case b.Version > BundleVersion:
+		return nil, fmt.Errorf("bundle version %d is newer than supported version %d; upgrade gam", b.Version, BundleVersion)
+	}
+	return &b, nil
+}
+
+// ExportBundle reads concepts, syncs, regions, assignments, plans with their
+// plan turns, quality grades, and golden principles into a Bundle.
+func ExportBundle(ctx context.Context, pool *pgxpool.Pool) (*Bundle, error) {
+	b := &Bundle{Version: BundleVersion, ExportedAt: time.Now().UTC()}
+
+	rows, err := pool.Query(ctx, `
+		SELECT name, purpose, spec, state_machine, invariants FROM concepts ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export concepts: %w", err)
+	}
+	for rows.Next() {
+		var c gam.Concept
+		var specJSON, smJSON, invJSON []byte
+		if err := rows.Scan(&c.Name, &c.Purpose, &specJSON, &smJSON, &invJSON); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export concepts: %w", err)
+		}
+		if err := decodeColumns(
+			jsonColumn{"spec", specJSON, &c.Spec},
+			jsonColumn{"state_machine", smJSON, &c.StateMachine},
+			jsonColumn{"invariants", invJSON, &c.Invariants},
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export concept %s: %w", c.Name, err)
+		}
+		b.Concepts = append(b.Concepts, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export concepts: %w", err)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT name, when_clause, where_clause, then_clause, COALESCE(description, ''), enabled
+		FROM synchronizations ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export syncs: %w", err)
+	}
+	for rows.Next() {
+		var s gam.Synchronization
+		var whenJSON, whereJSON, thenJSON []byte
+		if err := rows.Scan(&s.Name, &whenJSON, &whereJSON, &thenJSON, &s.Description, &s.Enabled); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export syncs: %w", err)
+		}
+		if err := decodeColumns(
+			jsonColumn{"when_clause", whenJSON, &s.WhenClause},
+			jsonColumn{"where_clause", whereJSON, &s.WhereClause},
+			jsonColumn{"then_clause", thenJSON, &s.ThenClause},
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export sync %s: %w", s.Name, err)
+		}
+		b.Syncs = append(b.Syncs, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export syncs: %w", err)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT path::text, COALESCE(description, ''), COALESCE(lifecycle_state, 'draft'), COALESCE(owner, '')
+		FROM regions ORDER BY path
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export regions: %w", err)
+	}
+	for rows.Next() {
+		var r gam.Region
+		if err := rows.Scan(&r.Path, &r.Description, &r.LifecycleState, &r.Owner); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export regions: %w", err)
+		}
+		b.Regions = append(b.Regions, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export regions: %w", err)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT c.name, r.path::text, cra.role
+		FROM concept_region_assignments cra
+		JOIN concepts c ON c.id = cra.concept_id
+		JOIN regions r ON r.id = cra.region_id
+		ORDER BY c.name, r.path
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export assignments: %w", err)
+	}
+	for rows.Next() {
+		var a BundleAssignment
+		if err := rows.Scan(&a.Concept, &a.Region, &a.Role); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export assignments: %w", err)
+		}
+		b.Assignments = append(b.Assignments, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export assignments: %w", err)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT id::text, name, goal, status::text, COALESCE(decisions, '[]'),
+		       COALESCE(quality_grade, ''), created_at, completed_at
+		FROM execution_plans ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export plans: %w", err)
+	}
+	for rows.Next() {
+		var p gam.ExecutionPlan
+		var decJSON []byte
+		if err := rows.Scan(&p.ID, &p.Name, &p.Goal, &p.Status, &decJSON, &p.QualityGrade, &p.CreatedAt, &p.CompletedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export plans: %w", err)
+		}
+		if err := decodeColumns(jsonColumn{"decisions", decJSON, &p.Decisions}); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export plan %s: %w", p.Name, err)
+		}
+		b.Plans = append(b.Plans, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export plans: %w", err)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT t.id, COALESCE(t.agent_role, ''), COALESCE(t.scope_path::text, ''), COALESCE(t.plan_id::text, ''),
+		       COALESCE(t.task_type, ''), t.status::text, t.created_at, t.completed_at
+		FROM turns t
+		WHERE EXISTS (SELECT 1 FROM plan_turns pt WHERE pt.turn_id = t.id)
+		ORDER BY t.created_at, t.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export turns: %w", err)
+	}
+	for rows.Next() {
+		var t BundleTurn
+		if err := rows.Scan(&t.ID, &t.AgentRole, &t.ScopePath, &t.PlanID, &t.TaskType, &t.Status, &t.CreatedAt, &t.CompletedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export turns: %w", err)
+		}
+		b.Turns = append(b.Turns, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export turns: %w", err)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT plan_id::text, turn_id, region_path::text, ordering, COALESCE(depends_on, '{}'), COALESCE(status, 'pending')
+		FROM plan_turns ORDER BY plan_id, ordering, turn_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export plan turns: %w", err)
+	}
+	for rows.Next() {
+		var pt gam.PlanTurn
+		if err := rows.Scan(&pt.PlanID, &pt.TurnID, &pt.RegionPath, &pt.Ordering, &pt.DependsOn, &pt.Status); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export plan turns: %w", err)
+		}
+		b.PlanTurns = append(b.PlanTurns, pt)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export plan turns: %w", err)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT r.path::text, qg.category, qg.grade, qg.details, COALESCE(qg.assessed_by, '')
+		FROM quality_grades qg
+		JOIN regions r ON r.id = qg.region_id
+		ORDER BY r.path, qg.category
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export quality grades: %w", err)
+	}
+	for rows.Next() {
+		var g BundleGrade
+		var details []byte
+		if err := rows.Scan(&g.Region, &g.Category, &g.Grade, &details, &g.AssessedBy); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export quality grades: %w", err)
+		}
+		if details != nil {
+			g.Details = details
+		}
+		b.QualityGrades = append(b.QualityGrades, g)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export quality grades: %w", err)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT name, rule, COALESCE(lint_check, ''), remediation, severity, enabled
+		FROM golden_principles ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("export golden principles: %w", err)
+	}
+	for rows.Next() {
+		var p gam.GoldenPrinciple
+		if err := rows.Scan(&p.Name, &p.Rule, &p.LintCheck, &p.Remediation, &p.Severity, &p.Enabled); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("export golden principles: %w", err)
+		}
+		b.Principles = append(b.Principles, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("export golden principles: %w", err)
+	}
+
+	return b, nil
+}
+
+// jsonColumn is a JSONB column value and the target it decodes into.
+type jsonColumn struct {
+	name string
+	data []byte
+	v    any
+}
+
+// decodeColumns decodes each column into its target, failing on the first
+// column that is not valid JSON. NULL columns leave their target unset.
+func decodeColumns(cols ...jsonColumn) error {
+	for _, c := range cols {
+		if c.data == nil {
+			continue
+		}
+		if err := json.Unmarshal(c.data, c.v); err != nil {
+			return fmt.Errorf("decode %s: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
+// ImportBundle upserts every row in b in a single transaction and rebuilds
+// sync_refs for the imported syncs. Importing the same bundle twice yields the
+// same design state, but each import bumps updated_at on regions and syncs
+// and records another import entry in each sync's audit log. Assignments and
+// quality grades must name concepts and regions present in the bundle or the
+// database; one that does not fails the import. Bundle turns are created when
+// missing and left untouched when a turn with that id already exists, since
+// its scratchpad and tree snapshots are not in the bundle. Plan turns must
+// name a plan and turn present in the bundle or the database.
+func ImportBundle(ctx context.Context, pool *pgxpool.Pool, b *Bundle) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, r := range b.Regions {
+		lifecycle := r.LifecycleState
+		if lifecycle == "" {
+			lifecycle = "draft"
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO regions (path, description, lifecycle_state, owner) VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''))
+			ON CONFLICT (path) DO UPDATE
+			SET description = NULLIF($2, ''), lifecycle_state = $3, owner = NULLIF($4, ''), updated_at = NOW()
+		`, r.Path, r.Description, lifecycle, r.Owner); err != nil {
+			return fmt.Errorf("import region %s: %w", r.Path, err)
+		}
+	}
+
+	for _, c := range b.Concepts {
+		if err := UpsertConcept(ctx, tx, c); err != nil {
+			return fmt.Errorf("import concept %s: %w", c.Name, err)
+		}
+	}
+
+	for _, a := range b.Assignments {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO concept_region_assignments (concept_id, region_id, role)
+			SELECT c.id, r.id, $3 FROM concepts c, regions r WHERE c.name = $1 AND r.path = $2
+			ON CONFLICT (concept_id, region_id) DO UPDATE SET role = $3
+		`, a.Concept, a.Region, a.Role)
+		if err != nil {
+			return fmt.Errorf("import assignment %s -> %s: %w", a.Concept, a.Region, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("import assignment %s -> %s: concept or region not in the bundle or database", a.Concept, a.Region)
+		}
+	}
+
+	for _, s := range b.Syncs {
+		if err := UpsertSync(ctx, tx, s); err != nil {
+			return fmt.Errorf("import sync %s: %w", s.Name, err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE synchronizations SET enabled = $2 WHERE name = $1`, s.Name, s.Enabled); err != nil {
+			return fmt.Errorf("import sync %s: %w", s.Name, err)
+		}
+		if err := RecordSyncAudit(ctx, tx, s.Name, SyncAuditImport, "bundle import", fmt.Sprintf("enabled=%t", s.Enabled)); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range b.Plans {
+		decJSON, _ := json.Marshal(p.Decisions)
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO execution_plans (id, name, goal, status, decisions, quality_grade, created_at, completed_at)
+			VALUES ($1, $2, $3, $4::plan_status, $5, NULLIF($6, ''), $7, $8)
+			ON CONFLICT (id) DO UPDATE
+			SET name = $2, goal = $3, status = $4::plan_status, decisions = $5,
+			    quality_grade = NULLIF($6, ''), created_at = $7, completed_at = $8
+		`, p.ID, p.Name, p.Goal, p.Status, decJSON, p.QualityGrade, p.CreatedAt, p.CompletedAt); err != nil {
+			return fmt.Errorf("import plan %s: %w", p.Name, err)
+		}
+	}
+
+	for _, t := range b.Turns {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO turns (id, agent_role, scope_path, plan_id, task_type, status, created_at, completed_at)
+			VALUES ($1, NULLIF($2, ''), NULLIF($3, '')::ltree, NULLIF($4, '')::uuid, COALESCE(NULLIF($5, ''), 'implement'),
+			        $6::turn_status, $7, $8)
+			ON CONFLICT (id) DO NOTHING
+		`, t.ID, t.AgentRole, t.ScopePath, t.PlanID, t.TaskType, t.Status, t.CreatedAt, t.CompletedAt); err != nil {
+			return fmt.Errorf("import turn %s: %w", t.ID, err)
+		}
+	}
+
+	for _, pt := range b.PlanTurns {
+		status := pt.Status
+		if status == "" {
+			status = "pending"
+		}
+		dependsOn := pt.DependsOn
+		if dependsOn == nil {
+			dependsOn = []string{}
+		}
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO plan_turns (plan_id, turn_id, region_path, ordering, depends_on, status)
+			SELECT p.id, t.id, $3::ltree, $4, $5, $6
+			FROM execution_plans p, turns t WHERE p.id = $1::uuid AND t.id = $2
+			ON CONFLICT (plan_id, turn_id) DO UPDATE
+			SET region_path = $3::ltree, ordering = $4, depends_on = $5, status = $6
+		`, pt.PlanID, pt.TurnID, pt.RegionPath, pt.Ordering, dependsOn, status)
+		if err != nil {
+			return fmt.Errorf("import plan turn %s/%s: %w", pt.PlanID, pt.TurnID, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("import plan turn %s/%s: plan or turn not in the bundle or database", pt.PlanID, pt.TurnID)
+		}
+	}
+
+	for _, g := range b.QualityGrades {
+		var details any
+		if len(g.Details) > 0 {
+			details = []byte(g.Details)
+		}
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO quality_grades (region_id, category, grade, details, assessed_by)
+			SELECT r.id, $2, $3, $4, NULLIF($5, '') FROM regions r WHERE r.path = $1
+			ON CONFLICT (region_id, category) DO UPDATE
+			SET grade = $3, details = $4, assessed_by = NULLIF($5, '')
+		`, g.Region, g.Category, g.Grade, details, g.AssessedBy)
+		if err != nil {
+			return fmt.Errorf("import quality grade %s/%s: %w", g.Region, g.Category, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("import quality grade %s/%s: region not in the bundle or database", g.Region, g.Category)
+		}
+	}
+
+	for _, p := range b.Principles {
+		severity := p.Severity
+		if severity == "" {
+			severity = SeverityWarn
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO golden_principles (name, rule, lint_check, remediation, severity, enabled)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)
+			ON CONFLICT (name) DO UPDATE
+			SET rule = $2, lint_check = NULLIF($3, ''), remediation = $4, severity = $5, enabled = $6
+		`, p.Name, p.Rule, p.LintCheck, p.Remediation, severity, p.Enabled); err != nil {
+			return fmt.Errorf("import golden principle %s: %w", p.Name, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,163 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestReadBundleVersion(t *testing.T) {
+	if _, err := ReadBundle([]byte(`{"concepts": []}`)); err == nil || !strings.Contains(err.Error(), "missing version") {
+		t.Errorf("unversioned bundle: err = %v", err)
+	}
+	if _, err := ReadBundle([]byte(`{"version": 99}`)); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("future bundle: err = %v", err)
+	}
+	b, err := ReadBundle([]byte(`{"version": 1, "regions": [{"path": "app"}]}`))
+	if err != nil {
+		t.Fatalf("ReadBundle: %v", err)
+	}
+	if len(b.Regions) != 1 || b.Regions[0].Path != "app" {
+		t.Errorf("regions = %+v", b.Regions)
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	wipe := func() {
+		pool.Exec(ctx, `
+			TRUNCATE concepts, regions, concept_region_assignments, synchronizations, sync_refs,
+			         execution_plans, plan_turns, quality_grades, golden_principles,
+			         turns, turn_regions, proposals CASCADE
+		`)
+	}
+	wipe()
+	defer wipe()
+
+	seed := &Bundle{
+		Version: BundleVersion,
+		Concepts: []gam.Concept{
+			{Name: "SearchSource", Purpose: "register index providers", Invariants: []gam.Invariant{}},
+			{Name: "Web", Purpose: "handle requests", Invariants: []gam.Invariant{}},
+		},
+		Syncs: []gam.Synchronization{{
+			Name:       "FanOut",
+			WhenClause: []gam.WhenPattern{{Concept: "Web", Action: "request"}},
+			ThenClause: []gam.ThenAction{{Concept: "SearchSource", Action: "query"}},
+			Enabled:    false,
+		}},
+		Regions:     []gam.Region{{Path: "app", LifecycleState: "draft"}, {Path: "app.search", Description: "Search", LifecycleState: "active"}},
+		Assignments: []BundleAssignment{{Concept: "SearchSource", Region: "app.search", Role: "implementation"}},
+		Plans: []gam.ExecutionPlan{{
+			ID: "11111111-1111-1111-1111-111111111111", Name: "search", Goal: "add search", Status: "ACTIVE",
+			Decisions: []gam.Decision{{Description: "use btree", Rationale: "simple"}},
+		}},
+		Turns: []BundleTurn{
+			{ID: "bundle-turn-1", AgentRole: "implementer", ScopePath: "app.search", PlanID: "11111111-1111-1111-1111-111111111111", TaskType: "implement", Status: "COMPLETED", CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+			{ID: "bundle-turn-2", AgentRole: "implementer", ScopePath: "app.search", PlanID: "11111111-1111-1111-1111-111111111111", TaskType: "implement", Status: "ACTIVE", CreatedAt: time.Date(2026, 1, 3, 3, 4, 5, 0, time.UTC)},
+		},
+		PlanTurns: []gam.PlanTurn{
+			{PlanID: "11111111-1111-1111-1111-111111111111", TurnID: "bundle-turn-1", RegionPath: "app.search", Ordering: 0, DependsOn: []string{}, Status: "completed"},
+			{PlanID: "11111111-1111-1111-1111-111111111111", TurnID: "bundle-turn-2", RegionPath: "app.search", Ordering: 1, DependsOn: []string{"bundle-turn-1"}, Status: "pending"},
+		},
+		QualityGrades: []BundleGrade{{Region: "app.search", Category: "tests", Grade: "B", Details: json.RawMessage(`{"coverage": 0.7}`)}},
+		Principles:    []gam.GoldenPrinciple{{Name: "no-println", Rule: "use the logger", Remediation: "replace", Severity: SeverityBlock, Enabled: true}},
+	}
+	if err := ImportBundle(ctx, pool, seed); err != nil {
+		t.Fatalf("seed import: %v", err)
+	}
+
+	exported, err := ExportBundle(ctx, pool)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	data, _ := json.Marshal(exported)
+
+	wipe()
+	restored, err := ReadBundle(data)
+	if err != nil {
+		t.Fatalf("ReadBundle: %v", err)
+	}
+	if err := ImportBundle(ctx, pool, restored); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	// Importing twice leaves the same design state.
+	if err := ImportBundle(ctx, pool, restored); err != nil {
+		t.Fatalf("second import: %v", err)
+	}
+
+	again, err := ExportBundle(ctx, pool)
+	if err != nil {
+		t.Fatalf("re-export: %v", err)
+	}
+	again.ExportedAt = exported.ExportedAt
+	if before, after := normalizeBundle(t, exported), normalizeBundle(t, again); before != after {
+		t.Errorf("round trip mismatch:\nbefore: %s\nafter:  %s", before, after)
+	}
+
+	var refs int
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM sync_refs`).Scan(&refs)
+	if refs != 2 {
+		t.Errorf("sync_refs = %d after import, want 2", refs)
+	}
+	if len(again.PlanTurns) != 2 || !reflect.DeepEqual(again.PlanTurns[1].DependsOn, []string{"bundle-turn-1"}) {
+		t.Errorf("plan turns after round trip = %+v, want both turns with their dependency", again.PlanTurns)
+	}
+}
+
+func TestExportBundleRejectsCorruptColumn(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO concepts (name, purpose, spec, state_machine) VALUES ('CorruptSpecTest', 'test', '"not a spec"', '{}')
+		ON CONFLICT (name) DO UPDATE SET spec = '"not a spec"'
+	`); err != nil {
+		t.Fatalf("insert concept: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM concepts WHERE name = 'CorruptSpecTest'`)
+
+	_, err := ExportBundle(ctx, pool)
+	if err == nil || !strings.Contains(err.Error(), "CorruptSpecTest") {
+		t.Fatalf("ExportBundle = %v, want an error naming the corrupt concept", err)
+	}
+}
+
+func TestImportBundleRejectsDanglingAssignment(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	b := &Bundle{
+		Version:     BundleVersion,
+		Regions:     []gam.Region{{Path: "danglingtest", LifecycleState: "draft"}},
+		Assignments: []BundleAssignment{{Concept: "DanglingTestMissing", Region: "danglingtest", Role: "implementation"}},
+	}
+	err := ImportBundle(ctx, pool, b)
+	if err == nil || !strings.Contains(err.Error(), "DanglingTestMissing") {
+		t.Fatalf("ImportBundle = %v, want an error naming the missing concept", err)
+	}
+	var n int
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM regions WHERE path = 'danglingtest'`).Scan(&n)
+	if n != 0 {
+		t.Error("failed import should roll back the regions it inserted")
+	}
+}
+
+// normalizeBundle compares bundles by their JSON encoding so that timestamps
+// and raw JSON fields compare by value.
+func normalizeBundle(t *testing.T, b *Bundle) string {
+	t.Helper()
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("marshal bundle: %v", err)
+	}
+	return string(data)
+}
@@ -0,0 +1,177 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+// ConceptDiff lists the differences between a stored concept and a candidate
+// spec for it.
+type ConceptDiff struct {
+	Name               string
+	AddedActions       []string
+	RemovedActions     []string
+	AddedState         []string
+	RemovedState       []string
+	AddedTransitions   []string // "from->to via action"
+	RemovedTransitions []string
+	Breaking           []BreakingChange
+}
+
+// BreakingChange is a removed action or state field that syncs still reference.
+type BreakingChange struct {
+	Kind  string // "action" or "state"
+	Name  string
+	Syncs []string
+}
+
+// HasChanges reports whether the candidate differs from the stored concept.
+func (d ConceptDiff) HasChanges() bool {
+	return len(d.AddedActions)+len(d.RemovedActions)+len(d.AddedState)+len(d.RemovedState)+
+		len(d.AddedTransitions)+len(d.RemovedTransitions) > 0
+}
+
+// DiffConcepts compares stored to candidate. actionRefs and fieldRefs map the
+// concept's action and state field names to the syncs referencing them (see
+// ConceptSyncRefs); removing a referenced name is a breaking change.
+func DiffConcepts(stored, candidate gam.Concept, actionRefs, fieldRefs map[string][]string) ConceptDiff {
+	d := ConceptDiff{Name: stored.Name}
+	d.AddedActions, d.RemovedActions = diffKeys(stored.Spec.Actions, candidate.Spec.Actions)
+	d.AddedState, d.RemovedState = diffKeys(stored.Spec.State, candidate.Spec.State)
+	d.AddedTransitions, d.RemovedTransitions = diffKeys(transitionSet(stored.StateMachine), transitionSet(candidate.StateMachine))
+
+	for _, a := range d.RemovedActions {
+		if syncs := actionRefs[a]; len(syncs) > 0 {
+			d.Breaking = append(d.Breaking, BreakingChange{Kind: "action", Name: a, Syncs: syncs})
+		}
+	}
+	for _, f := range d.RemovedState {
+		if syncs := fieldRefs[f]; len(syncs) > 0 {
+			d.Breaking = append(d.Breaking, BreakingChange{Kind: "state", Name: f, Syncs: syncs})
+		}
+	}
+	return d
+}
+
+// diffKeys returns the sorted keys only in b (added) and only in a (removed).
+func diffKeys[V any](a, b map[string]V) (added, removed []string) {
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func transitionSet(sm gam.StateMachine) map[string]bool {
+	set := make(map[string]bool, len(sm.Transitions))
+	for _, t := range sm.Transitions {
+		set[fmt.Sprintf("%s->%s via %s", t.From, t.To, t.Action)] = true
+	}
+	return set
+}
+
+// LoadConcept reads the stored definition of the named concept.
+func LoadConcept(ctx context.Context, pool *pgxpool.Pool, name string) (gam.Concept, error) {
+	c := gam.Concept{Name: name}
+	var specJSON, smJSON, invJSON []byte
+	err := pool.QueryRow(ctx, `
+		SELECT purpose, spec, state_machine, invariants FROM concepts WHERE name = $1
+	`, name).Scan(&c.Purpose, &specJSON, &smJSON, &invJSON)
+	if err != nil {
+		return c, fmt.Errorf("concept %s not found", name)
+	}
+	json.Unmarshal(specJSON, &c.Spec)
+	json.Unmarshal(smJSON, &c.StateMachine)
+	json.Unmarshal(invJSON, &c.Invariants)
+	return c, nil
+}
+
+// ConceptVersion is a superseded definition of a concept.
+type ConceptVersion struct {
+	Version      int
+	Concept      gam.Concept
+	ValidFrom    *time.Time // when this definition was stored
+	SupersededAt time.Time  // when an update replaced it
+}
+
+// ConceptVersions lists the superseded definitions of the named concept,
+// oldest first.
+func ConceptVersions(ctx context.Context, pool *pgxpool.Pool, name string) ([]ConceptVersion, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT version, purpose, spec, state_machine, invariants, valid_from, superseded_at
+		FROM concept_versions WHERE concept_name = $1 ORDER BY version
+	`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []ConceptVersion
+	for rows.Next() {
+		v := ConceptVersion{Concept: gam.Concept{Name: name}}
+		var specJSON, smJSON, invJSON []byte
+		if err := rows.Scan(&v.Version, &v.Concept.Purpose, &specJSON, &smJSON, &invJSON, &v.ValidFrom, &v.SupersededAt); err != nil {
+			return nil, err
+		}
+		json.Unmarshal(specJSON, &v.Concept.Spec)
+		json.Unmarshal(smJSON, &v.Concept.StateMachine)
+		json.Unmarshal(invJSON, &v.Concept.Invariants)
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// ConceptSyncRefs returns, for the named concept, the syncs referencing each
+// of its actions and state fields according to sync_refs.
+func ConceptSyncRefs(ctx context.Context, pool *pgxpool.Pool, concept string) (actions, fields map[string][]string, err error) {
+	rows, err := pool.Query(ctx, `
+		SELECT DISTINCT COALESCE(sr.action_name, ''), COALESCE(sr.state_field, ''), s.name
+		FROM sync_refs sr
+		JOIN synchronizations s ON s.id = sr.sync_id
+		WHERE sr.concept_name = $1
+		ORDER BY s.name
+	`, concept)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	actions = make(map[string][]string)
+	fields = make(map[string][]string)
+	for rows.Next() {
+		var action, field, sync string
+		if err := rows.Scan(&action, &field, &sync); err != nil {
+			return nil, nil, err
+		}
+		if action != "" && !containsString(actions[action], sync) {
+			actions[action] = append(actions[action], sync)
+		}
+		if field != "" && !containsString(fields[field], sync) {
+			fields[field] = append(fields[field], sync)
+		}
+	}
+	return actions, fields, rows.Err()
+}
+
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
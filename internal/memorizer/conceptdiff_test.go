@@ -0,0 +1,113 @@
+package memorizer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestDiffConceptsRemovedReferencedAction(t *testing.T) {
+	stored := gam.Concept{
+		Name: "SearchSource",
+		Spec: gam.ConceptSpec{
+			State: map[string]gam.StateComponent{"enabled": {Type: "set"}},
+			Actions: map[string]gam.ActionSpec{
+				"register": {},
+				"query":    {},
+			},
+		},
+		StateMachine: gam.StateMachine{Transitions: []gam.Transition{
+			{From: "idle", To: "querying", Action: "query"},
+		}},
+	}
+	candidate := gam.Concept{
+		Name: "SearchSource",
+		Spec: gam.ConceptSpec{
+			State: map[string]gam.StateComponent{"enabled": {Type: "set"}, "weight": {Type: "map"}},
+			Actions: map[string]gam.ActionSpec{
+				"register": {},
+			},
+		},
+	}
+	actionRefs := map[string][]string{"query": {"fan_out"}, "register": {"bootstrap"}}
+	fieldRefs := map[string][]string{"enabled": {"fan_out"}}
+
+	d := DiffConcepts(stored, candidate, actionRefs, fieldRefs)
+
+	if !reflect.DeepEqual(d.RemovedActions, []string{"query"}) || len(d.AddedActions) != 0 {
+		t.Errorf("actions +%v -%v, want -[query]", d.AddedActions, d.RemovedActions)
+	}
+	if !reflect.DeepEqual(d.AddedState, []string{"weight"}) || len(d.RemovedState) != 0 {
+		t.Errorf("state +%v -%v, want +[weight]", d.AddedState, d.RemovedState)
+	}
+	if !reflect.DeepEqual(d.RemovedTransitions, []string{"idle->querying via query"}) {
+		t.Errorf("removed transitions = %v", d.RemovedTransitions)
+	}
+
+	want := []BreakingChange{{Kind: "action", Name: "query", Syncs: []string{"fan_out"}}}
+	if !reflect.DeepEqual(d.Breaking, want) {
+		t.Errorf("breaking = %+v, want %+v", d.Breaking, want)
+	}
+	if !d.HasChanges() {
+		t.Error("HasChanges = false")
+	}
+}
+
+func TestDiffConceptsUnreferencedRemovalIsNotBreaking(t *testing.T) {
+	stored := gam.Concept{Spec: gam.ConceptSpec{Actions: map[string]gam.ActionSpec{"legacy": {}}}}
+	d := DiffConcepts(stored, gam.Concept{}, nil, nil)
+	if len(d.RemovedActions) != 1 || len(d.Breaking) != 0 {
+		t.Errorf("diff = %+v, want a non-breaking removal", d)
+	}
+
+	if DiffConcepts(stored, stored, nil, nil).HasChanges() {
+		t.Error("identical concepts reported changes")
+	}
+}
+
+func TestConceptVersionsRecordedOnUpdate(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+	const name = "VersionTestConcept"
+	defer pool.Exec(ctx, `DELETE FROM concepts WHERE name = $1`, name)
+	defer pool.Exec(ctx, `DELETE FROM concept_versions WHERE concept_name = $1`, name)
+
+	define := func(actions ...string) gam.Concept {
+		c := gam.Concept{Name: name, Purpose: "versioning test", Spec: gam.ConceptSpec{Actions: map[string]gam.ActionSpec{}}}
+		for _, a := range actions {
+			c.Spec.Actions[a] = gam.ActionSpec{}
+		}
+		return c
+	}
+
+	for _, c := range []gam.Concept{define("a"), define("a", "b"), define("a", "b", "c"), define("a", "b", "c")} {
+		if err := UpsertConcept(ctx, pool, c); err != nil {
+			t.Fatalf("UpsertConcept: %v", err)
+		}
+	}
+
+	versions, err := ConceptVersions(ctx, pool, name)
+	if err != nil {
+		t.Fatalf("ConceptVersions: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("got %d versions, want 2 (the unchanged upsert adds none)", len(versions))
+	}
+	for i, want := range []int{1, 2} {
+		v := versions[i]
+		if v.Version != want || len(v.Concept.Spec.Actions) != want {
+			t.Errorf("version %d = v%d with %d actions, want v%d with %d", i, v.Version, len(v.Concept.Spec.Actions), want, want)
+		}
+	}
+
+	current, err := LoadConcept(ctx, pool, name)
+	if err != nil {
+		t.Fatalf("LoadConcept: %v", err)
+	}
+	if d := DiffConcepts(versions[0].Concept, current, nil, nil); !reflect.DeepEqual(d.AddedActions, []string{"b", "c"}) {
+		t.Errorf("diff v1 -> current added %v, want [b c]", d.AddedActions)
+	}
+}
@@ -0,0 +1,56 @@
+package memorizer
+
+import (
+	"context"
+	"time"
+)
+
+// Conflict is a pair of ACTIVE turns whose scopes overlap: Ancestor's scope
+// equals or contains Descendant's. Proposals from the two turns contend for
+// the same hierarchical lock (see lockRegionPath) and can race on approval.
+type Conflict struct {
+	Ancestor   ConflictTurn `json:"ancestor"`
+	Descendant ConflictTurn `json:"descendant"`
+}
+
+// ConflictTurn identifies one side of a Conflict.
+type ConflictTurn struct {
+	ID        string    `json:"id"`
+	Scope     string    `json:"scope_path"`
+	Role      string    `json:"agent_role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DetectScopeConflicts returns every pair of ACTIVE turns whose scope paths
+// are equal or in an ancestor/descendant relationship. Sibling scopes never
+// conflict. Each pair is reported once, ordered by ancestor then descendant
+// scope.
+func (m *Memorizer) DetectScopeConflicts(ctx context.Context) ([]Conflict, error) {
+	rows, err := m.db.Query(ctx, `
+		SELECT a.id, a.scope_path::text, COALESCE(a.agent_role, ''), a.created_at,
+		       d.id, d.scope_path::text, COALESCE(d.agent_role, ''), d.created_at
+		FROM turns a
+		JOIN turns d ON d.scope_path <@ a.scope_path
+		  AND a.id <> d.id
+		  -- Equal scopes match both ways; keep one row per pair.
+		  AND (a.scope_path <> d.scope_path OR a.id < d.id)
+		WHERE a.status = 'ACTIVE' AND d.status = 'ACTIVE'
+		  AND a.scope_path IS NOT NULL AND d.scope_path IS NOT NULL
+		ORDER BY a.scope_path, d.scope_path, a.created_at, d.created_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conflicts []Conflict
+	for rows.Next() {
+		var c Conflict
+		if err := rows.Scan(&c.Ancestor.ID, &c.Ancestor.Scope, &c.Ancestor.Role, &c.Ancestor.CreatedAt,
+			&c.Descendant.ID, &c.Descendant.Scope, &c.Descendant.Role, &c.Descendant.CreatedAt); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, c)
+	}
+	return conflicts, rows.Err()
+}
@@ -0,0 +1,49 @@
+package memorizer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestDetectScopeConflicts(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	turns := []struct{ id, scope, status string }{
+		{"conflicttest-parent", "conflicttest.app", "ACTIVE"},
+		{"conflicttest-child", "conflicttest.app.search", "ACTIVE"},
+		{"conflicttest-sibling", "conflicttest.other", "ACTIVE"},
+		{"conflicttest-done", "conflicttest.app.search.index", "COMPLETED"},
+	}
+	for _, tt := range turns {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO turns (id, agent_role, scope_path, status)
+			VALUES ($1, 'implementer', $2, $3)
+		`, tt.id, tt.scope, tt.status); err != nil {
+			t.Fatalf("insert turn: %v", err)
+		}
+		defer pool.Exec(ctx, `DELETE FROM turns WHERE id = $1`, tt.id)
+	}
+
+	m := &Memorizer{db: pool}
+	conflicts, err := m.DetectScopeConflicts(ctx)
+	if err != nil {
+		t.Fatalf("DetectScopeConflicts: %v", err)
+	}
+
+	var ours []Conflict
+	for _, c := range conflicts {
+		if strings.HasPrefix(c.Ancestor.ID, "conflicttest-") || strings.HasPrefix(c.Descendant.ID, "conflicttest-") {
+			ours = append(ours, c)
+		}
+	}
+	if len(ours) != 1 {
+		t.Fatalf("expected 1 conflict, got %d: %+v", len(ours), ours)
+	}
+	if ours[0].Ancestor.ID != "conflicttest-parent" || ours[0].Descendant.ID != "conflicttest-child" {
+		t.Errorf("unexpected conflict: %+v", ours[0])
+	}
+}
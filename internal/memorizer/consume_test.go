@@ -0,0 +1,583 @@
+package memorizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/queue"
+)
+
+// fakeSource delivers msgs, up to perRead (or n) per read, then blocks like
+// an empty stream until ctx is cancelled. Delivered messages stay pending
+// under their consumer until acked, like a consumer group's PEL.
+type fakeSource struct {
+	mu        sync.Mutex
+	msgs      []*queue.ProposalMessage
+	perRead   int
+	claims    map[string]bool
+	acked     []string
+	pending   map[string][]*queue.ProposalMessage
+	consumers map[string]int
+	draining  bool
+	reads     int
+}
+
+func (f *fakeSource) ReadProposals(ctx context.Context, consumer string, n int) ([]*queue.ProposalMessage, []string, error) {
+	f.mu.Lock()
+	f.reads++
+	if f.perRead > 0 && f.perRead < n {
+		n = f.perRead
+	}
+	n = min(n, len(f.msgs))
+	msgs := f.msgs[:n]
+	f.msgs = f.msgs[n:]
+	if f.consumers != nil && len(msgs) > 0 {
+		f.consumers[consumer] += len(msgs)
+	}
+	if f.pending == nil {
+		f.pending = make(map[string][]*queue.ProposalMessage)
+	}
+	f.pending[consumer] = append(f.pending[consumer], msgs...)
+	f.mu.Unlock()
+	if len(msgs) == 0 {
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	}
+	return msgs, fakeIDs(msgs), nil
+}
+
+func (f *fakeSource) ReadPendingProposals(ctx context.Context, consumer string, n int) ([]*queue.ProposalMessage, []string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	msgs := f.pending[consumer]
+	if len(msgs) == 0 {
+		return nil, nil, queue.ErrNoMessages
+	}
+	msgs = append([]*queue.ProposalMessage(nil), msgs[:min(n, len(msgs))]...)
+	return msgs, fakeIDs(msgs), nil
+}
+
+func fakeIDs(msgs []*queue.ProposalMessage) []string {
+	ids := make([]string, len(msgs))
+	for i, m := range msgs {
+		ids[i] = "id-" + m.ProposalID
+	}
+	return ids
+}
+
+func (f *fakeSource) Claim(ctx context.Context, key string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.claims[key] {
+		return false, nil
+	}
+	f.claims[key] = true
+	return true, nil
+}
+
+func (f *fakeSource) Release(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.claims, key)
+	return nil
+}
+
+func (f *fakeSource) AckProposal(ctx context.Context, msgID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.acked = append(f.acked, msgID)
+	for consumer, msgs := range f.pending {
+		for i, m := range msgs {
+			if "id-"+m.ProposalID == msgID {
+				f.pending[consumer] = append(msgs[:i:i], msgs[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// pendingCount returns how many delivered messages are not yet acked.
+func (f *fakeSource) pendingCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, msgs := range f.pending {
+		n += len(msgs)
+	}
+	return n
+}
+
+func (f *fakeSource) Draining(ctx context.Context) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.draining, nil
+}
+
+func TestConsumeFinishesInFlightProposalOnCancel(t *testing.T) {
+	m := &Memorizer{workers: 1, grace: 2 * time.Second}
+	src := &fakeSource{
+		msgs:   []*queue.ProposalMessage{{ProposalID: "p1", RegionPath: "app"}},
+		claims: make(map[string]bool),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var workErr error
+	process := func(ctx context.Context, id, path string) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		workErr = ctx.Err()
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- m.consume(ctx, src, process) }()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("consume returned %v, want context.Canceled", err)
+		}
+	case <-time.After(m.grace + time.Second):
+		t.Fatal("consume did not return within the grace window")
+	}
+
+	if workErr != nil {
+		t.Errorf("in-flight proposal saw cancelled context: %v", workErr)
+	}
+	if len(src.acked) != 1 || src.acked[0] != "id-p1" {
+		t.Errorf("acked = %v, want the in-flight proposal acked", src.acked)
+	}
+}
+
+func TestConsumeCancelsWorkAfterGrace(t *testing.T) {
+	m := &Memorizer{workers: 1, grace: 100 * time.Millisecond}
+	src := &fakeSource{
+		msgs:   []*queue.ProposalMessage{{ProposalID: "slow", RegionPath: "app"}},
+		claims: make(map[string]bool),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	process := func(ctx context.Context, id, path string) error {
+		close(started)
+		<-ctx.Done() // a proposal that only stops when told to
+		return ctx.Err()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- m.consume(ctx, src, process) }()
+
+	<-started
+	begin := time.Now()
+	cancel()
+
+	select {
+	case <-done:
+		if elapsed := time.Since(begin); elapsed < m.grace {
+			t.Errorf("returned after %s, before the %s grace period", elapsed, m.grace)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("consume did not return after the grace period elapsed")
+	}
+	if len(src.acked) != 1 || src.pendingCount() != 0 {
+		t.Errorf("acked = %v with %d pending, want the cancelled proposal acked", src.acked, src.pendingCount())
+	}
+}
+
+func TestConsumeSkipsUnstartedProposalsOnCancel(t *testing.T) {
+	m := &Memorizer{workers: 1, grace: time.Second}
+	src := &fakeSource{
+		msgs: []*queue.ProposalMessage{
+			{ProposalID: "a", RegionPath: "app"},
+			{ProposalID: "b", RegionPath: "app"},
+		},
+		claims: make(map[string]bool),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var processed []string
+	process := func(pctx context.Context, id, path string) error {
+		processed = append(processed, id)
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+
+	if err := m.consume(ctx, src, process); err != context.Canceled {
+		t.Fatalf("consume returned %v", err)
+	}
+	if len(processed) != 1 {
+		t.Fatalf("processed %v, want only the first proposal", processed)
+	}
+	if src.claims["proposal:b"] {
+		t.Error("unstarted proposal was claimed and would be skipped after restart")
+	}
+}
+
+func TestConsumeResumesPendingAfterRestart(t *testing.T) {
+	m := &Memorizer{workers: 1, grace: time.Second}
+	src := &fakeSource{claims: make(map[string]bool)}
+	for _, id := range []string{"a", "b", "c"} {
+		src.msgs = append(src.msgs, &queue.ProposalMessage{ProposalID: id, RegionPath: "app"})
+	}
+
+	var processed []string
+	ctx, cancel := context.WithCancel(context.Background())
+	m.consume(ctx, src, func(pctx context.Context, id, path string) error {
+		processed = append(processed, id)
+		cancel() // stop mid-batch, after the first proposal
+		return nil
+	})
+	if src.pendingCount() != 2 {
+		t.Fatalf("pending after cancel = %d, want the 2 unstarted proposals", src.pendingCount())
+	}
+
+	// Restart: the same consumer finishes its pending batch before reading
+	// anything new.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- m.consume(ctx, src, func(pctx context.Context, id, path string) error {
+			processed = append(processed, id)
+			if len(processed) == 3 {
+				cancel()
+			}
+			return nil
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		cancel()
+		t.Fatal("restarted consumer did not process its pending proposals")
+	}
+
+	if strings.Join(processed, ",") != "a,b,c" {
+		t.Errorf("processed %v, want a,b,c each once", processed)
+	}
+	if src.pendingCount() != 0 || len(src.acked) != 3 {
+		t.Errorf("acked %v with %d still pending, want all 3 acked", src.acked, src.pendingCount())
+	}
+}
+
+func TestConsumeWorkersDrainBatch(t *testing.T) {
+	m := &Memorizer{workers: 4, grace: time.Second}
+	src := &fakeSource{perRead: 2, claims: make(map[string]bool), consumers: make(map[string]int)}
+	const total = 20
+	for i := 0; i < total; i++ {
+		src.msgs = append(src.msgs, &queue.ProposalMessage{
+			ProposalID: fmt.Sprintf("p%d", i),
+			RegionPath: fmt.Sprintf("app.r%d", i),
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	processed := make(map[string]int)
+	running, peak := 0, 0
+	process := func(pctx context.Context, id, path string) error {
+		mu.Lock()
+		processed[id]++
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		if len(processed) == total {
+			cancel()
+		}
+		mu.Unlock()
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- m.consume(ctx, src, process) }()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		cancel()
+		t.Fatal("workers did not drain the batch")
+	}
+
+	if len(processed) != total {
+		t.Errorf("processed %d distinct proposals, want %d", len(processed), total)
+	}
+	for id, n := range processed {
+		if n != 1 {
+			t.Errorf("proposal %s processed %d times", id, n)
+		}
+	}
+	if len(src.acked) != total {
+		t.Errorf("acked %d, want %d", len(src.acked), total)
+	}
+	if len(src.consumers) < 2 {
+		t.Errorf("reads came from consumers %v, want distinct consumer names per worker", src.consumers)
+	}
+	if peak < 2 {
+		t.Errorf("peak concurrency %d, want workers running in parallel", peak)
+	}
+}
+
+func TestConsumeLogsProposalFields(t *testing.T) {
+	var buf bytes.Buffer
+	m := &Memorizer{workers: 1, grace: time.Second}
+	m.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	src := &fakeSource{
+		msgs:   []*queue.ProposalMessage{{ProposalID: "p1", RegionPath: "app.search"}},
+		claims: make(map[string]bool),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	process := func(ctx context.Context, id, path string) error {
+		defer cancel()
+		return errors.New("lock timeout")
+	}
+	m.consume(ctx, src, process)
+	m.logValidation("p2", "app.index", &gam.ValidationResult{
+		Tier: 1, Code: 4, Message: "illegal transition",
+		Details: []gam.ValidationDetail{{Check: "state_machine", Passed: false}},
+	})
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e map[string]any
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("log line is not JSON: %q", line)
+		}
+		entries = append(entries, e)
+	}
+
+	find := func(msg string) map[string]any {
+		for _, e := range entries {
+			if e["msg"] == msg {
+				return e
+			}
+		}
+		t.Fatalf("no %q entry in logs:\n%s", msg, buf.String())
+		return nil
+	}
+
+	failed := find("proposal failed")
+	if failed["level"] != "ERROR" || failed["proposal"] != "p1" || failed["region"] != "app.search" ||
+		failed["consumer"] != "memorizer_1" || failed["error"] != "lock timeout" {
+		t.Errorf("proposal failed entry = %v", failed)
+	}
+	if find("processing proposal")["level"] != "DEBUG" {
+		t.Error("processing entry should be debug level")
+	}
+
+	rejected := find("proposal rejected")
+	if rejected["level"] != "WARN" || rejected["proposal"] != "p2" || rejected["region"] != "app.index" ||
+		rejected["tier"] != float64(1) || rejected["code"] != float64(4) {
+		t.Errorf("proposal rejected entry = %v", rejected)
+	}
+	if checks, _ := rejected["failed_checks"].([]any); len(checks) != 1 || checks[0] != "state_machine" {
+		t.Errorf("failed_checks = %v", rejected["failed_checks"])
+	}
+}
+
+func TestConsumeStopsReadingWhileDraining(t *testing.T) {
+	m := &Memorizer{workers: 2, grace: time.Second}
+	src := &fakeSource{
+		msgs:     []*queue.ProposalMessage{{ProposalID: "p1", RegionPath: "app"}},
+		claims:   make(map[string]bool),
+		draining: true,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	processed := 0
+	m.consume(ctx, src, func(ctx context.Context, id, path string) error {
+		processed++
+		return nil
+	})
+	if src.reads != 0 || processed != 0 {
+		t.Errorf("reads=%d processed=%d while draining, want none", src.reads, processed)
+	}
+}
+
+func TestDrainWaitsForStrandedPendingProposal(t *testing.T) {
+	m := &Memorizer{workers: 1, grace: time.Second}
+	// A previous run read p1 and stopped before processing it; p2 is new.
+	src := &fakeSource{
+		msgs:     []*queue.ProposalMessage{{ProposalID: "p2", RegionPath: "app"}},
+		pending:  map[string][]*queue.ProposalMessage{"memorizer_1": {{ProposalID: "p1", RegionPath: "app"}}},
+		claims:   make(map[string]bool),
+		draining: true,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	var processed []string
+	done := make(chan error, 1)
+	go func() {
+		done <- m.consume(ctx, src, func(pctx context.Context, id, path string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			processed = append(processed, id)
+			return nil
+		})
+	}()
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer waitCancel()
+	n, err := queue.WaitDrained(waitCtx, 10*time.Millisecond, func(context.Context) (int64, error) {
+		return int64(src.pendingCount()), nil
+	})
+	cancel()
+	<-done
+
+	if err != nil || n != 0 {
+		t.Fatalf("WaitDrained = %d, %v; want the stranded proposal finished", n, err)
+	}
+	if len(processed) != 1 || processed[0] != "p1" {
+		t.Errorf("processed %v while draining, want only the pending p1", processed)
+	}
+	if src.reads != 0 {
+		t.Errorf("read %d new batches while draining, want none", src.reads)
+	}
+}
+
+func TestDrainWaitsForStrandedPendingProposalRedis(t *testing.T) {
+	url := os.Getenv("GAM_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("GAM_TEST_REDIS_URL not set")
+	}
+	client, err := queue.ConnectRedis(url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	q := queue.New(client)
+	ctx := context.Background()
+	if err := q.EnsureStreams(ctx); err != nil {
+		t.Fatalf("ensure streams: %v", err)
+	}
+	t.Cleanup(func() { q.SetDraining(context.Background(), false) })
+
+	// Strand a proposal in memorizer_1's pending list, as a worker cancelled
+	// mid-batch would.
+	id := fmt.Sprintf("stranded-%d", time.Now().UnixNano())
+	if _, err := q.PushProposal(ctx, queue.ProposalMessage{TurnID: "turn", ProposalID: id, RegionPath: "app"}); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	readCtx, readCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer readCancel()
+	var msgID string
+	for msgID == "" {
+		msgs, ids, err := q.ReadProposals(readCtx, "memorizer_1", 10)
+		if errors.Is(err, queue.ErrNoMessages) && readCtx.Err() == nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		for i, msg := range msgs {
+			if msg.ProposalID == id {
+				msgID = ids[i]
+			}
+		}
+	}
+	if err := q.SetDraining(ctx, true); err != nil {
+		t.Fatalf("set draining: %v", err)
+	}
+
+	m := &Memorizer{workers: 1, grace: time.Second}
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- m.consume(runCtx, q, func(pctx context.Context, pid, path string) error { return nil })
+	}()
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer waitCancel()
+	n, err := queue.WaitDrained(waitCtx, 50*time.Millisecond, func(ctx context.Context) (int64, error) {
+		pending, err := q.Pending(ctx, queue.StreamProposals, 1000)
+		for _, p := range pending {
+			if p.ID == msgID {
+				return 1, err
+			}
+		}
+		return 0, err
+	})
+	cancel()
+	<-done
+	if err != nil || n != 0 {
+		t.Errorf("stranded proposal still pending after drain wait: %d, %v", n, err)
+	}
+}
+
+func TestConsumeReleasesClaimOfFailedProposal(t *testing.T) {
+	m := &Memorizer{workers: 1, grace: time.Second}
+	msg := &queue.ProposalMessage{ProposalID: "p1", RegionPath: "app"}
+	// The second copy is a re-push after the first attempt failed.
+	src := &fakeSource{msgs: []*queue.ProposalMessage{msg, msg}, claims: make(map[string]bool)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	m.consume(ctx, src, func(pctx context.Context, id, path string) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("database unavailable")
+		}
+		cancel()
+		return nil
+	})
+
+	if attempts != 2 {
+		t.Errorf("proposal attempted %d times, want the re-push processed after the failure", attempts)
+	}
+	if !src.claims[msg.IdempotencyKey()] {
+		t.Error("successful attempt should leave the proposal claimed")
+	}
+}
+
+func TestConsumeProposalsRejectsUndersizedPool(t *testing.T) {
+	config, err := pgxpool.ParseConfig("postgres://localhost:1/gamsync?pool_max_conns=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The pool connects lazily, so no server is needed.
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	// One connection per worker would deadlock: every worker holds its lock
+	// connection and waits for a second one.
+	m := &Memorizer{db: pool, workers: 4}
+	err = m.ConsumeProposals(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "at least 9 connections, have 4") {
+		t.Errorf("ConsumeProposals with workers == MaxConns = %v, want a pool size error", err)
+	}
+
+	if got := ProposalPoolConns(4); got != 9 {
+		t.Errorf("ProposalPoolConns(4) = %d, want 9", got)
+	}
+}
@@ -0,0 +1,231 @@
+package memorizer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ConceptCoverage is the amount of source a concept owns through its region
+// assignments.
+type ConceptCoverage struct {
+	Concept string           `json:"name"`
+	LOC     int              `json:"loc"`
+	Regions []RegionCoverage `json:"children"`
+}
+
+// RegionCoverage is the source size of one region assigned to a concept.
+type RegionCoverage struct {
+	Region string `json:"name"`
+	LOC    int    `json:"loc"`
+}
+
+// ConceptRegions returns every concept's assigned region paths, the inverse
+// of the validator's GetConceptsForRegion.
+func ConceptRegions(ctx context.Context, pool *pgxpool.Pool) (map[string][]string, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT c.name, r.path::text
+		FROM concept_region_assignments cra
+		JOIN concepts c ON c.id = cra.concept_id
+		JOIN regions r ON r.id = cra.region_id
+		ORDER BY c.name, r.path
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("load concept assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assigned := make(map[string][]string)
+	for rows.Next() {
+		var concept, path string
+		if err := rows.Scan(&concept, &path); err != nil {
+			return nil, fmt.Errorf("load concept assignments: %w", err)
+		}
+		assigned[concept] = append(assigned[concept], path)
+	}
+	return assigned, rows.Err()
+}
+
+// CoverageByConcept sums regionLOC over each concept's assigned regions and
+// ranks concepts by total LOC, largest first. A region nested under another
+// region assigned to the same concept is not counted again, since its lines
+// already fall inside the ancestor's markers.
+func CoverageByConcept(assigned map[string][]string, regionLOC map[string]int) []ConceptCoverage {
+	var out []ConceptCoverage
+	for concept, paths := range assigned {
+		sorted := append([]string(nil), paths...)
+		sort.Strings(sorted)
+
+		cc := ConceptCoverage{Concept: concept, Regions: []RegionCoverage{}}
+		for _, p := range sorted {
+			if nestedIn(p, sorted) {
+				continue
+			}
+			loc := regionLOC[p]
+			cc.Regions = append(cc.Regions, RegionCoverage{Region: p, LOC: loc})
+			cc.LOC += loc
+		}
+		sort.SliceStable(cc.Regions, func(i, j int) bool { return cc.Regions[i].LOC > cc.Regions[j].LOC })
+		out = append(out, cc)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].LOC != out[j].LOC {
+			return out[i].LOC > out[j].LOC
+		}
+		return out[i].Concept < out[j].Concept
+	})
+	return out
+}
+
+// CoverageGap is a concept assignment whose region has no source markers, so
+// the concept has nowhere in the code to live.
+type CoverageGap struct {
+	Concept string `json:"concept"`
+	Region  string `json:"region"`
+	InArch  bool   `json:"in_arch"` // declared in arch.md, not only in the DB
+}
+
+// CoverageGaps returns the assignments in assigned whose region has no
+// source markers, ordered by concept then region. A region counts as marked
+// when it or any region beneath it appears in markedPaths. archPaths is used
+// only to report where an unmarked region is declared.
+func CoverageGaps(assigned map[string][]string, markedPaths, archPaths []string) []CoverageGap {
+	marked := make(map[string]bool)
+	for _, p := range markedPaths {
+		// Mark every ancestor too, so a namespace covered by its children
+		// is not reported.
+		parts := strings.Split(p, ".")
+		for i := range parts {
+			marked[strings.Join(parts[:i+1], ".")] = true
+		}
+	}
+	inArch := make(map[string]bool)
+	for _, p := range archPaths {
+		inArch[p] = true
+	}
+
+	var gaps []CoverageGap
+	for concept, paths := range assigned {
+		for _, p := range paths {
+			if !marked[p] {
+				gaps = append(gaps, CoverageGap{Concept: concept, Region: p, InArch: inArch[p]})
+			}
+		}
+	}
+	sort.Slice(gaps, func(i, j int) bool {
+		if gaps[i].Concept != gaps[j].Concept {
+			return gaps[i].Concept < gaps[j].Concept
+		}
+		return gaps[i].Region < gaps[j].Region
+	})
+	return gaps
+}
+
+// nestedIn reports whether path is a strict descendant of any of paths.
+func nestedIn(path string, paths []string) bool {
+	for _, p := range paths {
+		if strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// ConceptUsage summarizes how actively a concept is developed: its assigned
+// regions, the turns that touched those regions (or regions beneath them)
+// in a window, and the syncs that reference it.
+type ConceptUsage struct {
+	Concept string `json:"concept"`
+	Regions int    `json:"regions"`
+	Turns   int    `json:"turns"`
+	Syncs   int    `json:"syncs"`
+}
+
+// ConceptUsageReport returns usage for every concept, counting turns created
+// at or after since, ordered by turn count (most active first) then name.
+func ConceptUsageReport(ctx context.Context, pool *pgxpool.Pool, since time.Time) ([]ConceptUsage, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT c.name,
+		       (SELECT COUNT(*) FROM concept_region_assignments cra WHERE cra.concept_id = c.id),
+		       (SELECT COUNT(DISTINCT tr.turn_id)
+		          FROM concept_region_assignments cra
+		          JOIN regions ar ON ar.id = cra.region_id
+		          JOIN regions r ON r.path <@ ar.path
+		          JOIN turn_regions tr ON tr.region_id = r.id
+		          JOIN turns t ON t.id = tr.turn_id
+		         WHERE cra.concept_id = c.id AND t.created_at >= $1),
+		       (SELECT COUNT(DISTINCT sr.sync_id) FROM sync_refs sr WHERE sr.concept_name = c.name)
+		FROM concepts c
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("concept usage: %w", err)
+	}
+	defer rows.Close()
+
+	var out []ConceptUsage
+	for rows.Next() {
+		var u ConceptUsage
+		if err := rows.Scan(&u.Concept, &u.Regions, &u.Turns, &u.Syncs); err != nil {
+			return nil, fmt.Errorf("concept usage: %w", err)
+		}
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Turns != out[j].Turns {
+			return out[i].Turns > out[j].Turns
+		}
+		return out[i].Concept < out[j].Concept
+	})
+	return out, nil
+}
+
+// DeadAction is a concept action that no sync references and that has not
+// run since the report window began.
+type DeadAction struct {
+	Concept string `json:"concept"`
+	Action  string `json:"action"`
+}
+
+// DeadActions lists the actions declared in each concept's spec that appear
+// in no sync_refs row and in no flow_log entry at or after since, ordered by
+// concept then action.
+func DeadActions(ctx context.Context, pool *pgxpool.Pool, since time.Time) ([]DeadAction, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT c.name, a.action
+		FROM concepts c
+		CROSS JOIN LATERAL jsonb_object_keys(
+			CASE WHEN jsonb_typeof(c.spec->'actions') = 'object' THEN c.spec->'actions' ELSE '{}'::jsonb END
+		) AS a(action)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM sync_refs sr
+			WHERE sr.concept_name = c.name AND sr.action_name = a.action
+		)
+		  AND NOT EXISTS (
+			SELECT 1 FROM flow_log fl
+			WHERE fl.concept_name = c.name AND fl.action_name = a.action
+			  AND fl.created_at >= $1
+		)
+		ORDER BY c.name, a.action
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("dead actions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []DeadAction
+	for rows.Next() {
+		var d DeadAction
+		if err := rows.Scan(&d.Concept, &d.Action); err != nil {
+			return nil, fmt.Errorf("dead actions: %w", err)
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
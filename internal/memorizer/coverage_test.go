@@ -0,0 +1,171 @@
+package memorizer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestCoverageByConcept(t *testing.T) {
+	markers := []*region.RegionMarker{
+		{Path: "app.search", File: "search.go", StartLine: 1, EndLine: 52},
+		{Path: "app.search.query", File: "search.go", StartLine: 10, EndLine: 21},
+		{Path: "app.index", File: "index.go", StartLine: 3, EndLine: 34},
+		{Path: "app.index", File: "index_build.go", StartLine: 1, EndLine: 12},
+		{Path: "app.auth", File: "auth.go", StartLine: 1, EndLine: 8},
+		{Path: "app.broken", File: "broken.go", StartLine: 5},
+	}
+	loc := region.RegionLOC(markers)
+	if loc["app.index"] != 40 || loc["app.search"] != 50 || loc["app.broken"] != 0 {
+		t.Fatalf("RegionLOC = %v", loc)
+	}
+
+	assigned := map[string][]string{
+		"Search":  {"app.search", "app.index", "app.search.query"},
+		"Session": {"app.auth"},
+	}
+	got := CoverageByConcept(assigned, loc)
+	want := []ConceptCoverage{
+		{Concept: "Search", LOC: 90, Regions: []RegionCoverage{{"app.search", 50}, {"app.index", 40}}},
+		{Concept: "Session", LOC: 6, Regions: []RegionCoverage{{"app.auth", 6}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CoverageByConcept = %+v, want %+v", got, want)
+	}
+}
+
+func TestCoverageGaps(t *testing.T) {
+	assigned := map[string][]string{
+		"Search":  {"app.search", "app.index"},
+		"Session": {"app.auth"},
+		"Billing": {"app.billing", "app"},
+	}
+	marked := []string{"app.search.query", "app.index"}
+	arch := []string{"app", "app.search", "app.index", "app.billing"}
+
+	got := CoverageGaps(assigned, marked, arch)
+	want := []CoverageGap{
+		{Concept: "Billing", Region: "app.billing", InArch: true},
+		{Concept: "Session", Region: "app.auth", InArch: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CoverageGaps = %+v, want %+v", got, want)
+	}
+}
+
+func TestConceptUsageReport(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	for _, path := range []string{"usagetest.a", "usagetest.a.child", "usagetest.b"} {
+		pool.Exec(ctx, `INSERT INTO regions (path) VALUES ($1) ON CONFLICT (path) DO NOTHING`, path)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'usagetest'`)
+	pool.Exec(ctx, `INSERT INTO concepts (name, purpose, spec, state_machine) VALUES ('UsageTest', 'test', '{}', '{}') ON CONFLICT (name) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM concepts WHERE name = 'UsageTest'`)
+	pool.Exec(ctx, `
+		INSERT INTO concept_region_assignments (concept_id, region_id)
+		SELECT c.id, r.id FROM concepts c, regions r
+		WHERE c.name = 'UsageTest' AND r.path IN ('usagetest.a', 'usagetest.b')
+	`)
+
+	// Two recent turns touch the subtree (one on a child region), one turn
+	// is outside the window, and one touches an unrelated region.
+	pool.Exec(ctx, `
+		INSERT INTO turns (id, agent_role, status, created_at) VALUES
+		  ('usagetest-1', 'implementer', 'COMPLETED', NOW()),
+		  ('usagetest-2', 'implementer', 'COMPLETED', NOW()),
+		  ('usagetest-old', 'implementer', 'COMPLETED', NOW() - INTERVAL '60 days')
+	`)
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id LIKE 'usagetest-%'`)
+	pool.Exec(ctx, `
+		INSERT INTO turn_regions (turn_id, region_id, action)
+		SELECT t, r.id, 'modified' FROM regions r,
+		  (VALUES ('usagetest-1', 'usagetest.a'), ('usagetest-1', 'usagetest.b'),
+		          ('usagetest-2', 'usagetest.a.child'), ('usagetest-old', 'usagetest.a')) v(t, p)
+		WHERE r.path = v.p::ltree
+	`)
+	defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id LIKE 'usagetest-%'`)
+
+	var syncID string
+	pool.QueryRow(ctx, `
+		INSERT INTO synchronizations (name, when_clause, then_clause) VALUES ('UsageTestSync', '[]', '[]') RETURNING id
+	`).Scan(&syncID)
+	defer pool.Exec(ctx, `DELETE FROM synchronizations WHERE name = 'UsageTestSync'`)
+	pool.Exec(ctx, `
+		INSERT INTO sync_refs (sync_id, concept_name, action_name, clause_type) VALUES
+		  ($1, 'UsageTest', 'a', 'when'), ($1, 'UsageTest', 'b', 'then')
+	`, syncID)
+
+	usage, err := ConceptUsageReport(ctx, pool, time.Now().Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range usage {
+		if u.Concept == "UsageTest" {
+			want := ConceptUsage{Concept: "UsageTest", Regions: 2, Turns: 2, Syncs: 1}
+			if u != want {
+				t.Errorf("usage = %+v, want %+v", u, want)
+			}
+			return
+		}
+	}
+	t.Fatalf("UsageTest missing from report: %+v", usage)
+}
+
+func TestDeadActions(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	pool.Exec(ctx, `
+		INSERT INTO concepts (name, purpose, spec, state_machine)
+		VALUES ('DeadTest', 'test', '{"actions": {"referenced": {}, "ran": {}, "unused": {}}}', '{}')
+		ON CONFLICT (name) DO UPDATE SET spec = EXCLUDED.spec
+	`)
+	defer pool.Exec(ctx, `DELETE FROM concepts WHERE name = 'DeadTest'`)
+
+	var syncID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO synchronizations (name, when_clause, then_clause) VALUES ('DeadTestSync', '[]', '[]') RETURNING id
+	`).Scan(&syncID); err != nil {
+		t.Fatalf("insert sync: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM synchronizations WHERE id = $1`, syncID)
+	pool.Exec(ctx, `
+		INSERT INTO sync_refs (sync_id, concept_name, action_name, clause_type)
+		VALUES ($1, 'DeadTest', 'referenced', 'when')
+	`, syncID)
+
+	// "ran" has only an old flow_log entry, so it is dead within 7d but
+	// alive within 60d.
+	pool.Exec(ctx, `
+		INSERT INTO flow_log (flow_token, concept_name, action_name, created_at)
+		VALUES (gen_random_uuid(), 'DeadTest', 'ran', NOW() - INTERVAL '30 days')
+	`)
+	defer pool.Exec(ctx, `DELETE FROM flow_log WHERE concept_name = 'DeadTest'`)
+
+	deadFor := func(window time.Duration) []string {
+		all, err := DeadActions(ctx, pool, time.Now().Add(-window))
+		if err != nil {
+			t.Fatalf("DeadActions: %v", err)
+		}
+		var out []string
+		for _, d := range all {
+			if d.Concept == "DeadTest" {
+				out = append(out, d.Action)
+			}
+		}
+		return out
+	}
+
+	if got := deadFor(7 * 24 * time.Hour); !reflect.DeepEqual(got, []string{"ran", "unused"}) {
+		t.Errorf("dead within 7d = %v, want [ran unused]", got)
+	}
+	if got := deadFor(60 * 24 * time.Hour); !reflect.DeepEqual(got, []string{"unused"}) {
+		t.Errorf("dead within 60d = %v, want [unused]", got)
+	}
+}
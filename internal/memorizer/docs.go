@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/sbenjam1n/gamsync/internal/gam"
@@ -53,6 +54,11 @@ func (d *DocsExporter) ExportAll(ctx context.Context) error {
 
 // ExportConcepts writes concept specs to docs/concepts/.
 func (d *DocsExporter) ExportConcepts(ctx context.Context) error {
+	refs, err := d.actionSyncRefs(ctx)
+	if err != nil {
+		return err
+	}
+
 	rows, err := d.m.db.Query(ctx, `
 		SELECT name, purpose, spec, invariants FROM concepts ORDER BY name
 	`)
@@ -65,84 +71,147 @@ func (d *DocsExporter) ExportConcepts(ctx context.Context) error {
 	index.WriteString("# Concept Catalog\n\n")
 
 	for rows.Next() {
-		var name, purpose string
+		var c gam.Concept
 		var specJSON, invJSON []byte
-		rows.Scan(&name, &purpose, &specJSON, &invJSON)
+		rows.Scan(&c.Name, &c.Purpose, &specJSON, &invJSON)
+		json.Unmarshal(specJSON, &c.Spec)
+		json.Unmarshal(invJSON, &c.Invariants)
+
+		index.WriteString(fmt.Sprintf("- **%s**: %s\n", c.Name, c.Purpose))
 
-		var spec gam.ConceptSpec
-		json.Unmarshal(specJSON, &spec)
+		filename := filepath.Join(d.projectRoot, "docs", "concepts", docSlug(c.Name)+".md")
+		os.WriteFile(filename, []byte(renderConceptDoc(c, refs[c.Name])), 0644)
+	}
 
-		var invariants []gam.Invariant
-		json.Unmarshal(invJSON, &invariants)
+	indexFile := filepath.Join(d.projectRoot, "docs", "concepts", "index.md")
+	return os.WriteFile(indexFile, []byte(index.String()), 0644)
+}
 
-		index.WriteString(fmt.Sprintf("- **%s**: %s\n", name, purpose))
+// actionSyncRef is a sync whose when or then clause references an action.
+type actionSyncRef struct {
+	Sync   string
+	Clause string
+}
 
-		// Write individual concept file
-		var content strings.Builder
-		content.WriteString(fmt.Sprintf("# %s\n\n", name))
-		content.WriteString(fmt.Sprintf("**Purpose**: %s\n\n", purpose))
+// actionSyncRefs reads the when/then entries of sync_refs, keyed by concept
+// and then action, ordered by sync name and clause.
+func (d *DocsExporter) actionSyncRefs(ctx context.Context) (map[string]map[string][]actionSyncRef, error) {
+	rows, err := d.m.db.Query(ctx, `
+		SELECT DISTINCT sr.concept_name, sr.action_name, s.name, sr.clause_type
+		FROM sync_refs sr
+		JOIN synchronizations s ON s.id = sr.sync_id
+		WHERE sr.action_name IS NOT NULL AND sr.clause_type IN ('when', 'then')
+		ORDER BY sr.concept_name, sr.action_name, s.name, sr.clause_type
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("load sync refs: %w", err)
+	}
+	defer rows.Close()
 
-		if len(spec.TypeParams) > 0 {
-			content.WriteString(fmt.Sprintf("**Type Parameters**: %s\n\n", strings.Join(spec.TypeParams, ", ")))
+	refs := make(map[string]map[string][]actionSyncRef)
+	for rows.Next() {
+		var concept, action string
+		var ref actionSyncRef
+		if err := rows.Scan(&concept, &action, &ref.Sync, &ref.Clause); err != nil {
+			return nil, fmt.Errorf("load sync refs: %w", err)
 		}
+		if refs[concept] == nil {
+			refs[concept] = make(map[string][]actionSyncRef)
+		}
+		refs[concept][action] = append(refs[concept][action], ref)
+	}
+	return refs, rows.Err()
+}
 
-		if len(spec.State) > 0 {
-			content.WriteString("## State\n\n")
-			for field, sc := range spec.State {
-				if sc.Type == "set" {
-					content.WriteString(fmt.Sprintf("- `%s`: set %s\n", field, sc.Of))
-				} else if sc.Type == "map" {
-					content.WriteString(fmt.Sprintf("- `%s`: %s -> %s\n", field, sc.From, sc.To))
-				}
+// renderConceptDoc renders the markdown page for a concept. Each action lists
+// the syncs that reference it, linked to their pages under docs/syncs/.
+// Map-backed sections are sorted so regenerating docs yields stable output.
+func renderConceptDoc(c gam.Concept, refs map[string][]actionSyncRef) string {
+	spec := c.Spec
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("# %s\n\n", c.Name))
+	content.WriteString(fmt.Sprintf("**Purpose**: %s\n\n", c.Purpose))
+
+	if len(spec.TypeParams) > 0 {
+		content.WriteString(fmt.Sprintf("**Type Parameters**: %s\n\n", strings.Join(spec.TypeParams, ", ")))
+	}
+
+	if len(spec.State) > 0 {
+		content.WriteString("## State\n\n")
+		for _, field := range sortedKeys(spec.State) {
+			sc := spec.State[field]
+			if sc.Type == "set" {
+				content.WriteString(fmt.Sprintf("- `%s`: set %s\n", field, sc.Of))
+			} else if sc.Type == "map" {
+				content.WriteString(fmt.Sprintf("- `%s`: %s -> %s\n", field, sc.From, sc.To))
 			}
-			content.WriteString("\n")
 		}
+		content.WriteString("\n")
+	}
 
-		if len(spec.Actions) > 0 {
-			content.WriteString("## Actions\n\n")
-			for actionName, action := range spec.Actions {
-				for _, c := range action.Cases {
-					inputParts := make([]string, 0)
-					for k, v := range c.Input {
-						inputParts = append(inputParts, fmt.Sprintf("%s: %s", k, v))
-					}
-					outputParts := make([]string, 0)
-					for k, v := range c.Output {
-						outputParts = append(outputParts, fmt.Sprintf("%s: %s", k, v))
-					}
-					content.WriteString(fmt.Sprintf("- `%s [%s] => [%s]`\n",
-						actionName,
-						strings.Join(inputParts, "; "),
-						strings.Join(outputParts, "; "),
-					))
-					if c.Description != "" {
-						content.WriteString(fmt.Sprintf("  %s\n", c.Description))
-					}
+	if len(spec.Actions) > 0 {
+		content.WriteString("## Actions\n\n")
+		for _, actionName := range sortedKeys(spec.Actions) {
+			for _, ac := range spec.Actions[actionName].Cases {
+				content.WriteString(fmt.Sprintf("- `%s [%s] => [%s]`\n",
+					actionName,
+					joinFields(ac.Input),
+					joinFields(ac.Output),
+				))
+				if ac.Description != "" {
+					content.WriteString(fmt.Sprintf("  %s\n", ac.Description))
 				}
 			}
-			content.WriteString("\n")
-		}
-
-		if len(invariants) > 0 {
-			content.WriteString("## Invariants\n\n")
-			for _, inv := range invariants {
-				content.WriteString(fmt.Sprintf("- **%s** (%s): %s\n", inv.Name, inv.Type, inv.Rule))
+			if actionRefs := refs[actionName]; len(actionRefs) > 0 {
+				links := make([]string, 0, len(actionRefs))
+				for _, r := range actionRefs {
+					links = append(links, fmt.Sprintf("[%s](../syncs/%s.md) (%s)", r.Sync, docSlug(r.Sync), r.Clause))
+				}
+				content.WriteString(fmt.Sprintf("  Synced by: %s\n", strings.Join(links, ", ")))
 			}
-			content.WriteString("\n")
 		}
+		content.WriteString("\n")
+	}
 
-		if spec.OperationalPrinciple != "" {
-			content.WriteString("## Operational Principle\n\n")
-			content.WriteString(fmt.Sprintf("```\n%s\n```\n", spec.OperationalPrinciple))
+	if len(c.Invariants) > 0 {
+		content.WriteString("## Invariants\n\n")
+		for _, inv := range c.Invariants {
+			content.WriteString(fmt.Sprintf("- **%s** (%s): %s\n", inv.Name, inv.Type, inv.Rule))
 		}
+		content.WriteString("\n")
+	}
 
-		slug := strings.ReplaceAll(strings.ToLower(name), " ", "-")
-		filename := filepath.Join(d.projectRoot, "docs", "concepts", slug+".md")
-		os.WriteFile(filename, []byte(content.String()), 0644)
+	if spec.OperationalPrinciple != "" {
+		content.WriteString("## Operational Principle\n\n")
+		content.WriteString(fmt.Sprintf("```\n%s\n```\n", spec.OperationalPrinciple))
 	}
 
-	indexFile := filepath.Join(d.projectRoot, "docs", "concepts", "index.md")
-	return os.WriteFile(indexFile, []byte(index.String()), 0644)
+	return content.String()
+}
+
+// joinFields renders an action case's input or output map as "k: v; ...".
+func joinFields(fields map[string]string) string {
+	parts := make([]string, 0, len(fields))
+	for _, k := range sortedKeys(fields) {
+		parts = append(parts, fmt.Sprintf("%s: %s", k, fields[k]))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// docSlug is the file name (without extension) used for a concept or sync page.
+func docSlug(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "-")
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // ExportSyncs writes synchronization definitions to docs/syncs/.
@@ -202,8 +271,7 @@ func (d *DocsExporter) ExportSyncs(ctx context.Context) error {
 		content.WriteString(string(prettyThen))
 		content.WriteString("\n```\n")
 
-		slug := strings.ReplaceAll(strings.ToLower(name), " ", "-")
-		filename := filepath.Join(d.projectRoot, "docs", "syncs", slug+".md")
+		filename := filepath.Join(d.projectRoot, "docs", "syncs", docSlug(name)+".md")
 		os.WriteFile(filename, []byte(content.String()), 0644)
 	}
 
@@ -0,0 +1,40 @@
+package memorizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+func TestRenderConceptDocLinksSyncs(t *testing.T) {
+	c := gam.Concept{
+		Name:    "Password",
+		Purpose: "securely store credentials",
+		Spec: gam.ConceptSpec{Actions: map[string]gam.ActionSpec{
+			"set":   {Cases: []gam.ActionCase{{Input: map[string]string{"user": "U", "password": "string"}, Output: map[string]string{"user": "U"}}}},
+			"check": {Cases: []gam.ActionCase{{Input: map[string]string{"user": "U"}, Output: map[string]string{"valid": "bool"}}}},
+		}},
+	}
+	refs := map[string][]actionSyncRef{
+		"set": {{Sync: "Register User", Clause: "then"}},
+	}
+
+	doc := renderConceptDoc(c, refs)
+
+	if !strings.Contains(doc, "Synced by: [Register User](../syncs/register-user.md) (then)") {
+		t.Errorf("doc does not link the referencing sync:\n%s", doc)
+	}
+	if strings.Count(doc, "Synced by:") != 1 {
+		t.Errorf("only set is referenced, got:\n%s", doc)
+	}
+	if !strings.Contains(doc, "`set [password: string; user: U] => [user: U]`") {
+		t.Errorf("action fields not sorted:\n%s", doc)
+	}
+	if strings.Index(doc, "`check") > strings.Index(doc, "`set") {
+		t.Errorf("actions not sorted:\n%s", doc)
+	}
+	if doc != renderConceptDoc(c, refs) {
+		t.Error("renderConceptDoc is not deterministic")
+	}
+}
@@ -0,0 +1,79 @@
+package memorizer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultFlowGCBatch is how many flow roots PruneFlowLog deletes per
+// statement when no batch size is given.
+const DefaultFlowGCBatch = 500
+
+// FlowGCResult reports what PruneFlowLog removed, or would remove on a dry
+// run: Flows root entries and Rows entries in total, children included.
+type FlowGCResult struct {
+	Flows int64 `json:"flows"`
+	Rows  int64 `json:"rows"`
+}
+
+// PruneFlowLog deletes flow_log entries older than before. Whole flows are
+// pruned: a root entry (one with no parent) created before the cutoff is
+// deleted together with every entry beneath it, however recent, so no child
+// is left pointing at a missing parent and a flow that started inside the
+// window is kept intact. Roots are deleted batchSize at a time, each batch in
+// its own statement, to keep locks short on a large table. With dryRun set
+// the rows are counted but not deleted.
+func PruneFlowLog(ctx context.Context, pool *pgxpool.Pool, before time.Time, batchSize int, dryRun bool) (FlowGCResult, error) {
+	var res FlowGCResult
+	if batchSize <= 0 {
+		batchSize = DefaultFlowGCBatch
+	}
+
+	if dryRun {
+		err := pool.QueryRow(ctx, `
+			WITH RECURSIVE old_roots AS (
+				SELECT id FROM flow_log WHERE parent_id IS NULL AND created_at < $1
+			), tree AS (
+				SELECT id FROM old_roots
+				UNION ALL
+				SELECT fl.id FROM flow_log fl JOIN tree t ON fl.parent_id = t.id
+			)
+			SELECT (SELECT COUNT(*) FROM old_roots), (SELECT COUNT(*) FROM tree)
+		`, before).Scan(&res.Flows, &res.Rows)
+		if err != nil {
+			return res, fmt.Errorf("count old flows: %w", err)
+		}
+		return res, nil
+	}
+
+	for {
+		var flows, rows int64
+		err := pool.QueryRow(ctx, `
+			WITH RECURSIVE old_roots AS (
+				SELECT id FROM flow_log
+				WHERE parent_id IS NULL AND created_at < $1
+				ORDER BY created_at
+				LIMIT $2
+			), tree AS (
+				SELECT id FROM old_roots
+				UNION ALL
+				SELECT fl.id FROM flow_log fl JOIN tree t ON fl.parent_id = t.id
+			), deleted AS (
+				DELETE FROM flow_log WHERE id IN (SELECT id FROM tree)
+				RETURNING parent_id
+			)
+			SELECT COUNT(*) FILTER (WHERE parent_id IS NULL), COUNT(*) FROM deleted
+		`, before, batchSize).Scan(&flows, &rows)
+		if err != nil {
+			return res, fmt.Errorf("prune flow log: %w", err)
+		}
+		res.Flows += flows
+		res.Rows += rows
+		if flows < int64(batchSize) {
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package memorizer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestPruneFlowLog(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	old, recent := now.Add(-40*24*time.Hour), now.Add(-time.Hour)
+	insert := func(concept string, parent *string, at time.Time) string {
+		t.Helper()
+		var id string
+		if err := pool.QueryRow(ctx, `
+			INSERT INTO flow_log (flow_token, concept_name, action_name, parent_id, created_at)
+			VALUES (gen_random_uuid(), $1, 'act', $2, $3) RETURNING id
+		`, concept, parent, at).Scan(&id); err != nil {
+			t.Fatalf("insert flow entry: %v", err)
+		}
+		return id
+	}
+	defer pool.Exec(ctx, `DELETE FROM flow_log WHERE concept_name LIKE 'FlowGCTest%'`)
+
+	// An old flow with a child and a grandchild (the grandchild is recent),
+	// a second old flow, and a recent flow whose child is recent.
+	oldRoot := insert("FlowGCTestOld", nil, old)
+	oldChild := insert("FlowGCTestOld", &oldRoot, old)
+	insert("FlowGCTestOld", &oldChild, recent)
+	insert("FlowGCTestOld", nil, old)
+	recentRoot := insert("FlowGCTestRecent", nil, recent)
+	insert("FlowGCTestRecent", &recentRoot, recent)
+
+	cutoff := now.Add(-30 * 24 * time.Hour)
+	count := func(concept string) int {
+		var n int
+		pool.QueryRow(ctx, `SELECT COUNT(*) FROM flow_log WHERE concept_name = $1`, concept).Scan(&n)
+		return n
+	}
+
+	dry, err := PruneFlowLog(ctx, pool, cutoff, 1, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if dry.Flows < 2 || dry.Rows < 4 || count("FlowGCTestOld") != 4 {
+		t.Fatalf("dry run = %+v, old rows left = %d; want nothing deleted", dry, count("FlowGCTestOld"))
+	}
+
+	// A batch size of one exercises the batching loop.
+	res, err := PruneFlowLog(ctx, pool, cutoff, 1, false)
+	if err != nil {
+		t.Fatalf("PruneFlowLog: %v", err)
+	}
+	if res.Flows < 2 || res.Rows < 4 {
+		t.Errorf("result = %+v, want at least 2 flows and 4 rows", res)
+	}
+	if n := count("FlowGCTestOld"); n != 0 {
+		t.Errorf("%d old flow entries left, want 0", n)
+	}
+	if n := count("FlowGCTestRecent"); n != 2 {
+		t.Errorf("%d recent flow entries left, want 2", n)
+	}
+}
@@ -3,20 +3,88 @@ package memorizer
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sbenjam1n/gamsync/internal/region"
 )
 
 // GardenFinding represents an entropy issue discovered by the gardener.
 type GardenFinding struct {
 	RegionPath  string `json:"region_path"`
-	Category    string `json:"category"` // stale_todo, orphaned_region, sync_drift, spec_divergence, stale_docs, duplication
+	Category    string `json:"category"` // stale_todo, orphaned_region, unregistered_region, sync_drift, spec_divergence, stale_docs, duplication, golden_principle
 	Description string `json:"description"`
-	Mechanical  bool   `json:"mechanical"` // can be fixed without human judgment
+	Fix         string `json:"fix,omitempty"`
+	Severity    string `json:"severity,omitempty"` // warn, block (golden_principle findings)
+	Mechanical  bool   `json:"mechanical"`         // can be fixed without human judgment
 }
 
-// RunGardener performs a full entropy sweep and queues fix-up turns.
+// Default gardener staleness windows.
+const (
+	DefaultTodoAge     = 7 * 24 * time.Hour
+	DefaultDriftWindow = 7 * 24 * time.Hour
+)
+
+// SetGardenerWindows sets how old a completed turn's scratchpad TODO must be
+// before it is reported as stale, and how far back flow_log is searched for
+// sync drift. Zero leaves a window unchanged.
+func (m *Memorizer) SetGardenerWindows(todoAge, driftWindow time.Duration) {
+	if todoAge > 0 {
+		m.todoAge = todoAge
+	}
+	if driftWindow > 0 {
+		m.driftWindow = driftWindow
+	}
+}
+
+// RunGardener performs a full entropy sweep. Unless dryRun, it registers
+// source-only regions and queues fix-up turns for other mechanical findings.
 func (m *Memorizer) RunGardener(ctx context.Context, dryRun bool) ([]GardenFinding, error) {
+	findings, err := m.entropyFindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	principles, err := m.RunGoldenPrinciples(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("golden principles: %w", err)
+	}
+	findings = append(findings, principles...)
+
+	if !dryRun {
+		if err := m.applyFindings(ctx, findings); err != nil {
+			return nil, err
+		}
+	}
+
+	return findings, nil
+}
+
+// ScopedGardenFindings runs the gardener's entropy checks, without golden
+// principles or queueing, and keeps the findings within scopePath. It is for
+// callers without a Memorizer, such as the strict turn-end gate. Zero windows
+// use the defaults.
+func ScopedGardenFindings(ctx context.Context, pool *pgxpool.Pool, projectRoot, scopePath string, todoAge, driftWindow time.Duration) ([]GardenFinding, error) {
+	m := &Memorizer{db: pool, projectRoot: projectRoot, todoAge: DefaultTodoAge, driftWindow: DefaultDriftWindow}
+	m.SetGardenerWindows(todoAge, driftWindow)
+
+	findings, err := m.entropyFindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var scoped []GardenFinding
+	for _, f := range findings {
+		if f.RegionPath == scopePath || strings.HasPrefix(f.RegionPath, scopePath+".") {
+			scoped = append(scoped, f)
+		}
+	}
+	return scoped, nil
+}
+
+// entropyFindings runs every gardener check except golden principles.
+func (m *Memorizer) entropyFindings(ctx context.Context) ([]GardenFinding, error) {
 	var findings []GardenFinding
 
 	staleTodos, err := m.findStaleTodos(ctx)
@@ -25,46 +93,82 @@ func (m *Memorizer) RunGardener(ctx context.Context, dryRun bool) ([]GardenFindi
 	}
 	findings = append(findings, staleTodos...)
 
-	orphaned, err := m.findOrphanedRegions(ctx)
+	// Source markers are scanned once for both directions of region drift
+	gamignore := region.ParseGamignore(m.projectRoot)
+	sourceMarkers, _, _ := region.ScanDirectory(m.projectRoot, gamignore)
+	sourceRegions := make(map[string]bool)
+	for _, mk := range sourceMarkers {
+		sourceRegions[mk.Path] = true
+	}
+
+	orphaned, err := m.findOrphanedRegions(ctx, sourceRegions)
 	if err != nil {
 		return nil, fmt.Errorf("orphaned regions: %w", err)
 	}
 	findings = append(findings, orphaned...)
 
+	unregistered, err := m.findUnregisteredRegions(ctx, sourceRegions)
+	if err != nil {
+		return nil, fmt.Errorf("unregistered regions: %w", err)
+	}
+	findings = append(findings, unregistered...)
+
 	syncDrift, err := m.findSyncDrift(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("sync drift: %w", err)
 	}
 	findings = append(findings, syncDrift...)
 
-	if !dryRun {
-		for _, f := range findings {
-			if f.Mechanical {
-				m.queueTask(ctx, f.RegionPath, "gardener", f.Description)
+	return findings, nil
+}
+
+// applyFindings registers unregistered regions directly and queues a
+// gardener task for every other mechanical finding.
+func (m *Memorizer) applyFindings(ctx context.Context, findings []GardenFinding) error {
+	for _, f := range findings {
+		if f.Category == "unregistered_region" {
+			if err := m.registerRegion(ctx, f.RegionPath); err != nil {
+				return fmt.Errorf("register region %s: %w", f.RegionPath, err)
+			}
+			continue
+		}
+		if f.Mechanical {
+			reason := f.Description
+			if f.Fix != "" {
+				reason += "\nFix: " + f.Fix
 			}
+			m.queueTask(ctx, f.RegionPath, "gardener", reason)
 		}
 	}
-
-	return findings, nil
+	return nil
 }
 
+// findStaleTodos reports completed turns whose scratchpad still mentions a
+// TODO after the configured age. A TODO counts as addressed once a later
+// completed turn touches a region under the same scope and leaves no TODO of
+// its own in its scratchpad.
 func (m *Memorizer) findStaleTodos(ctx context.Context) ([]GardenFinding, error) {
 	var findings []GardenFinding
 
 	rows, err := m.db.Query(ctx, `
-		SELECT t.id, t.scratchpad, t.scope_path
+		SELECT t.id, t.scratchpad, t.scope_path::text
 		FROM turns t
 		WHERE t.scratchpad LIKE '%TODO%'
 		  AND t.status = 'COMPLETED'
-		  AND t.completed_at < NOW() - INTERVAL '7 days'
+		  AND t.scope_path IS NOT NULL
+		  AND t.completed_at < NOW() - $1 * INTERVAL '1 second'
 		  AND NOT EXISTS (
 			  SELECT 1 FROM turns t2
 			  JOIN turn_regions tr2 ON tr2.turn_id = t2.id
 			  JOIN regions r2 ON r2.id = tr2.region_id
-			  WHERE r2.path <@ t.scope_path
+			  WHERE r2.path <@ t.scope_path::ltree
+			    AND t2.id <> t.id
+			    AND t2.status = 'COMPLETED'
 			    AND t2.created_at > t.completed_at
+			    AND COALESCE(t2.scratchpad, '') NOT LIKE '%TODO%'
 		  )
-	`)
+		ORDER BY t.completed_at, t.id
+	`, m.todoAge.Seconds())
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +176,9 @@ func (m *Memorizer) findStaleTodos(ctx context.Context) ([]GardenFinding, error)
 
 	for rows.Next() {
 		var turnID, scratchpad, scopePath string
-		rows.Scan(&turnID, &scratchpad, &scopePath)
+		if err := rows.Scan(&turnID, &scratchpad, &scopePath); err != nil {
+			return nil, err
+		}
 		findings = append(findings, GardenFinding{
 			RegionPath:  scopePath,
 			Category:    "stale_todo",
@@ -80,20 +186,12 @@ func (m *Memorizer) findStaleTodos(ctx context.Context) ([]GardenFinding, error)
 			Mechanical:  false,
 		})
 	}
-	return findings, nil
+	return findings, rows.Err()
 }
 
-func (m *Memorizer) findOrphanedRegions(ctx context.Context) ([]GardenFinding, error) {
+func (m *Memorizer) findOrphanedRegions(ctx context.Context, sourceRegions map[string]bool) ([]GardenFinding, error) {
 	var findings []GardenFinding
 
-	// Scan source code for actual region markers
-	gamignore := region.ParseGamignore(m.projectRoot)
-	sourceMarkers, _, _ := region.ScanDirectory(m.projectRoot, gamignore)
-	sourceRegions := make(map[string]bool)
-	for _, mk := range sourceMarkers {
-		sourceRegions[mk.Path] = true
-	}
-
 	// Find DB regions with no source code markers
 	rows, err := m.db.Query(ctx, `
 		SELECT r.path FROM regions r
@@ -119,6 +217,58 @@ func (m *Memorizer) findOrphanedRegions(ctx context.Context) ([]GardenFinding, e
 	return findings, nil
 }
 
+// findUnregisteredRegions reports region markers in source with no regions
+// row. Such regions get no concept validation until they are registered.
+func (m *Memorizer) findUnregisteredRegions(ctx context.Context, sourceRegions map[string]bool) ([]GardenFinding, error) {
+	rows, err := m.db.Query(ctx, `SELECT path::text FROM regions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dbRegions := make(map[string]bool)
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		dbRegions[path] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for path := range sourceRegions {
+		if !dbRegions[path] {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var findings []GardenFinding
+	for _, path := range paths {
+		findings = append(findings, GardenFinding{
+			RegionPath:  path,
+			Category:    "unregistered_region",
+			Description: fmt.Sprintf("Region %s has @region markers in source but no database row, so no concept validation runs for it.", path),
+			Fix:         fmt.Sprintf("Run gam gardener run without --dry to register %s, and add it to arch.md if missing.", path),
+			Mechanical:  true,
+		})
+	}
+	return findings, nil
+}
+
+// registerRegion inserts a draft regions row, as gam region touch does.
+func (m *Memorizer) registerRegion(ctx context.Context, path string) error {
+	_, err := m.db.Exec(ctx, `
+		INSERT INTO regions (path, lifecycle_state)
+		VALUES ($1, 'draft')
+		ON CONFLICT (path) DO NOTHING
+	`, path)
+	return err
+}
+
 func (m *Memorizer) findSyncDrift(ctx context.Context) ([]GardenFinding, error) {
 	var findings []GardenFinding
 
@@ -131,14 +281,14 @@ func (m *Memorizer) findSyncDrift(ctx context.Context) ([]GardenFinding, error)
 			  SELECT 1 FROM flow_log fl
 			  WHERE fl.concept_name = sr.concept_name
 				AND fl.action_name = sr.action_name
-				AND fl.created_at > NOW() - INTERVAL '7 days'
+				AND fl.created_at > NOW() - $1 * INTERVAL '1 second'
 		  )
 		  AND NOT EXISTS (
 			  SELECT 1 FROM flow_log fl
 			  WHERE fl.sync_name = s.name
-				AND fl.created_at > NOW() - INTERVAL '7 days'
+				AND fl.created_at > NOW() - $1 * INTERVAL '1 second'
 		  )
-	`)
+	`, m.driftWindow.Seconds())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,158 @@
+package memorizer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestFindStaleTodosRespectsWindow(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	const window = 72 * time.Hour
+	turns := []struct {
+		id    string
+		scope string
+		age   time.Duration
+	}{
+		{"gardentest-under", "gardentest.under", window - time.Hour},
+		{"gardentest-over", "gardentest.over", window + time.Hour},
+	}
+	for _, tt := range turns {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO turns (id, agent_role, scope_path, status, scratchpad, created_at, completed_at)
+			VALUES ($1, 'implementer', $2, 'COMPLETED', 'TODO: finish this', $3, $3)
+		`, tt.id, tt.scope, time.Now().Add(-tt.age)); err != nil {
+			t.Fatalf("insert turn: %v", err)
+		}
+		defer pool.Exec(ctx, `DELETE FROM turns WHERE id = $1`, tt.id)
+	}
+
+	m := &Memorizer{db: pool}
+	m.SetGardenerWindows(window, 0)
+	findings, err := m.findStaleTodos(ctx)
+	if err != nil {
+		t.Fatalf("findStaleTodos: %v", err)
+	}
+
+	flagged := make(map[string]bool)
+	for _, f := range findings {
+		if strings.HasPrefix(f.RegionPath, "gardentest.") {
+			flagged[f.RegionPath] = true
+		}
+	}
+	if flagged["gardentest.under"] {
+		t.Error("TODO just under the window was flagged")
+	}
+	if !flagged["gardentest.over"] {
+		t.Error("TODO just over the window was not flagged")
+	}
+}
+
+func TestFindStaleTodosAddressedByLaterTurn(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	regionIDs := make(map[string]string)
+	for _, path := range []string{"todotest.stale", "todotest.fixed", "todotest.carried"} {
+		var id string
+		if err := pool.QueryRow(ctx, `
+			INSERT INTO regions (path) VALUES ($1)
+			ON CONFLICT (path) DO UPDATE SET updated_at = NOW()
+			RETURNING id
+		`, path).Scan(&id); err != nil {
+			t.Fatalf("insert region %s: %v", path, err)
+		}
+		regionIDs[path] = id
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'todotest'`)
+
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	turns := []struct {
+		id, scope, scratchpad string
+		at                    time.Time
+	}{
+		{"todotest-stale", "todotest.stale", "TODO: add retries", old},
+		{"todotest-fixed", "todotest.fixed", "TODO: handle empty input", old},
+		{"todotest-fixed-followup", "todotest.fixed", "Handled empty input.", recent},
+		{"todotest-carried", "todotest.carried", "TODO: paginate", old},
+		{"todotest-carried-followup", "todotest.carried", "Partial fix. TODO: paginate", recent},
+	}
+	for _, tt := range turns {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO turns (id, agent_role, scope_path, status, scratchpad, created_at, completed_at)
+			VALUES ($1, 'implementer', $2, 'COMPLETED', $3, $4, $4)
+		`, tt.id, tt.scope, tt.scratchpad, tt.at); err != nil {
+			t.Fatalf("insert turn: %v", err)
+		}
+		defer pool.Exec(ctx, `DELETE FROM turns WHERE id = $1`, tt.id)
+		pool.Exec(ctx, `INSERT INTO turn_regions (turn_id, region_id, action) VALUES ($1, $2, 'modified')`, tt.id, regionIDs[tt.scope])
+		defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = $1`, tt.id)
+	}
+
+	m := &Memorizer{db: pool, todoAge: DefaultTodoAge}
+	findings, err := m.findStaleTodos(ctx)
+	if err != nil {
+		t.Fatalf("findStaleTodos: %v", err)
+	}
+
+	var flagged []string
+	for _, f := range findings {
+		if strings.HasPrefix(f.RegionPath, "todotest.") {
+			flagged = append(flagged, f.RegionPath)
+		}
+	}
+	want := "todotest.carried,todotest.stale"
+	if got := strings.Join(flagged, ","); got != want {
+		t.Errorf("flagged %s, want %s", got, want)
+	}
+}
+
+func TestUnregisteredRegionFoundAndRegistered(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "billing.go"),
+		[]byte("// @region:unregtest.billing\npackage billing\n// @endregion:unregtest.billing\n"), 0644)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'unregtest'`)
+
+	markers, _, _ := region.ScanDirectory(root, nil)
+	sourceRegions := make(map[string]bool)
+	for _, mk := range markers {
+		sourceRegions[mk.Path] = true
+	}
+
+	m := &Memorizer{db: pool, projectRoot: root}
+	findings, err := m.findUnregisteredRegions(ctx, sourceRegions)
+	if err != nil {
+		t.Fatalf("findUnregisteredRegions: %v", err)
+	}
+	if len(findings) != 1 || findings[0].RegionPath != "unregtest.billing" ||
+		findings[0].Category != "unregistered_region" || !findings[0].Mechanical {
+		t.Fatalf("findings = %+v, want one mechanical unregistered_region for unregtest.billing", findings)
+	}
+
+	if err := m.applyFindings(ctx, findings); err != nil {
+		t.Fatalf("applyFindings: %v", err)
+	}
+	var lifecycle string
+	if err := pool.QueryRow(ctx, `SELECT lifecycle_state FROM regions WHERE path = 'unregtest.billing'`).Scan(&lifecycle); err != nil {
+		t.Fatalf("region not inserted: %v", err)
+	}
+	if lifecycle != "draft" {
+		t.Errorf("lifecycle = %q, want draft", lifecycle)
+	}
+
+	if findings, _ := m.findUnregisteredRegions(ctx, sourceRegions); len(findings) != 0 {
+		t.Errorf("still unregistered after apply: %+v", findings)
+	}
+}
@@ -0,0 +1,141 @@
+package memorizer
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// gradeLetters maps grade points back to letters, indexed by points.
+var gradeLetters = []string{"F", "D", "C", "B", "A"}
+
+// GradePoints converts a letter grade (A-F, with optional +/- which is
+// ignored) to grade points on a 4.0 scale.
+func GradePoints(grade string) (float64, bool) {
+	g := strings.ToUpper(strings.TrimSpace(grade))
+	if g == "" {
+		return 0, false
+	}
+	switch g[0] {
+	case 'A':
+		return 4, true
+	case 'B':
+		return 3, true
+	case 'C':
+		return 2, true
+	case 'D':
+		return 1, true
+	case 'F':
+		return 0, true
+	}
+	return 0, false
+}
+
+// AggregateGrades averages letter grades by grade points and rounds to the
+// nearest letter. Unrecognized grades are ignored; ok is false when none of
+// the grades were recognized.
+func AggregateGrades(grades []string) (string, bool) {
+	var total float64
+	n := 0
+	for _, g := range grades {
+		if p, ok := GradePoints(g); ok {
+			total += p
+			n++
+		}
+	}
+	if n == 0 {
+		return "", false
+	}
+	return gradeLetters[int(math.Round(total/float64(n)))], true
+}
+
+// GradeRollup is an aggregate of quality grades across a set of regions.
+type GradeRollup struct {
+	Overall    string            // aggregate of every grade, "" when ungraded
+	Categories map[string]string // aggregate per category
+	Regions    int               // distinct regions with at least one grade
+}
+
+// ComputePlanGrade aggregates the quality grades of the regions a plan
+// touched (its plan turn scopes and the regions its turns modified).
+func (m *Memorizer) ComputePlanGrade(ctx context.Context, planID string) (string, error) {
+	return PlanGrade(ctx, m.db, planID)
+}
+
+// PlanGrade is ComputePlanGrade for callers without a Memorizer.
+func PlanGrade(ctx context.Context, pool *pgxpool.Pool, planID string) (string, error) {
+	rollup, err := queryRollup(ctx, pool, `
+		SELECT r.path::text, qg.category, qg.grade
+		FROM quality_grades qg
+		JOIN regions r ON r.id = qg.region_id
+		WHERE r.path IN (SELECT region_path FROM plan_turns WHERE plan_id = $1)
+		   OR r.id IN (
+			   SELECT tr.region_id FROM turn_regions tr
+			   JOIN plan_turns pt ON pt.turn_id = tr.turn_id
+			   WHERE pt.plan_id = $1
+		   )
+	`, planID)
+	if err != nil {
+		return "", err
+	}
+	return rollup.Overall, nil
+}
+
+// SubtreeRollup aggregates quality grades for regionPath and every region
+// beneath it, or for all regions when regionPath is empty.
+func SubtreeRollup(ctx context.Context, pool *pgxpool.Pool, regionPath string) (GradeRollup, error) {
+	return queryRollup(ctx, pool, `
+		SELECT r.path::text, qg.category, qg.grade
+		FROM quality_grades qg
+		JOIN regions r ON r.id = qg.region_id
+		WHERE $1 = '' OR r.path <@ $1::ltree
+	`, regionPath)
+}
+
+func queryRollup(ctx context.Context, pool *pgxpool.Pool, query string, args ...any) (GradeRollup, error) {
+	rollup := GradeRollup{Categories: make(map[string]string)}
+
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return rollup, err
+	}
+	defer rows.Close()
+
+	var all []string
+	byCategory := make(map[string][]string)
+	regions := make(map[string]bool)
+	for rows.Next() {
+		var path, category, grade string
+		if err := rows.Scan(&path, &category, &grade); err != nil {
+			return rollup, err
+		}
+		all = append(all, grade)
+		byCategory[category] = append(byCategory[category], grade)
+		regions[path] = true
+	}
+	if err := rows.Err(); err != nil {
+		return rollup, err
+	}
+
+	rollup.Overall, _ = AggregateGrades(all)
+	for category, grades := range byCategory {
+		if g, ok := AggregateGrades(grades); ok {
+			rollup.Categories[category] = g
+		}
+	}
+	rollup.Regions = len(regions)
+	return rollup, nil
+}
+
+// SortedCategories returns the rollup's category names in order.
+func (r GradeRollup) SortedCategories() []string {
+	cats := make([]string, 0, len(r.Categories))
+	for c := range r.Categories {
+		cats = append(cats, c)
+	}
+	sort.Strings(cats)
+	return cats
+}
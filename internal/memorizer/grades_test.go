@@ -0,0 +1,88 @@
+package memorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestAggregateGrades(t *testing.T) {
+	tests := []struct {
+		grades []string
+		want   string
+		ok     bool
+	}{
+		{[]string{"A", "A"}, "A", true},
+		{[]string{"A", "C"}, "B", true},
+		{[]string{"A", "B"}, "A", true},
+		{[]string{"B-", "c+"}, "B", true},
+		{[]string{"F", "D", "unknown"}, "D", true},
+		{[]string{"?"}, "", false},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := AggregateGrades(tt.grades)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("AggregateGrades(%v) = %q, %v; want %q, %v", tt.grades, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestComputePlanGrade(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	regionIDs := make(map[string]string)
+	for _, path := range []string{"gradetest.scoped", "gradetest.modified", "gradetest.untouched"} {
+		var id string
+		if err := pool.QueryRow(ctx, `
+			INSERT INTO regions (path) VALUES ($1) ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path RETURNING id
+		`, path).Scan(&id); err != nil {
+			t.Fatalf("insert region %s: %v", path, err)
+		}
+		regionIDs[path] = id
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'gradetest'`)
+
+	grades := map[string]string{"gradetest.scoped": "A", "gradetest.modified": "C", "gradetest.untouched": "F"}
+	for path, grade := range grades {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO quality_grades (region_id, category, grade) VALUES ($1, 'tests', $2)
+			ON CONFLICT (region_id, category) DO UPDATE SET grade = $2
+		`, regionIDs[path], grade); err != nil {
+			t.Fatalf("insert grade: %v", err)
+		}
+	}
+
+	var planID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO execution_plans (name, goal, status) VALUES ('grade-plan', 'test', 'ACTIVE') RETURNING id
+	`).Scan(&planID); err != nil {
+		t.Fatalf("insert plan: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM execution_plans WHERE id = $1`, planID)
+
+	turnID := GenerateTurnID() + "g"
+	pool.Exec(ctx, `INSERT INTO turns (id, agent_role, scope_path, status, plan_id) VALUES ($1, 'implementer', 'gradetest', 'COMPLETED', $2)`, turnID, planID)
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = $1`, turnID)
+	pool.Exec(ctx, `INSERT INTO plan_turns (plan_id, turn_id, region_path, ordering, status) VALUES ($1, $2, 'gradetest.scoped', 0, 'completed')`, planID, turnID)
+	pool.Exec(ctx, `INSERT INTO turn_regions (turn_id, region_id, action) VALUES ($1, $2, 'modified')`, turnID, regionIDs["gradetest.modified"])
+	defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = $1`, turnID)
+
+	got, err := PlanGrade(ctx, pool, planID)
+	if err != nil {
+		t.Fatalf("PlanGrade: %v", err)
+	}
+	if got != "B" {
+		t.Errorf("plan grade = %q, want B (average of A and C, untouched region excluded)", got)
+	}
+
+	rollup, err := SubtreeRollup(ctx, pool, "gradetest")
+	if err != nil {
+		t.Fatalf("SubtreeRollup: %v", err)
+	}
+	if rollup.Regions != 3 || rollup.Overall != "C" || rollup.Categories["tests"] != "C" {
+		t.Errorf("rollup = %+v, want 3 regions graded C", rollup)
+	}
+}
@@ -0,0 +1,119 @@
+package memorizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RegionHistoryEntry is one turn's recorded action on a region. turn_regions
+// carries no timestamp, so At is when the turn completed, or when it started
+// if it is still open.
+type RegionHistoryEntry struct {
+	Region    string    `json:"region"`
+	Action    string    `json:"action"`
+	TurnID    string    `json:"turn_id"`
+	AgentRole string    `json:"agent_role"`
+	Status    string    `json:"status"`
+	At        time.Time `json:"at"`
+}
+
+// RegionHistory returns the turn_regions entries for regionPath and its
+// descendants in chronological order. A zero since includes all history;
+// limit > 0 keeps only the most recent entries.
+func RegionHistory(ctx context.Context, pool *pgxpool.Pool, regionPath string, since time.Time, limit int) ([]RegionHistoryEntry, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT * FROM (
+			SELECT r.path::text, tr.action, t.id, COALESCE(t.agent_role, ''), t.status::text,
+			       COALESCE(t.completed_at, t.created_at) AS at
+			FROM turn_regions tr
+			JOIN turns t ON t.id = tr.turn_id
+			JOIN regions r ON r.id = tr.region_id
+			WHERE r.path <@ $1::ltree
+			  AND ($2::timestamptz IS NULL OR COALESCE(t.completed_at, t.created_at) >= $2)
+			ORDER BY at DESC, t.id DESC, r.path DESC
+			LIMIT NULLIF($3, 0)
+		) h ORDER BY at, id, path
+	`, regionPath, nullTime(since), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []RegionHistoryEntry
+	for rows.Next() {
+		var e RegionHistoryEntry
+		if err := rows.Scan(&e.Region, &e.Action, &e.TurnID, &e.AgentRole, &e.Status, &e.At); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// ScratchpadExport is one completed turn's scratchpad with the regions it
+// touched, as written by gam memory export.
+type ScratchpadExport struct {
+	TurnID      string    `json:"turn_id"`
+	Scope       string    `json:"scope_path"`
+	AgentRole   string    `json:"agent_role"`
+	CompletedAt time.Time `json:"completed_at"`
+	Regions     []string  `json:"regions"`
+	Scratchpad  string    `json:"scratchpad"`
+}
+
+// ExportScratchpads returns every completed turn with a non-empty scratchpad,
+// oldest first. A non-empty regionPath keeps turns scoped to or touching that
+// subtree; zero since and until leave the completion range open.
+func ExportScratchpads(ctx context.Context, pool *pgxpool.Pool, regionPath string, since, until time.Time) ([]ScratchpadExport, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT t.id, COALESCE(t.scope_path::text, ''), COALESCE(t.agent_role, ''), t.completed_at,
+		       COALESCE(ARRAY(
+		           SELECT r.path::text FROM turn_regions tr
+		           JOIN regions r ON r.id = tr.region_id
+		           WHERE tr.turn_id = t.id ORDER BY r.path
+		       ), '{}'),
+		       t.scratchpad
+		FROM turns t
+		WHERE t.status = 'COMPLETED'
+		  AND t.completed_at IS NOT NULL
+		  AND COALESCE(t.scratchpad, '') != ''
+		  AND ($1::ltree IS NULL OR t.scope_path <@ $1::ltree OR EXISTS (
+		      SELECT 1 FROM turn_regions tr
+		      JOIN regions r ON r.id = tr.region_id
+		      WHERE tr.turn_id = t.id AND r.path <@ $1::ltree
+		  ))
+		  AND ($2::timestamptz IS NULL OR t.completed_at >= $2)
+		  AND ($3::timestamptz IS NULL OR t.completed_at < $3)
+		ORDER BY t.completed_at, t.id
+	`, nullString(regionPath), nullTime(since), nullTime(until))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ScratchpadExport
+	for rows.Next() {
+		var e ScratchpadExport
+		if err := rows.Scan(&e.TurnID, &e.Scope, &e.AgentRole, &e.CompletedAt, &e.Regions, &e.Scratchpad); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
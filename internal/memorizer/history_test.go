@@ -0,0 +1,84 @@
+package memorizer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestRegionHistory(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	regionIDs := make(map[string]string)
+	for _, path := range []string{"histtest", "histtest.child", "histother"} {
+		var id string
+		if err := pool.QueryRow(ctx, `
+			INSERT INTO regions (path) VALUES ($1) ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path RETURNING id
+		`, path).Scan(&id); err != nil {
+			t.Fatalf("insert region %s: %v", path, err)
+		}
+		regionIDs[path] = id
+	}
+
+	base := time.Now().Add(-72 * time.Hour).UTC().Truncate(time.Second)
+	seed := []struct {
+		region, action, role string
+		at                   time.Time
+	}{
+		{"histtest", "created", "implementer", base},
+		{"histtest.child", "created", "implementer", base.Add(time.Hour)},
+		{"histtest", "modified", "gardener", base.Add(48 * time.Hour)},
+		{"histother", "modified", "implementer", base.Add(2 * time.Hour)},
+	}
+	var turnIDs []string
+	for i, s := range seed {
+		turnID := GenerateTurnID() + string(rune('a'+i))
+		turnIDs = append(turnIDs, turnID)
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO turns (id, agent_role, scope_path, status, created_at, completed_at)
+			VALUES ($1, $2, $3, 'COMPLETED', $4, $4)
+		`, turnID, s.role, s.region, s.at); err != nil {
+			t.Fatalf("insert turn: %v", err)
+		}
+		pool.Exec(ctx, `INSERT INTO turn_regions (turn_id, region_id, action) VALUES ($1, $2, $3)`, turnID, regionIDs[s.region], s.action)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'histtest' OR path <@ 'histother'`)
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = ANY($1)`, turnIDs)
+	defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = ANY($1)`, turnIDs)
+
+	entries, err := RegionHistory(ctx, pool, "histtest", time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("RegionHistory: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3 (descendants included, sibling excluded): %+v", len(entries), entries)
+	}
+	wantOrder := []string{"histtest/created", "histtest.child/created", "histtest/modified"}
+	for i, e := range entries {
+		if got := e.Region + "/" + e.Action; got != wantOrder[i] {
+			t.Errorf("entry %d = %s, want %s", i, got, wantOrder[i])
+		}
+	}
+	if entries[2].AgentRole != "gardener" || entries[2].TurnID != turnIDs[2] {
+		t.Errorf("last entry = %+v, want gardener turn %s", entries[2], turnIDs[2])
+	}
+
+	limited, err := RegionHistory(ctx, pool, "histtest", time.Time{}, 2)
+	if err != nil {
+		t.Fatalf("RegionHistory limit: %v", err)
+	}
+	if len(limited) != 2 || limited[0].Region != "histtest.child" || limited[1].Action != "modified" {
+		t.Errorf("limit 2 should keep the two most recent in order: %+v", limited)
+	}
+
+	recent, err := RegionHistory(ctx, pool, "histtest", base.Add(24*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("RegionHistory since: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Action != "modified" {
+		t.Errorf("since filter = %+v, want only the modified entry", recent)
+	}
+}
@@ -0,0 +1,242 @@
+package memorizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/sbenjam1n/gamsync/internal/retry"
+)
+
+// Lifecycle events that hooks can subscribe to.
+const (
+	EventTurnStart        = "turn_start"
+	EventTurnEnd          = "turn_end"
+	EventProposalApproved = "proposal_approved"
+)
+
+// HookEvents lists every lifecycle event that fires hooks.
+var HookEvents = []string{EventTurnStart, EventTurnEnd, EventProposalApproved}
+
+// HookContext is the payload handed to a hook handler. The exec handler
+// writes it to the command's stdin as JSON.
+type HookContext struct {
+	Event      string `json:"event"`
+	TurnID     string `json:"turn_id,omitempty"`
+	RegionPath string `json:"region_path,omitempty"`
+	ProposalID string `json:"proposal_id,omitempty"`
+	Scratchpad string `json:"scratchpad,omitempty"`
+}
+
+// HookHandler executes a lifecycle hook.
+type HookHandler func(ctx context.Context, hook gam.LifecycleHook, hc HookContext) error
+
+// HookRegistry loads enabled lifecycle hooks and dispatches them to handlers
+// by the hook's handler name.
+type HookRegistry struct {
+	db          *pgxpool.Pool
+	projectRoot string
+	handlers    map[string]HookHandler
+	retry       retry.Policy // exec handler retries; see retry.Exec
+}
+
+// NewHookRegistry creates a registry with the built-in exec handler registered.
+func NewHookRegistry(db *pgxpool.Pool, projectRoot string) *HookRegistry {
+	r := &HookRegistry{
+		db:          db,
+		projectRoot: projectRoot,
+		handlers:    make(map[string]HookHandler),
+		retry:       retry.DefaultPolicy,
+	}
+	r.Register("exec", r.execHandler)
+	return r
+}
+
+// Register adds or replaces the handler for a handler name.
+func (r *HookRegistry) Register(name string, h HookHandler) {
+	r.handlers[name] = h
+}
+
+// Fire loads the enabled hooks for hc.Event and runs those in scope.
+func (r *HookRegistry) Fire(ctx context.Context, hc HookContext) error {
+	hooks, err := r.Load(ctx, hc.Event)
+	if err != nil {
+		return fmt.Errorf("load %s hooks: %w", hc.Event, err)
+	}
+	return r.Run(ctx, hooks, hc)
+}
+
+// Load returns the enabled hooks registered for an event.
+func (r *HookRegistry) Load(ctx context.Context, event string) ([]gam.LifecycleHook, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, event, hook_name, priority, handler, config, enabled, scope
+		FROM lifecycle_hooks
+		WHERE event = $1 AND enabled = true
+		ORDER BY priority, hook_name
+	`, event)
+	if err != nil {
+		return nil, err
+	}
+	return scanHooks(rows)
+}
+
+// Run invokes, in ascending priority order, every hook whose scope covers
+// hc.RegionPath. A hook with no scope always runs. Every hook runs even if an
+// earlier one fails; the failures are returned together.
+func (r *HookRegistry) Run(ctx context.Context, hooks []gam.LifecycleHook, hc HookContext) error {
+	ordered := make([]gam.LifecycleHook, 0, len(hooks))
+	for _, h := range hooks {
+		if hookInScope(h.Scope, hc.RegionPath) {
+			ordered = append(ordered, h)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority < ordered[j].Priority
+	})
+
+	var errs []error
+	for _, h := range ordered {
+		handler, ok := r.handlers[h.Handler]
+		if !ok {
+			errs = append(errs, fmt.Errorf("hook %s: unknown handler %q", h.HookName, h.Handler))
+			continue
+		}
+		if err := handler(ctx, h, hc); err != nil {
+			errs = append(errs, fmt.Errorf("hook %s: %w", h.HookName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// execHandler runs the hook's configured "command" through sh with the hook
+// context as JSON on stdin. A command that exits with retry.ExitTempFail or
+// fails to start is retried with backoff; other non-zero exits fail at once.
+func (r *HookRegistry) execHandler(ctx context.Context, hook gam.LifecycleHook, hc HookContext) error {
+	config, _ := hook.Config.(map[string]any)
+	command, _ := config["command"].(string)
+	if command == "" {
+		return fmt.Errorf("exec handler requires a \"command\" in config")
+	}
+
+	payload, _ := json.Marshal(hc)
+	return retry.Do(ctx, r.retry, func(ctx context.Context) error {
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Dir = r.projectRoot
+		cmd.Stdin = bytes.NewReader(payload)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return retry.Exec(fmt.Errorf("%s: %w\n%s", command, err, strings.TrimSpace(string(out))))
+		}
+		return nil
+	})
+}
+
+// hookInScope reports whether regionPath equals scope or lies beneath it.
+func hookInScope(scope, regionPath string) bool {
+	if scope == "" {
+		return true
+	}
+	return regionPath == scope || strings.HasPrefix(regionPath, scope+".")
+}
+
+// ValidateHook checks that a hook names a known event, has a name and handler,
+// and that its scope, if set, is a valid namespace path.
+func ValidateHook(h gam.LifecycleHook) error {
+	known := false
+	for _, e := range HookEvents {
+		if h.Event == e {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown event %q (valid: %s)", h.Event, strings.Join(HookEvents, ", "))
+	}
+	if h.HookName == "" {
+		return fmt.Errorf("hook name is required")
+	}
+	if h.Handler == "" {
+		return fmt.Errorf("hook handler is required")
+	}
+	if h.Scope != "" && !region.IsValidNamespace(h.Scope) {
+		return fmt.Errorf("invalid scope %q: must be a dot-separated namespace path", h.Scope)
+	}
+	return nil
+}
+
+// AddHook validates and upserts a lifecycle hook keyed by (event, hook_name).
+func (r *HookRegistry) AddHook(ctx context.Context, h gam.LifecycleHook) error {
+	if err := ValidateHook(h); err != nil {
+		return err
+	}
+	configJSON, _ := json.Marshal(h.Config)
+	var scope *string
+	if h.Scope != "" {
+		scope = &h.Scope
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO lifecycle_hooks (event, hook_name, priority, handler, config, enabled, scope)
+		VALUES ($1, $2, $3, $4, $5, true, $6::ltree)
+		ON CONFLICT (event, hook_name) DO UPDATE
+		SET priority = $3, handler = $4, config = $5, enabled = true, scope = $6::ltree
+	`, h.Event, h.HookName, h.Priority, h.Handler, configJSON, scope)
+	return err
+}
+
+// ListHooks returns all hooks, enabled or not, optionally for a single event.
+func (r *HookRegistry) ListHooks(ctx context.Context, event string) ([]gam.LifecycleHook, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, event, hook_name, priority, handler, config, enabled, scope
+		FROM lifecycle_hooks
+		WHERE $1 = '' OR event = $1
+		ORDER BY event, priority, hook_name
+	`, event)
+	if err != nil {
+		return nil, err
+	}
+	return scanHooks(rows)
+}
+
+// RemoveHook deletes hooks by name, limited to one event when event is set.
+// It returns the number of hooks removed.
+func (r *HookRegistry) RemoveHook(ctx context.Context, name, event string) (int64, error) {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM lifecycle_hooks WHERE hook_name = $1 AND ($2 = '' OR event = $2)
+	`, name, event)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanHooks(rows pgx.Rows) ([]gam.LifecycleHook, error) {
+	defer rows.Close()
+
+	var hooks []gam.LifecycleHook
+	for rows.Next() {
+		var h gam.LifecycleHook
+		var configJSON []byte
+		var scope *string
+		if err := rows.Scan(&h.ID, &h.Event, &h.HookName, &h.Priority, &h.Handler, &configJSON, &h.Enabled, &scope); err != nil {
+			return nil, err
+		}
+		if configJSON != nil {
+			var config map[string]any
+			json.Unmarshal(configJSON, &config)
+			h.Config = config
+		}
+		if scope != nil {
+			h.Scope = *scope
+		}
+		hooks = append(hooks, h)
+	}
+	return hooks, rows.Err()
+}
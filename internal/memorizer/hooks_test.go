@@ -0,0 +1,138 @@
+package memorizer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestHookRegistryRunOrderAndScope(t *testing.T) {
+	r := NewHookRegistry(nil, t.TempDir())
+
+	var fired []string
+	r.Register("fake", func(ctx context.Context, hook gam.LifecycleHook, hc HookContext) error {
+		fired = append(fired, hook.HookName)
+		return nil
+	})
+
+	hooks := []gam.LifecycleHook{
+		{HookName: "late", Priority: 200, Handler: "fake"},
+		{HookName: "billing-only", Priority: 10, Handler: "fake", Scope: "app.billing"},
+		{HookName: "search-subtree", Priority: 50, Handler: "fake", Scope: "app.search"},
+		{HookName: "early", Priority: 1, Handler: "fake"},
+		{HookName: "search-prefix-lookalike", Priority: 60, Handler: "fake", Scope: "app.sea"},
+	}
+
+	err := r.Run(context.Background(), hooks, HookContext{Event: EventTurnStart, RegionPath: "app.search.sources"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := []string{"early", "search-subtree", "late"}
+	if !reflect.DeepEqual(fired, want) {
+		t.Errorf("fired = %v, want %v", fired, want)
+	}
+}
+
+func TestHookRegistryExecHandler(t *testing.T) {
+	dir := t.TempDir()
+	r := NewHookRegistry(nil, dir)
+
+	hooks := []gam.LifecycleHook{
+		{HookName: "capture", Handler: "exec", Config: map[string]any{"command": "cat > payload.json"}},
+		{HookName: "broken", Handler: "exec", Config: map[string]any{"command": "exit 3"}},
+		{HookName: "missing", Handler: "nope"},
+	}
+
+	err := r.Run(context.Background(), hooks, HookContext{Event: EventTurnEnd, TurnID: "T_1", RegionPath: "app"})
+	if err == nil {
+		t.Fatal("expected errors from broken and missing hooks")
+	}
+
+	data, readErr := os.ReadFile(filepath.Join(dir, "payload.json"))
+	if readErr != nil {
+		t.Fatalf("exec hook did not run: %v", readErr)
+	}
+	if string(data) != `{"event":"turn_end","turn_id":"T_1","region_path":"app"}` {
+		t.Errorf("unexpected stdin payload: %s", data)
+	}
+}
+
+func TestValidateHook(t *testing.T) {
+	valid := gam.LifecycleHook{Event: EventTurnEnd, HookName: "lint", Handler: "exec", Scope: "app.search"}
+	if err := ValidateHook(valid); err != nil {
+		t.Fatalf("ValidateHook(valid): %v", err)
+	}
+
+	tests := []struct {
+		name string
+		edit func(h *gam.LifecycleHook)
+	}{
+		{"unknown event", func(h *gam.LifecycleHook) { h.Event = "turn_middle" }},
+		{"missing name", func(h *gam.LifecycleHook) { h.HookName = "" }},
+		{"missing handler", func(h *gam.LifecycleHook) { h.Handler = "" }},
+		{"bad scope", func(h *gam.LifecycleHook) { h.Scope = "app..search" }},
+	}
+	for _, tt := range tests {
+		h := valid
+		tt.edit(&h)
+		if err := ValidateHook(h); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestHookRegistryAddListRemove(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+	r := NewHookRegistry(pool, t.TempDir())
+
+	pool.Exec(ctx, `DELETE FROM lifecycle_hooks WHERE hook_name = 'test-hook'`)
+	defer pool.Exec(ctx, `DELETE FROM lifecycle_hooks WHERE hook_name = 'test-hook'`)
+
+	hook := gam.LifecycleHook{
+		Event:    EventTurnEnd,
+		HookName: "test-hook",
+		Priority: 5,
+		Handler:  "exec",
+		Config:   map[string]any{"command": "true"},
+		Scope:    "app.search",
+	}
+	if err := r.AddHook(ctx, hook); err != nil {
+		t.Fatalf("AddHook: %v", err)
+	}
+	hook.Priority = 7
+	if err := r.AddHook(ctx, hook); err != nil {
+		t.Fatalf("AddHook (update): %v", err)
+	}
+
+	hooks, err := r.ListHooks(ctx, EventTurnEnd)
+	if err != nil {
+		t.Fatalf("ListHooks: %v", err)
+	}
+	var found *gam.LifecycleHook
+	for i := range hooks {
+		if hooks[i].HookName == "test-hook" {
+			found = &hooks[i]
+		}
+	}
+	if found == nil {
+		t.Fatal("test-hook not listed")
+	}
+	if found.Priority != 7 || found.Scope != "app.search" || !found.Enabled {
+		t.Errorf("listed hook = %+v", *found)
+	}
+
+	n, err := r.RemoveHook(ctx, "test-hook", "")
+	if err != nil {
+		t.Fatalf("RemoveHook: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("removed %d hooks, want 1", n)
+	}
+}
@@ -0,0 +1,201 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sbenjam1n/gamsync/internal/db"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+// ImportResult is the outcome of importing one spec file.
+type ImportResult struct {
+	File string
+	Name string
+	Err  error
+}
+
+// ParseConcept decodes a concept from either a full concept JSON document or a
+// bare ConceptSpec. name is used unless the document sets its own.
+func ParseConcept(data []byte, name string) (gam.Concept, error) {
+	concept := gam.Concept{Name: name}
+
+	// Try parsing as full concept JSON
+	if err := json.Unmarshal(data, &concept); err != nil {
+		// Try parsing as just ConceptSpec
+		if err := json.Unmarshal(data, &concept.Spec); err != nil {
+			return concept, fmt.Errorf("parse spec: %w (expected JSON with concept spec fields)", err)
+		}
+	}
+	return concept, nil
+}
+
+// UpsertConcept inserts a concept or replaces the stored definition of an
+// existing one with the same name. When the replacement changes the
+// definition, the prior one is kept in concept_versions.
+func UpsertConcept(ctx context.Context, exec db.Execer, concept gam.Concept) error {
+	if concept.Purpose == "" {
+		return fmt.Errorf("concept %s has no purpose", concept.Name)
+	}
+
+	specJSON, _ := json.Marshal(concept.Spec)
+	smJSON, _ := json.Marshal(concept.StateMachine)
+	invJSON, _ := json.Marshal(concept.Invariants)
+
+	// Both statements see the row as it was before the upsert.
+	_, err := exec.Exec(ctx, `
+		WITH snapshot AS (
+			INSERT INTO concept_versions (concept_name, version, purpose, spec, state_machine, invariants, valid_from)
+			SELECT c.name,
+			       COALESCE((SELECT MAX(v.version) FROM concept_versions v WHERE v.concept_name = c.name), 0) + 1,
+			       c.purpose, c.spec, c.state_machine, c.invariants, c.updated_at
+			FROM concepts c
+			WHERE c.name = $1
+			  AND (c.purpose, c.spec, c.state_machine, c.invariants)
+			      IS DISTINCT FROM ($2::text, $3::jsonb, $4::jsonb, $5::jsonb)
+		)
+		INSERT INTO concepts (name, purpose, spec, state_machine, invariants)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (name) DO UPDATE
+		SET purpose = $2, spec = $3, state_machine = $4, invariants = $5, updated_at = NOW()
+	`, concept.Name, concept.Purpose, specJSON, smJSON, invJSON)
+	return err
+}
+
+// ImportConcepts upserts every *.json concept spec in dir, using the file name
+// stem as the concept name when the spec does not set one. Failures are
+// recorded per file and do not stop the import.
+func ImportConcepts(ctx context.Context, exec db.Execer, dir string) ([]ImportResult, error) {
+	files, err := jsonFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ImportResult
+	for _, file := range files {
+		res := ImportResult{File: file, Name: strings.TrimSuffix(filepath.Base(file), ".json")}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			res.Err = err
+			results = append(results, res)
+			continue
+		}
+		concept, err := ParseConcept(data, res.Name)
+		if err == nil {
+			res.Name = concept.Name
+			err = UpsertConcept(ctx, exec, concept)
+		}
+		res.Err = err
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// UpsertSync inserts or replaces a synchronization and rebuilds its
+// sync_refs index. Run it inside a transaction to keep both in step.
+func UpsertSync(ctx context.Context, exec db.Execer, sc gam.Synchronization) error {
+	whenJSON, _ := json.Marshal(sc.WhenClause)
+	whereJSON, _ := json.Marshal(sc.WhereClause)
+	thenJSON, _ := json.Marshal(sc.ThenClause)
+
+	_, err := exec.Exec(ctx, `
+		INSERT INTO synchronizations (name, when_clause, where_clause, then_clause, description, enabled)
+		VALUES ($1, $2, $3, $4, $5, true)
+		ON CONFLICT (name) DO UPDATE
+		SET when_clause = $2, where_clause = $3, then_clause = $4,
+		    description = $5, updated_at = NOW()
+	`, sc.Name, whenJSON, whereJSON, thenJSON, sc.Description)
+	if err != nil {
+		return fmt.Errorf("insert sync: %w", err)
+	}
+
+	if err := db.BuildSyncRefs(ctx, exec, sc); err != nil {
+		return fmt.Errorf("index sync refs: %w", err)
+	}
+	return nil
+}
+
+// UnknownConcepts returns the concepts referenced by sc that are not in known,
+// sorted and without duplicates.
+func UnknownConcepts(sc gam.Synchronization, known map[string]bool) []string {
+	seen := make(map[string]bool)
+	var unknown []string
+	for _, ref := range sc.Refs() {
+		if !known[ref.ConceptName] && !seen[ref.ConceptName] {
+			seen[ref.ConceptName] = true
+			unknown = append(unknown, ref.ConceptName)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// ImportSyncs upserts every *.json sync spec in dir and rebuilds its
+// sync_refs. Specs referencing concepts missing from known are skipped unless
+// force is set. Each file runs under its own savepoint, so exec should be a
+// transaction; a failing file is rolled back without aborting the rest.
+func ImportSyncs(ctx context.Context, exec db.Execer, dir string, known map[string]bool, force bool) ([]ImportResult, error) {
+	files, err := jsonFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ImportResult
+	for _, file := range files {
+		res := ImportResult{File: file, Name: strings.TrimSuffix(filepath.Base(file), ".json")}
+		res.Err = importSyncFile(ctx, exec, file, &res, known, force)
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func importSyncFile(ctx context.Context, exec db.Execer, file string, res *ImportResult, known map[string]bool, force bool) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var sc gam.Synchronization
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return fmt.Errorf("parse spec: %w", err)
+	}
+	if sc.Name == "" {
+		sc.Name = res.Name
+	}
+	res.Name = sc.Name
+
+	if unknown := UnknownConcepts(sc, known); len(unknown) > 0 && !force {
+		return fmt.Errorf("references unknown concept(s) %s (use --force to import anyway)", strings.Join(unknown, ", "))
+	}
+
+	if _, err := exec.Exec(ctx, "SAVEPOINT import_sync"); err != nil {
+		return err
+	}
+	if err := UpsertSync(ctx, exec, sc); err != nil {
+		exec.Exec(ctx, "ROLLBACK TO SAVEPOINT import_sync")
+		return err
+	}
+	_, err = exec.Exec(ctx, "RELEASE SAVEPOINT import_sync")
+	return err
+}
+
+// jsonFiles returns the *.json files directly inside dir, sorted by name.
+func jsonFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,152 @@
+package memorizer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeExecer records Exec statements and arguments and answers QueryRow with
+// a fixed id.
+type fakeExecer struct {
+	sqls  []string
+	execs [][]any
+}
+
+func (f *fakeExecer) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if strings.HasPrefix(sql, "SAVEPOINT") || strings.HasPrefix(sql, "RELEASE") || strings.HasPrefix(sql, "ROLLBACK") {
+		return pgconn.NewCommandTag(sql), nil
+	}
+	f.sqls = append(f.sqls, sql)
+	f.execs = append(f.execs, args)
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+
+func (f *fakeExecer) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return fakeRow{}
+}
+
+type fakeRow struct{}
+
+func (fakeRow) Scan(dest ...any) error {
+	if len(dest) > 0 {
+		if s, ok := dest[0].(*string); ok {
+			*s = "00000000-0000-0000-0000-000000000001"
+		}
+	}
+	return nil
+}
+
+func TestImportConcepts(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "session.json"), []byte(`{
+		"purpose": "track authenticated sessions",
+		"spec": {"actions": {"create": {"cases": [{"input": {"user_id": "string"}}]}}}
+	}`), 0644)
+	os.WriteFile(filepath.Join(dir, "b_search.json"), []byte(`{
+		"name": "SearchSource",
+		"purpose": "register and query index providers"
+	}`), 0644)
+	os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"purpose": `), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644)
+
+	exec := &fakeExecer{}
+	results, err := ImportConcepts(context.Background(), exec, dir)
+	if err != nil {
+		t.Fatalf("ImportConcepts: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d: %+v", len(results), results)
+	}
+
+	byName := map[string]ImportResult{}
+	for _, r := range results {
+		byName[filepath.Base(r.File)] = r
+	}
+	if r := byName["session.json"]; r.Err != nil || r.Name != "session" {
+		t.Errorf("session.json: %+v, want name from file stem", r)
+	}
+	if r := byName["b_search.json"]; r.Err != nil || r.Name != "SearchSource" {
+		t.Errorf("b_search.json: %+v, want name from spec", r)
+	}
+	if r := byName["broken.json"]; r.Err == nil {
+		t.Error("broken.json: expected parse error")
+	}
+
+	if len(exec.execs) != 2 {
+		t.Fatalf("expected 2 upserts, got %d", len(exec.execs))
+	}
+	if exec.execs[0][0] != "SearchSource" || exec.execs[1][0] != "session" {
+		t.Errorf("upserted names = %v, %v", exec.execs[0][0], exec.execs[1][0])
+	}
+}
+
+func TestImportSyncsBuildsRefs(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "fan_out.json"), []byte(`{
+		"when_clause": [{"concept": "Web", "action": "request"}],
+		"where_clause": [{"concept": "SearchSource", "pattern": {"?s": {"enabled": true}}}],
+		"then_clause": [{"concept": "SearchSource", "action": "query"}]
+	}`), 0644)
+	os.WriteFile(filepath.Join(dir, "notify.json"), []byte(`{
+		"name": "NotifyOnResult",
+		"when_clause": [{"concept": "SearchSource", "action": "query"}],
+		"then_clause": [{"concept": "Notifier", "action": "send"}]
+	}`), 0644)
+	os.WriteFile(filepath.Join(dir, "unknown.json"), []byte(`{
+		"when_clause": [{"concept": "Ghost", "action": "haunt"}],
+		"then_clause": [{"concept": "Web", "action": "respond"}]
+	}`), 0644)
+
+	known := map[string]bool{"Web": true, "SearchSource": true, "Notifier": true}
+	exec := &fakeExecer{}
+	results, err := ImportSyncs(context.Background(), exec, dir, known, false)
+	if err != nil {
+		t.Fatalf("ImportSyncs: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %+v", results)
+	}
+	if results[0].Err != nil || results[0].Name != "fan_out" {
+		t.Errorf("fan_out.json: %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Name != "NotifyOnResult" {
+		t.Errorf("notify.json: %+v", results[1])
+	}
+	if results[2].Err == nil || !strings.Contains(results[2].Err.Error(), "Ghost") {
+		t.Errorf("unknown.json: expected unknown concept error, got %v", results[2].Err)
+	}
+
+	var syncs, refs int
+	var refConcepts []string
+	for i, sql := range exec.sqls {
+		switch {
+		case strings.Contains(sql, "INSERT INTO synchronizations"):
+			syncs++
+		case strings.Contains(sql, "INSERT INTO sync_refs"):
+			refs++
+			refConcepts = append(refConcepts, exec.execs[i][1].(string))
+		}
+	}
+	if syncs != 2 {
+		t.Errorf("upserted %d syncs, want 2", syncs)
+	}
+	// fan_out: Web/request (when), SearchSource/enabled (where), SearchSource/query (then)
+	// NotifyOnResult: SearchSource/query (when), Notifier/send (then)
+	if refs != 5 {
+		t.Errorf("built %d sync_refs, want 5 (%v)", refs, refConcepts)
+	}
+
+	exec = &fakeExecer{}
+	results, _ = ImportSyncs(context.Background(), exec, dir, known, true)
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("%s with --force: %v", r.File, r.Err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package memorizer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Proposal locking
+//
+// A proposal locks its region hierarchically with session advisory locks: a
+// shared lock on every proper ancestor, then an exclusive lock on the exact
+// path. Two proposals conflict only when one path equals or contains the
+// other, so disjoint subtrees, including siblings under a common parent, are
+// processed concurrently.
+//
+// Locks are always taken root to leaf (app, app.search, app.search.sources).
+// Any lock two proposals both need is a common ancestor, which each reaches at
+// the same depth and in the same order, so no cycle of waits can form between
+// sibling subtrees. Locks are released in reverse order.
+
+// lockOrder returns the paths to lock for regionPath, root first. Every entry
+// but the last is an ancestor and is locked shared.
+func lockOrder(regionPath string) []string {
+	parts := strings.Split(regionPath, ".")
+	order := make([]string, len(parts))
+	for i := range parts {
+		order[i] = strings.Join(parts[:i+1], ".")
+	}
+	return order
+}
+
+// lockRegionPath acquires the hierarchical lock for regionPath on a dedicated
+// connection, since session advisory locks belong to the connection that took
+// them. The returned func releases the locks and the connection.
+func lockRegionPath(ctx context.Context, pool *pgxpool.Pool, regionPath string) (func(), error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type heldLock struct {
+		key       int64
+		exclusive bool
+	}
+	var held []heldLock
+	unlock := func() {
+		// Release even if ctx was cancelled while processing.
+		bg := context.Background()
+		for i := len(held) - 1; i >= 0; i-- {
+			conn.Exec(bg, unlockSQL(held[i].exclusive), held[i].key)
+		}
+		conn.Release()
+	}
+
+	order := lockOrder(regionPath)
+	for i, p := range order {
+		l := heldLock{key: hashTo64Bit(p), exclusive: i == len(order)-1}
+		if _, err := conn.Exec(ctx, lockSQL(l.exclusive), l.key); err != nil {
+			unlock()
+			return nil, fmt.Errorf("lock %s: %w", p, err)
+		}
+		held = append(held, l)
+	}
+	return unlock, nil
+}
+
+func lockSQL(exclusive bool) string {
+	if exclusive {
+		return "SELECT pg_advisory_lock($1)"
+	}
+	return "SELECT pg_advisory_lock_shared($1)"
+}
+
+func unlockSQL(exclusive bool) string {
+	if exclusive {
+		return "SELECT pg_advisory_unlock($1)"
+	}
+	return "SELECT pg_advisory_unlock_shared($1)"
+}
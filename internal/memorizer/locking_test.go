@@ -0,0 +1,66 @@
+package memorizer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestLockOrder(t *testing.T) {
+	got := lockOrder("app.search.sources")
+	want := []string{"app", "app.search", "app.search.sources"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lockOrder = %v, want %v", got, want)
+	}
+	if got := lockOrder("app"); !reflect.DeepEqual(got, []string{"app"}) {
+		t.Errorf("lockOrder(app) = %v", got)
+	}
+}
+
+// tryLock attempts lockRegionPath within timeout and reports whether it was
+// acquired, releasing it immediately if so.
+func tryLock(ctx context.Context, pool *pgxpool.Pool, path string, timeout time.Duration) bool {
+	lockCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	unlock, err := lockRegionPath(lockCtx, pool, path)
+	if err != nil {
+		return false
+	}
+	unlock()
+	return true
+}
+
+func TestLockRegionPathDisjointSubtrees(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	// Hold the lock for one proposal as if it were still being processed.
+	unlock, err := lockRegionPath(ctx, pool, "locktest.api")
+	if err != nil {
+		t.Fatalf("lock locktest.api: %v", err)
+	}
+	defer unlock()
+
+	// A sibling subtree must not wait for it.
+	done := make(chan bool, 1)
+	go func() { done <- tryLock(ctx, pool, "locktest.store.cache", 2*time.Second) }()
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Error("disjoint subtree locktest.store.cache was serialized behind locktest.api")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("disjoint lock did not return")
+	}
+
+	// Overlapping paths must wait: a descendant and the common ancestor.
+	for _, path := range []string{"locktest.api.handlers", "locktest"} {
+		if tryLock(ctx, pool, path, 300*time.Millisecond) {
+			t.Errorf("%s acquired its lock while locktest.api was held", path)
+		}
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
+	"github.com/sbenjam1n/gamsync/internal/db"
 	"github.com/sbenjam1n/gamsync/internal/gam"
 	"github.com/sbenjam1n/gamsync/internal/queue"
 	"github.com/sbenjam1n/gamsync/internal/validator"
@@ -23,7 +26,15 @@ type Memorizer struct {
 	rdb         *redis.Client
 	queue       *queue.Queue
 	validator   *validator.Validator
+	hooks       *HookRegistry
 	projectRoot string
+	workers     int           // ConsumeProposals workers; see locking.go
+	grace       time.Duration // how long in-flight proposals may finish after cancel
+	todoAge     time.Duration // gardener: age before a scratchpad TODO is stale
+	driftWindow time.Duration // gardener: lookback for sync drift in flow_log
+	log         *slog.Logger  // nil means slog.Default()
+
+	maxReviewIterations int // Tier 3 rounds before escalating to a human
 }
 
 // New creates a new Memorizer.
@@ -33,42 +44,241 @@ func New(db *pgxpool.Pool, rdb *redis.Client, projectRoot string) *Memorizer {
 		rdb:         rdb,
 		queue:       queue.New(rdb),
 		validator:   validator.New(db, projectRoot),
+		hooks:       NewHookRegistry(db, projectRoot),
 		projectRoot: projectRoot,
+		workers:     1,
+		grace:       DefaultShutdownGrace,
+		todoAge:     DefaultTodoAge,
+		driftWindow: DefaultDriftWindow,
+
+		maxReviewIterations: DefaultMaxReviewIterations,
+	}
+}
+
+// SetProposalWorkers sets how many workers ConsumeProposals runs. Proposals
+// on overlapping region subtrees still serialize on their path locks; values
+// below 1 mean 1.
+func (m *Memorizer) SetProposalWorkers(n int) {
+	if n < 1 {
+		n = 1
+	}
+	m.workers = n
+}
+
+// ProposalPoolConns is the smallest database pool that can serve n proposal
+// workers without deadlocking: each in-flight proposal holds one connection
+// for its region locks (see locking.go) while its queries need a second, and
+// one more is left for everything else.
+func ProposalPoolConns(n int) int32 {
+	return int32(2*max(n, 1) + 1)
+}
+
+// SetExternalValidator configures the Tier 2 command run on proposals that
+// pass Tier 1. See validator.SetExternalCheck.
+func (m *Memorizer) SetExternalValidator(command string, timeout time.Duration) {
+	m.validator.SetExternalCheck(command, timeout)
+}
+
+// SetMaxReviewIterations sets how many review rounds a proposal may go
+// through before ReviewProposal escalates it; values below 1 mean
+// DefaultMaxReviewIterations.
+func (m *Memorizer) SetMaxReviewIterations(n int) {
+	if n < 1 {
+		n = DefaultMaxReviewIterations
+	}
+	m.maxReviewIterations = n
+}
+
+// SetLogger sets the structured logger for proposal processing. A nil logger
+// means slog.Default().
+func (m *Memorizer) SetLogger(l *slog.Logger) {
+	m.log = l
+}
+
+func (m *Memorizer) logger() *slog.Logger {
+	if m.log == nil {
+		return slog.Default()
 	}
+	return m.log
 }
 
-// ConsumeProposals blocks on Redis, processing proposals as they arrive.
+// proposalBatchSize is the most proposals ConsumeProposals reads per poll.
+const proposalBatchSize = 10
+
+// DefaultShutdownGrace bounds how long in-flight proposals may run after
+// ConsumeProposals is cancelled before their work is cancelled too.
+const DefaultShutdownGrace = 30 * time.Second
+
+// proposalSource is the part of queue.Queue that ConsumeProposals uses.
+type proposalSource interface {
+	ReadProposals(ctx context.Context, consumer string, n int) ([]*queue.ProposalMessage, []string, error)
+	ReadPendingProposals(ctx context.Context, consumer string, n int) ([]*queue.ProposalMessage, []string, error)
+	Claim(ctx context.Context, key string) (bool, error)
+	Release(ctx context.Context, key string) error
+	AckProposal(ctx context.Context, msgID string) error
+	Draining(ctx context.Context) (bool, error)
+}
+
+// drainPoll is how often a worker rechecks the drain flag while reads are
+// paused.
+const drainPoll = time.Second
+
+// ConsumeProposals blocks on Redis, processing proposals as they arrive. It
+// runs the configured number of workers, each reading batches from the
+// memorizer consumer group under its own consumer name and acking each
+// proposal as it finishes. Conflicting proposals picked up by different
+// workers serialize on their region path locks (see locking.go).
+//
+// When ctx is cancelled no new proposals are started. Proposals already
+// running keep going, so their path locks and acks are not abandoned midway,
+// for up to the shutdown grace period; after that their context is cancelled.
+// Proposals read but not started stay pending under the worker's consumer
+// name, and that worker processes them first on the next run.
+// ConsumeProposals returns ctx.Err() once every worker has exited.
 func (m *Memorizer) ConsumeProposals(ctx context.Context) error {
+	if need, have := ProposalPoolConns(m.workers), m.db.Config().MaxConns; have < need {
+		return fmt.Errorf("%d proposal workers need a database pool of at least %d connections, have %d: lower the worker count or raise pool_max_conns", m.workers, need, have)
+	}
 	if err := m.queue.EnsureStreams(ctx); err != nil {
 		return err
 	}
+	return m.consume(ctx, m.queue, m.processProposal)
+}
+
+func (m *Memorizer) consume(ctx context.Context, src proposalSource, process func(ctx context.Context, id, path string) error) error {
+	// In-flight work outlives ctx by up to the grace period.
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopped:
+			return
+		}
+		timer := time.NewTimer(m.grace)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancelWork()
+		case <-stopped:
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < max(m.workers, 1); i++ {
+		consumer := fmt.Sprintf("memorizer_%d", i+1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.consumeWorker(ctx, workCtx, src, consumer, process)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// consumeWorker reads and processes batches as consumer until ctx is
+// cancelled. It first finishes the proposals left pending under consumer by
+// an earlier run, then reads new ones. Proposals run under workCtx so
+// cancellation does not interrupt one midway. While the queue is draining
+// (see gam queue drain) it stops reading new proposals but keeps running,
+// so in-flight batches finish and get acked.
+func (m *Memorizer) consumeWorker(ctx, workCtx context.Context, src proposalSource, consumer string, process func(ctx context.Context, id, path string) error) {
+	for ctx.Err() == nil {
+		msgs, msgIDs, err := src.ReadPendingProposals(ctx, consumer, proposalBatchSize)
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, queue.ErrNoMessages) {
+				m.logger().Error("pending proposal read failed", "consumer", consumer, "error", err)
+			}
+			break
+		}
+		if len(msgs) > 0 {
+			m.logger().Info("resuming pending proposals", "consumer", consumer, "count", len(msgs))
+		}
+		if !m.processBatch(ctx, workCtx, src, consumer, msgs, msgIDs, process) {
+			return
+		}
+	}
+
+	paused := false
+	for ctx.Err() == nil {
+		if draining, err := src.Draining(ctx); err == nil && draining {
+			if !paused {
+				m.logger().Info("queue draining, proposal reads paused", "consumer", consumer)
+				paused = true
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(drainPoll):
+			}
+			continue
+		} else if paused {
+			m.logger().Info("queue drain cleared, proposal reads resumed", "consumer", consumer)
+			paused = false
+		}
 
-	for {
-		msg, msgID, err := m.queue.ReadProposal(ctx, "memorizer_1")
+		msgs, msgIDs, err := src.ReadProposals(ctx, consumer, proposalBatchSize)
 		if err != nil {
-			if ctx.Err() != nil {
-				return ctx.Err()
+			if ctx.Err() == nil && !errors.Is(err, queue.ErrNoMessages) {
+				m.logger().Error("proposal read failed", "consumer", consumer, "error", err)
 			}
-			log.Printf("proposal read error: %v", err)
 			continue
 		}
+		if !m.processBatch(ctx, workCtx, src, consumer, msgs, msgIDs, process) {
+			return
+		}
+	}
+}
+
+// processBatch claims, processes and acks msgs in order, releasing the
+// claim of any proposal that fails. It reports false
+// when ctx was cancelled before the batch finished. Proposals not yet
+// started stay unclaimed and pending under consumer, so the next run's
+// pending read picks them up. Acks use a context that outlives the grace
+// period, so a proposal that ran is never left pending.
+func (m *Memorizer) processBatch(ctx, workCtx context.Context, src proposalSource, consumer string, msgs []*queue.ProposalMessage, msgIDs []string, process func(ctx context.Context, id, path string) error) bool {
+	ackCtx := context.WithoutCancel(workCtx)
+	for i, msg := range msgs {
+		if ctx.Err() != nil {
+			return false
+		}
 
-		if err := m.processProposal(ctx, msg.ProposalID, msg.RegionPath); err != nil {
-			log.Printf("proposal %s failed: %v", msg.ProposalID, err)
+		plog := m.logger().With("consumer", consumer, "proposal", msg.ProposalID, "region", msg.RegionPath)
+		first, err := src.Claim(workCtx, msg.IdempotencyKey())
+		if err != nil {
+			plog.Warn("proposal claim failed", "error", err)
+		} else if !first {
+			plog.Info("proposal already processed, skipping duplicate")
+			src.AckProposal(ackCtx, msgIDs[i])
+			continue
 		}
 
-		m.queue.AckProposal(ctx, msgID)
+		plog.Debug("processing proposal")
+		if err := process(workCtx, msg.ProposalID, msg.RegionPath); err != nil {
+			plog.Error("proposal failed", "error", err)
+			// A failed proposal is not processed; let a re-push run it.
+			if first {
+				if err := src.Release(ackCtx, msg.IdempotencyKey()); err != nil {
+					plog.Warn("proposal claim release failed", "error", err)
+				}
+			}
+		}
+		if err := src.AckProposal(ackCtx, msgIDs[i]); err != nil {
+			plog.Error("proposal ack failed", "error", err)
+		}
 	}
+	return true
 }
 
 func (m *Memorizer) processProposal(ctx context.Context, id, path string) error {
-	// Advisory lock on LTREE path
-	pathHash := hashTo64Bit(path)
-	_, err := m.db.Exec(ctx, "SELECT pg_advisory_lock($1)", pathHash)
+	// Hierarchical advisory lock on the LTREE path
+	unlock, err := lockRegionPath(ctx, m.db, path)
 	if err != nil {
-		return fmt.Errorf("lock %s: %w", path, err)
+		return err
 	}
-	defer m.db.Exec(ctx, "SELECT pg_advisory_unlock($1)", pathHash)
+	defer unlock()
 
 	// Fetch proposal
 	proposal, err := m.getProposal(ctx, id)
@@ -81,11 +291,29 @@ func (m *Memorizer) processProposal(ctx context.Context, id, path string) error
 	if err != nil {
 		return err
 	}
+	m.logValidation(id, path, result)
 	if !result.Passed {
 		return m.rejectProposal(ctx, id, result)
 	}
 
-	return m.approveProposal(ctx, id, proposal)
+	return m.approveProposal(ctx, id, proposal, result)
+}
+
+// logValidation records the outcome of validating a proposal: rejections at
+// warn level with the failing checks, approvals at info.
+func (m *Memorizer) logValidation(id, path string, result *gam.ValidationResult) {
+	l := m.logger().With("proposal", id, "region", path, "tier", result.Tier, "code", result.Code)
+	if result.Passed {
+		l.Info("proposal approved")
+		return
+	}
+	var failed []string
+	for _, d := range result.Details {
+		if !d.Passed {
+			failed = append(failed, d.Check)
+		}
+	}
+	l.Warn("proposal rejected", "message", result.Message, "failed_checks", failed)
 }
 
 func (m *Memorizer) getProposal(ctx context.Context, id string) (*gam.Proposal, error) {
@@ -136,19 +364,21 @@ func (m *Memorizer) rejectProposal(ctx context.Context, id string, result *gam.V
 	}
 
 	detailsJSON, _ := json.Marshal(result.Details)
+	resultJSON, _ := json.Marshal(result)
 
 	_, err := m.db.Exec(ctx, `
 		UPDATE proposals
 		SET status = 'REJECTED',
 			validation_error_code = $1,
 			violation_details = $2,
-			rejection_reason = $3
+			rejection_reason = $3,
+			validation_result = $5
 		WHERE id = $4
-	`, result.Code, detailsJSON, briefing, id)
+	`, result.Code, detailsJSON, briefing, id, resultJSON)
 	return err
 }
 
-func (m *Memorizer) approveProposal(ctx context.Context, id string, p *gam.Proposal) error {
+func (m *Memorizer) approveProposal(ctx context.Context, id string, p *gam.Proposal, result *gam.ValidationResult) error {
 	tx, err := m.db.Begin(ctx)
 	if err != nil {
 		return err
@@ -156,7 +386,8 @@ func (m *Memorizer) approveProposal(ctx context.Context, id string, p *gam.Propo
 	defer tx.Rollback(ctx)
 
 	// Update proposal status
-	tx.Exec(ctx, "UPDATE proposals SET status = 'APPROVED' WHERE id = $1", id)
+	resultJSON, _ := json.Marshal(result)
+	tx.Exec(ctx, "UPDATE proposals SET status = 'APPROVED', validation_result = $2 WHERE id = $1", id, resultJSON)
 
 	// Update region lifecycle state if transition specified
 	if p.ProposedState != "" {
@@ -168,14 +399,28 @@ func (m *Memorizer) approveProposal(ctx context.Context, id string, p *gam.Propo
 
 	// Insert sync changes if any — all within the transaction
 	if p.SyncChanges != nil {
+		actor := "proposal " + id
 		for _, sc := range p.SyncChanges.Added {
-			m.insertSyncTx(ctx, tx, sc)
+			if err := m.insertSyncTx(ctx, tx, sc); err != nil {
+				return err
+			}
+			if err := RecordSyncAudit(ctx, tx, sc.Name, SyncAuditAdd, actor, ""); err != nil {
+				return err
+			}
 		}
 		for _, sc := range p.SyncChanges.Modified {
-			m.updateSyncTx(ctx, tx, sc)
+			if err := m.updateSyncTx(ctx, tx, sc); err != nil {
+				return err
+			}
+			if err := RecordSyncAudit(ctx, tx, sc.Name, SyncAuditUpdate, actor, ""); err != nil {
+				return err
+			}
 		}
 		for _, name := range p.SyncChanges.Deleted {
 			tx.Exec(ctx, "DELETE FROM synchronizations WHERE name = $1", name)
+			if err := RecordSyncAudit(ctx, tx, name, SyncAuditDelete, actor, ""); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -183,6 +428,15 @@ func (m *Memorizer) approveProposal(ctx context.Context, id string, p *gam.Propo
 		return err
 	}
 
+	if err := m.hooks.Fire(ctx, HookContext{
+		Event:      EventProposalApproved,
+		TurnID:     p.TurnID,
+		RegionPath: p.RegionPath,
+		ProposalID: id,
+	}); err != nil {
+		m.logger().Error("proposal_approved hooks failed", "proposal", id, "region", p.RegionPath, "error", err)
+	}
+
 	// Post-commit: queue deferred actions via Redis (outside tx)
 	for _, deferred := range p.DeferredActions {
 		m.queueTask(ctx, deferred.TargetRegion, deferred.TaskType, deferred.Reason)
@@ -200,71 +454,38 @@ func (m *Memorizer) approveProposal(ctx context.Context, id string, p *gam.Propo
 	return nil
 }
 
-func (m *Memorizer) insertSyncTx(ctx context.Context, tx pgx.Tx, sc gam.Synchronization) {
+func (m *Memorizer) insertSyncTx(ctx context.Context, tx pgx.Tx, sc gam.Synchronization) error {
 	whenJSON, _ := json.Marshal(sc.WhenClause)
 	whereJSON, _ := json.Marshal(sc.WhereClause)
 	thenJSON, _ := json.Marshal(sc.ThenClause)
 
-	tx.Exec(ctx, `
+	_, err := tx.Exec(ctx, `
 		INSERT INTO synchronizations (name, when_clause, where_clause, then_clause, description, enabled)
 		VALUES ($1, $2, $3, $4, $5, $6)
 	`, sc.Name, whenJSON, whereJSON, thenJSON, sc.Description, true)
+	if err != nil {
+		return fmt.Errorf("insert sync %s: %w", sc.Name, err)
+	}
 
-	m.buildSyncRefsTx(ctx, tx, sc)
+	return db.BuildSyncRefs(ctx, tx, sc)
 }
 
-func (m *Memorizer) updateSyncTx(ctx context.Context, tx pgx.Tx, sc gam.Synchronization) {
+func (m *Memorizer) updateSyncTx(ctx context.Context, tx pgx.Tx, sc gam.Synchronization) error {
 	whenJSON, _ := json.Marshal(sc.WhenClause)
 	whereJSON, _ := json.Marshal(sc.WhereClause)
 	thenJSON, _ := json.Marshal(sc.ThenClause)
 
-	tx.Exec(ctx, `
+	_, err := tx.Exec(ctx, `
 		UPDATE synchronizations
 		SET when_clause = $1, where_clause = $2, then_clause = $3,
 		    description = $4, updated_at = NOW()
 		WHERE name = $5
 	`, whenJSON, whereJSON, thenJSON, sc.Description, sc.Name)
-
-	tx.Exec(ctx, `DELETE FROM sync_refs WHERE sync_id = (SELECT id FROM synchronizations WHERE name = $1)`, sc.Name)
-	m.buildSyncRefsTx(ctx, tx, sc)
-}
-
-func (m *Memorizer) buildSyncRefsTx(ctx context.Context, tx pgx.Tx, sc gam.Synchronization) {
-	var syncID string
-	tx.QueryRow(ctx, "SELECT id FROM synchronizations WHERE name = $1", sc.Name).Scan(&syncID)
-	if syncID == "" {
-		return
-	}
-
-	for _, w := range sc.WhenClause {
-		tx.Exec(ctx, `
-			INSERT INTO sync_refs (sync_id, concept_name, action_name, clause_type)
-			VALUES ($1, $2, $3, 'when')
-			ON CONFLICT DO NOTHING
-		`, syncID, w.Concept, w.Action)
+	if err != nil {
+		return fmt.Errorf("update sync %s: %w", sc.Name, err)
 	}
 
-	for _, t := range sc.ThenClause {
-		tx.Exec(ctx, `
-			INSERT INTO sync_refs (sync_id, concept_name, action_name, clause_type)
-			VALUES ($1, $2, $3, 'then')
-			ON CONFLICT DO NOTHING
-		`, syncID, t.Concept, t.Action)
-	}
-
-	for _, w := range sc.WhereClause {
-		for _, patternVal := range w.Pattern {
-			if fields, ok := patternVal.(map[string]any); ok {
-				for fieldName := range fields {
-					tx.Exec(ctx, `
-						INSERT INTO sync_refs (sync_id, concept_name, state_field, clause_type)
-						VALUES ($1, $2, $3, 'where')
-						ON CONFLICT DO NOTHING
-					`, syncID, w.Concept, fieldName)
-				}
-			}
-		}
-	}
+	return db.BuildSyncRefs(ctx, tx, sc)
 }
 
 // CreateTurn creates a new turn for a researcher to work on.
@@ -520,15 +741,22 @@ func (m *Memorizer) CreatePlan(ctx context.Context, name, goal string, turns []g
 	return plan, nil
 }
 
-// RecordDecision appends a design decision to an active plan.
+// RecordDecision appends a design decision to an active plan. It fails when
+// no ACTIVE plan has planID.
 func (m *Memorizer) RecordDecision(ctx context.Context, planID string, decision gam.Decision) error {
-	decJSON, _ := json.Marshal(decision)
-	_, err := m.db.Exec(ctx, `
+	decJSON, _ := json.Marshal([]gam.Decision{decision})
+	tag, err := m.db.Exec(ctx, `
 		UPDATE execution_plans
-		SET decisions = decisions || $1::jsonb
+		SET decisions = COALESCE(decisions, '[]'::jsonb) || $1::jsonb
 		WHERE id = $2 AND status = 'ACTIVE'
 	`, decJSON, planID)
-	return err
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("plan is not active")
+	}
+	return nil
 }
 
 // UpdatePlanProgress marks a turn as completed and queues newly unblocked turns.
@@ -543,9 +771,12 @@ func (m *Memorizer) UpdatePlanProgress(ctx context.Context, planID, turnID strin
 	`, planID).Scan(&remaining)
 
 	if remaining == 0 {
+		grade, _ := m.ComputePlanGrade(ctx, planID)
 		m.db.Exec(ctx, `
-			UPDATE execution_plans SET status = 'COMPLETED', completed_at = NOW() WHERE id = $1
-		`, planID)
+			UPDATE execution_plans
+			SET status = 'COMPLETED', completed_at = NOW(), quality_grade = COALESCE(NULLIF($2, ''), quality_grade)
+			WHERE id = $1
+		`, planID, grade)
 	}
 
 	m.queueReadyPlanTurns(ctx, planID)
@@ -558,12 +789,7 @@ func (m *Memorizer) queueReadyPlanTurns(ctx context.Context, planID string) {
 		FROM plan_turns pt
 		WHERE pt.plan_id = $1
 		  AND pt.status = 'pending'
-		  AND NOT EXISTS (
-			  SELECT 1 FROM unnest(pt.depends_on) dep
-			  JOIN plan_turns dep_pt ON dep_pt.turn_id = dep AND dep_pt.plan_id = $1
-			  WHERE dep_pt.status != 'completed'
-		  )
-	`, planID)
+		  AND `+planTurnUnblocked, planID)
 	if rows == nil {
 		return
 	}
@@ -0,0 +1,247 @@
+package memorizer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/queue"
+)
+
+// PlanDeleteResult reports what DeletePlan removed.
+type PlanDeleteResult struct {
+	PlanTurns int64
+	Turns     int64
+}
+
+// DeletePlan removes the named plan and its plan_turns in one transaction.
+// Plans with active plan turns are refused unless force is set. When
+// deleteTurns is set, the plan's generated turns are deleted too if nothing
+// else references them (no touched regions and no proposals); other turns are
+// kept and detached from the plan.
+func DeletePlan(ctx context.Context, pool *pgxpool.Pool, name string, force, deleteTurns bool) (PlanDeleteResult, error) {
+	var res PlanDeleteResult
+
+	planID, err := LookupPlanID(ctx, pool, name)
+	if err != nil {
+		return res, err
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback(ctx)
+
+	var active int
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM plan_turns WHERE plan_id = $1 AND status = 'active'
+	`, planID).Scan(&active); err != nil {
+		return res, fmt.Errorf("count active plan turns: %w", err)
+	}
+	if active > 0 && !force {
+		return res, fmt.Errorf("plan '%s' has %d in-progress turn(s); use --force to delete anyway", name, active)
+	}
+
+	var turnIDs []string
+	rows, err := tx.Query(ctx, `SELECT turn_id FROM plan_turns WHERE plan_id = $1`, planID)
+	if err != nil {
+		return res, err
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return res, err
+		}
+		turnIDs = append(turnIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return res, fmt.Errorf("list plan turns: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM plan_turns WHERE plan_id = $1`, planID)
+	if err != nil {
+		return res, fmt.Errorf("delete plan turns: %w", err)
+	}
+	res.PlanTurns = tag.RowsAffected()
+
+	if _, err := tx.Exec(ctx, `UPDATE turns SET plan_id = NULL WHERE plan_id = $1`, planID); err != nil {
+		return res, fmt.Errorf("detach turns: %w", err)
+	}
+
+	if deleteTurns && len(turnIDs) > 0 {
+		tag, err := tx.Exec(ctx, `
+			DELETE FROM turns t
+			WHERE t.id = ANY($1)
+			  AND NOT EXISTS (SELECT 1 FROM turn_regions tr WHERE tr.turn_id = t.id)
+			  AND NOT EXISTS (SELECT 1 FROM proposals p WHERE p.turn_id = t.id)
+			  AND NOT EXISTS (SELECT 1 FROM plan_turns pt WHERE pt.turn_id = t.id)
+		`, turnIDs)
+		if err != nil {
+			return res, fmt.Errorf("delete turns: %w", err)
+		}
+		res.Turns = tag.RowsAffected()
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM execution_plans WHERE id = $1`, planID); err != nil {
+		return res, fmt.Errorf("delete plan: %w", err)
+	}
+
+	return res, tx.Commit(ctx)
+}
+
+// ArchivePlan marks the named plan ARCHIVED, hiding it from default listings.
+func ArchivePlan(ctx context.Context, pool *pgxpool.Pool, name string) error {
+	planID, err := LookupPlanID(ctx, pool, name)
+	if err != nil {
+		return err
+	}
+	_, err = pool.Exec(ctx, `UPDATE execution_plans SET status = 'ARCHIVED' WHERE id = $1`, planID)
+	return err
+}
+
+// planTurnUnblocked holds for a plan_turns row aliased pt when every turn it
+// depends on has completed.
+const planTurnUnblocked = `NOT EXISTS (
+	SELECT 1 FROM unnest(pt.depends_on) dep
+	JOIN plan_turns dep_pt ON dep_pt.turn_id = dep AND dep_pt.plan_id = pt.plan_id
+	WHERE dep_pt.status != 'completed'
+)`
+
+// NextPlanTurns returns the named plan's actionable turns in plan order:
+// active turns, and pending turns whose dependencies have all completed.
+func NextPlanTurns(ctx context.Context, pool *pgxpool.Pool, name string) ([]gam.PlanTurn, error) {
+	planID, err := LookupPlanID(ctx, pool, name)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT pt.plan_id::text, pt.turn_id, pt.region_path::text, pt.ordering,
+		       COALESCE(pt.depends_on, '{}'), pt.status
+		FROM plan_turns pt
+		WHERE pt.plan_id = $1
+		  AND (pt.status = 'active' OR (pt.status = 'pending' AND `+planTurnUnblocked+`))
+		ORDER BY pt.ordering, pt.turn_id
+	`, planID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var turns []gam.PlanTurn
+	for rows.Next() {
+		var pt gam.PlanTurn
+		if err := rows.Scan(&pt.PlanID, &pt.TurnID, &pt.RegionPath, &pt.Ordering, &pt.DependsOn, &pt.Status); err != nil {
+			return nil, err
+		}
+		turns = append(turns, pt)
+	}
+	return turns, rows.Err()
+}
+
+// taskPusher is the part of queue.Queue that ReplayStalledPlanTurns uses.
+type taskPusher interface {
+	PushTask(ctx context.Context, msg queue.TaskMessage) (string, error)
+}
+
+// ReplayStalledPlanTurns re-pushes the task for every active plan turn whose
+// turn is still ACTIVE and has no proposal created within window, recovering
+// turns whose task was lost after queueReadyPlanTurns marked them active. An
+// empty plan name covers all plans. The original push's idempotency key may
+// already be claimed by a researcher that then lost the task, so each replay
+// carries a fresh replay token and therefore its own key. With dryRun set
+// nothing is pushed. It returns the stalled tasks.
+func (m *Memorizer) ReplayStalledPlanTurns(ctx context.Context, plan string, window time.Duration, dryRun bool) ([]queue.TaskMessage, error) {
+	return replayStalledPlanTurns(ctx, m.db, m.queue, plan, window, dryRun)
+}
+
+func replayStalledPlanTurns(ctx context.Context, pool *pgxpool.Pool, q taskPusher, plan string, window time.Duration, dryRun bool) ([]queue.TaskMessage, error) {
+	planID := ""
+	if plan != "" {
+		var err error
+		if planID, err = LookupPlanID(ctx, pool, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT pt.turn_id, pt.region_path::text, COALESCE(t.task_type, 'implement')
+		FROM plan_turns pt
+		JOIN execution_plans ep ON ep.id = pt.plan_id
+		JOIN turns t ON t.id = pt.turn_id
+		WHERE pt.status = 'active'
+		  AND t.status = 'ACTIVE'
+		  AND ($1 = '' OR pt.plan_id::text = $1)
+		  AND NOT EXISTS (
+			SELECT 1 FROM proposals p
+			WHERE p.turn_id = pt.turn_id AND p.created_at >= $2
+		  )
+		ORDER BY ep.name, pt.ordering, pt.turn_id
+	`, planID, time.Now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("find stalled plan turns: %w", err)
+	}
+	var tasks []queue.TaskMessage
+	for rows.Next() {
+		var task queue.TaskMessage
+		if err := rows.Scan(&task.TurnID, &task.RegionPath, &task.TaskType); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return tasks, nil
+	}
+	replay := strconv.FormatInt(time.Now().UnixNano(), 36)
+	for i := range tasks {
+		tasks[i].Replay = replay
+	}
+	for i, task := range tasks {
+		if _, err := q.PushTask(ctx, task); err != nil {
+			return tasks[:i], fmt.Errorf("replay %s: %w", task.TurnID, err)
+		}
+	}
+	return tasks, nil
+}
+
+// LookupPlanID resolves a plan name to its id. Plan names are not unique, so
+// an ambiguous name is an error.
+func LookupPlanID(ctx context.Context, pool *pgxpool.Pool, name string) (string, error) {
+	rows, err := pool.Query(ctx, `SELECT id FROM execution_plans WHERE name = $1`, name)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("plan '%s' not found", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("plan name '%s' matches %d plans", name, len(ids))
+	}
+}
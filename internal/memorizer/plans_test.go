@@ -0,0 +1,269 @@
+package memorizer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/queue"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+// seedPlan inserts a plan with two generated turns; the first is marked with
+// planTurnStatus and has touched a region, the second is unused.
+func seedPlan(t *testing.T, pool *pgxpool.Pool, name, planTurnStatus string) (usedTurn, unusedTurn string) {
+	t.Helper()
+	ctx := context.Background()
+
+	var planID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO execution_plans (name, goal, status) VALUES ($1, 'test', 'ACTIVE') RETURNING id
+	`, name).Scan(&planID); err != nil {
+		t.Fatalf("insert plan: %v", err)
+	}
+
+	usedTurn, unusedTurn = GenerateTurnID()+"u", GenerateTurnID()+"n"
+	var regionID string
+	pool.QueryRow(ctx, `
+		INSERT INTO regions (path) VALUES ('plantest') ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path RETURNING id
+	`).Scan(&regionID)
+	for i, id := range []string{usedTurn, unusedTurn} {
+		pool.Exec(ctx, `INSERT INTO turns (id, agent_role, scope_path, status, plan_id) VALUES ($1, 'researcher', 'plantest', 'ACTIVE', $2)`, id, planID)
+		status := "pending"
+		if i == 0 {
+			status = planTurnStatus
+		}
+		pool.Exec(ctx, `INSERT INTO plan_turns (plan_id, turn_id, region_path, ordering, status) VALUES ($1, $2, 'plantest', $3, $4)`, planID, id, i, status)
+	}
+	pool.Exec(ctx, `INSERT INTO turn_regions (turn_id, region_id, action) VALUES ($1, $2, 'modified')`, usedTurn, regionID)
+	return usedTurn, unusedTurn
+}
+
+func TestDeletePlan(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	used, unused := seedPlan(t, pool, "delete-me", "active")
+	defer pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = $1`, used)
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = ANY($1)`, []string{used, unused})
+
+	if _, err := DeletePlan(ctx, pool, "delete-me", false, true); err == nil {
+		t.Fatal("expected refusal for plan with in-progress turns")
+	}
+
+	res, err := DeletePlan(ctx, pool, "delete-me", true, true)
+	if err != nil {
+		t.Fatalf("DeletePlan --force: %v", err)
+	}
+	if res.PlanTurns != 2 || res.Turns != 1 {
+		t.Errorf("result = %+v, want 2 plan turns and 1 unused turn", res)
+	}
+
+	var plans, kept, removed int
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM execution_plans WHERE name = 'delete-me'`).Scan(&plans)
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM turns WHERE id = $1 AND plan_id IS NULL`, used).Scan(&kept)
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM turns WHERE id = $1`, unused).Scan(&removed)
+	if plans != 0 {
+		t.Error("plan still exists")
+	}
+	if kept != 1 {
+		t.Error("used turn should be kept and detached from the plan")
+	}
+	if removed != 0 {
+		t.Error("unused turn should be deleted")
+	}
+}
+
+func TestArchivePlan(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	used, unused := seedPlan(t, pool, "archive-me", "completed")
+	defer func() {
+		DeletePlan(ctx, pool, "archive-me", true, true)
+		pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = $1`, used)
+		pool.Exec(ctx, `DELETE FROM turns WHERE id = ANY($1)`, []string{used, unused})
+	}()
+
+	if err := ArchivePlan(ctx, pool, "archive-me"); err != nil {
+		t.Fatalf("ArchivePlan: %v", err)
+	}
+
+	var status string
+	pool.QueryRow(ctx, `SELECT status::text FROM execution_plans WHERE name = 'archive-me'`).Scan(&status)
+	if status != "ARCHIVED" {
+		t.Errorf("status = %q, want ARCHIVED", status)
+	}
+
+	if err := ArchivePlan(ctx, pool, "no-such-plan"); err == nil {
+		t.Error("expected not-found error")
+	}
+}
+
+func TestRecordDecisionRequiresActivePlan(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	used, unused := seedPlan(t, pool, "decide-me", "completed")
+	defer func() {
+		DeletePlan(ctx, pool, "decide-me", true, true)
+		pool.Exec(ctx, `DELETE FROM turn_regions WHERE turn_id = $1`, used)
+		pool.Exec(ctx, `DELETE FROM turns WHERE id = ANY($1)`, []string{used, unused})
+	}()
+
+	planID, err := LookupPlanID(ctx, pool, "decide-me")
+	if err != nil {
+		t.Fatalf("LookupPlanID: %v", err)
+	}
+	m := New(pool, nil, t.TempDir())
+	dec := gam.Decision{Description: "use btree", Rationale: "simple", Alternatives: []string{"hash"}}
+	if err := m.RecordDecision(ctx, planID, dec); err != nil {
+		t.Fatalf("RecordDecision on an active plan: %v", err)
+	}
+
+	if err := ArchivePlan(ctx, pool, "decide-me"); err != nil {
+		t.Fatalf("ArchivePlan: %v", err)
+	}
+	if err := m.RecordDecision(ctx, planID, dec); err == nil {
+		t.Error("expected an error recording a decision in an archived plan")
+	}
+
+	var n int
+	pool.QueryRow(ctx, `SELECT jsonb_array_length(decisions) FROM execution_plans WHERE id = $1`, planID).Scan(&n)
+	if n != 1 {
+		t.Errorf("decisions = %d, want only the one recorded while active", n)
+	}
+}
+
+func TestNextPlanTurnsDiamond(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	var planID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO execution_plans (name, goal, status) VALUES ('next-diamond', 'test', 'ACTIVE') RETURNING id
+	`).Scan(&planID); err != nil {
+		t.Fatalf("insert plan: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM execution_plans WHERE id = $1`, planID)
+
+	// a -> {b, c} -> d, with a and b done: only c is unblocked.
+	a, b, c, d := GenerateTurnID()+"a", GenerateTurnID()+"b", GenerateTurnID()+"c", GenerateTurnID()+"d"
+	steps := []struct {
+		id, status string
+		deps       []string
+	}{
+		{a, "completed", nil},
+		{b, "completed", []string{a}},
+		{c, "pending", []string{a}},
+		{d, "pending", []string{b, c}},
+	}
+	for i, s := range steps {
+		pool.Exec(ctx, `INSERT INTO turns (id, agent_role, scope_path, status, plan_id) VALUES ($1, 'researcher', 'plantest', 'ACTIVE', $2)`, s.id, planID)
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO plan_turns (plan_id, turn_id, region_path, ordering, depends_on, status)
+			VALUES ($1, $2, 'plantest', $3, $4, $5)
+		`, planID, s.id, i, s.deps, s.status); err != nil {
+			t.Fatalf("insert plan turn: %v", err)
+		}
+	}
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = ANY($1)`, []string{a, b, c, d})
+	defer pool.Exec(ctx, `DELETE FROM plan_turns WHERE plan_id = $1`, planID)
+
+	turns, err := NextPlanTurns(ctx, pool, "next-diamond")
+	if err != nil {
+		t.Fatalf("NextPlanTurns: %v", err)
+	}
+	if len(turns) != 1 || turns[0].TurnID != c {
+		t.Errorf("next turns = %+v, want only %s", turns, c)
+	}
+}
+
+// recordingPusher records pushed tasks in place of a Redis queue.
+type recordingPusher struct {
+	pushed []queue.TaskMessage
+}
+
+func (r *recordingPusher) PushTask(ctx context.Context, msg queue.TaskMessage) (string, error) {
+	r.pushed = append(r.pushed, msg)
+	return fmt.Sprintf("%d-0", len(r.pushed)), nil
+}
+
+func TestReplayStalledPlanTurns(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	var planID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO execution_plans (name, goal, status) VALUES ('replay-stalled', 'test', 'ACTIVE') RETURNING id
+	`).Scan(&planID); err != nil {
+		t.Fatalf("insert plan: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM execution_plans WHERE id = $1`, planID)
+
+	pool.Exec(ctx, `INSERT INTO regions (path) VALUES ('replaytest') ON CONFLICT (path) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'replaytest'`)
+
+	// stalled is active with no proposal; busy proposed recently; done has
+	// completed; waiting has not been queued yet.
+	stalled, busy, done, waiting := GenerateTurnID()+"s", GenerateTurnID()+"b", GenerateTurnID()+"d", GenerateTurnID()+"w"
+	steps := []struct{ id, status string }{
+		{stalled, "active"}, {busy, "active"}, {done, "completed"}, {waiting, "pending"},
+	}
+	for i, s := range steps {
+		pool.Exec(ctx, `INSERT INTO turns (id, agent_role, scope_path, status, plan_id, task_type) VALUES ($1, 'researcher', 'replaytest', 'ACTIVE', $2, 'implement')`, s.id, planID)
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO plan_turns (plan_id, turn_id, region_path, ordering, status)
+			VALUES ($1, $2, 'replaytest', $3, $4)
+		`, planID, s.id, i, s.status); err != nil {
+			t.Fatalf("insert plan turn: %v", err)
+		}
+	}
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = ANY($1)`, []string{stalled, busy, done, waiting})
+	defer pool.Exec(ctx, `DELETE FROM plan_turns WHERE plan_id = $1`, planID)
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO proposals (turn_id, region_id, action_taken, evidence)
+		SELECT $1, id, 'implement', '{}' FROM regions WHERE path = 'replaytest'
+	`, busy); err != nil {
+		t.Fatalf("insert proposal: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM proposals WHERE turn_id = $1`, busy)
+
+	q := &recordingPusher{}
+	tasks, err := replayStalledPlanTurns(ctx, pool, q, "replay-stalled", time.Hour, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(tasks) != 1 || len(q.pushed) != 0 {
+		t.Fatalf("dry run: tasks = %+v, pushed = %+v", tasks, q.pushed)
+	}
+
+	tasks, err = replayStalledPlanTurns(ctx, pool, q, "replay-stalled", time.Hour, false)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	want := queue.TaskMessage{TurnID: stalled, RegionPath: "replaytest", TaskType: "implement"}
+	if len(q.pushed) != 1 || q.pushed[0].Replay == "" {
+		t.Fatalf("pushed = %+v, want only %+v with a replay token", q.pushed, want)
+	}
+	got := q.pushed[0]
+	got.Replay = ""
+	if got != want {
+		t.Fatalf("pushed = %+v, want %+v", q.pushed[0], want)
+	}
+	original := want.IdempotencyKey()
+	if len(tasks) != 1 || tasks[0].IdempotencyKey() == original {
+		t.Errorf("replayed task should get a fresh idempotency key, not %s: %+v", original, tasks)
+	}
+
+	time.Sleep(time.Millisecond)
+	if _, err := replayStalledPlanTurns(ctx, pool, q, "replay-stalled", time.Hour, false); err != nil {
+		t.Fatalf("second replay: %v", err)
+	}
+	if len(q.pushed) != 2 || q.pushed[1].IdempotencyKey() == q.pushed[0].IdempotencyKey() {
+		t.Errorf("each replay should carry its own key: %+v", q.pushed)
+	}
+}
@@ -0,0 +1,182 @@
+package memorizer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/region"
+)
+
+// Golden principle severities. Failing block principles stop turn end; warn
+// principles are only reported.
+const (
+	SeverityWarn  = "warn"
+	SeverityBlock = "block"
+)
+
+// RunGoldenPrinciples executes the lint check of every enabled golden principle
+// and returns a finding for each check that exits non-zero. When regionPath is
+// set, checks receive the files carrying markers for that region subtree as
+// positional arguments; otherwise they run once against the whole project and
+// their findings, having no region, are not mechanical.
+func (m *Memorizer) RunGoldenPrinciples(ctx context.Context, regionPath string) ([]GardenFinding, error) {
+	return LintGoldenPrinciples(ctx, m.db, m.projectRoot, regionPath)
+}
+
+// LintGoldenPrinciples is RunGoldenPrinciples for callers without a Memorizer,
+// such as the turn-end validation gate.
+func LintGoldenPrinciples(ctx context.Context, pool *pgxpool.Pool, projectRoot, regionPath string) ([]GardenFinding, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT id, name, rule, COALESCE(lint_check, ''), remediation, severity, enabled
+		FROM golden_principles
+		WHERE enabled = true AND COALESCE(lint_check, '') != ''
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var principles []gam.GoldenPrinciple
+	for rows.Next() {
+		var p gam.GoldenPrinciple
+		if err := rows.Scan(&p.ID, &p.Name, &p.Rule, &p.LintCheck, &p.Remediation, &p.Severity, &p.Enabled); err != nil {
+			return nil, err
+		}
+		principles = append(principles, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if regionPath != "" {
+		files = regionFiles(projectRoot, regionPath)
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no source files found for region %s", regionPath)
+		}
+	}
+
+	return lintPrinciples(ctx, projectRoot, principles, regionPath, files), nil
+}
+
+// BlockingFindings returns the findings from block-severity principles.
+func BlockingFindings(findings []GardenFinding) []GardenFinding {
+	var blocking []GardenFinding
+	for _, f := range findings {
+		if f.Severity == SeverityBlock {
+			blocking = append(blocking, f)
+		}
+	}
+	return blocking
+}
+
+// ValidSeverity reports whether s is a known golden principle severity.
+func ValidSeverity(s string) bool {
+	return s == SeverityWarn || s == SeverityBlock
+}
+
+// lintPrinciples runs each principle's lint check through sh in projectRoot.
+// The region path is exported as GAM_REGION and files are passed as "$@".
+func lintPrinciples(ctx context.Context, projectRoot string, principles []gam.GoldenPrinciple, regionPath string, files []string) []GardenFinding {
+	var findings []GardenFinding
+	for _, p := range principles {
+		if !p.Enabled || p.LintCheck == "" {
+			continue
+		}
+
+		args := append([]string{"-c", p.LintCheck, "sh"}, files...)
+		cmd := exec.CommandContext(ctx, "sh", args...)
+		cmd.Dir = projectRoot
+		cmd.Env = append(os.Environ(), "GAM_REGION="+regionPath)
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+
+		if err := cmd.Run(); err != nil {
+			severity := p.Severity
+			if severity == "" {
+				severity = SeverityWarn
+			}
+			desc := fmt.Sprintf("Golden principle %s violated: %s", p.Name, p.Rule)
+			if output := strings.TrimSpace(out.String()); output != "" {
+				desc += "\n" + truncate(output, 500)
+			}
+			findings = append(findings, GardenFinding{
+				RegionPath:  regionPath,
+				Category:    "golden_principle",
+				Description: desc,
+				Fix:         p.Remediation,
+				Severity:    severity,
+				// A project-wide run has no region to queue a fix-up turn on.
+				Mechanical: regionPath != "",
+			})
+		}
+	}
+	return findings
+}
+
+// regionFiles returns the project-relative files containing markers for
+// regionPath or any region beneath it.
+func regionFiles(projectRoot, regionPath string) []string {
+	gamignore := region.ParseGamignore(projectRoot)
+	markers, _, _ := region.ScanDirectory(projectRoot, gamignore)
+
+	seen := make(map[string]bool)
+	var files []string
+	var walk func(ms []*region.RegionMarker)
+	walk = func(ms []*region.RegionMarker) {
+		for _, mk := range ms {
+			if hookInScope(regionPath, mk.Path) && !seen[mk.File] {
+				seen[mk.File] = true
+				files = append(files, mk.File)
+			}
+			walk(mk.Children)
+		}
+	}
+	walk(markers)
+	sort.Strings(files)
+	return files
+}
+
+// SetPrincipleEnabled toggles the enabled flag of the named golden principle.
+func SetPrincipleEnabled(ctx context.Context, pool *pgxpool.Pool, name string, enabled bool) error {
+	tag, err := pool.Exec(ctx, `UPDATE golden_principles SET enabled = $2 WHERE name = $1`, name, enabled)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("principle '%s' not found", name)
+	}
+	return nil
+}
+
+// RemovePrinciple deletes the named golden principle.
+func RemovePrinciple(ctx context.Context, pool *pgxpool.Pool, name string) error {
+	tag, err := pool.Exec(ctx, `DELETE FROM golden_principles WHERE name = $1`, name)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("principle '%s' not found", name)
+	}
+	return nil
+}
+
+// PrincipleInDocs reports whether the exported docs/quality/golden-principles.md
+// still lists the named principle.
+func PrincipleInDocs(projectRoot, name string) bool {
+	data, err := os.ReadFile(filepath.Join(projectRoot, "docs", "quality", "golden-principles.md"))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), fmt.Sprintf("## %s (", name))
+}
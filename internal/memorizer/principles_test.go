@@ -0,0 +1,171 @@
+package memorizer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestLintPrinciplesReportsFailingCheck(t *testing.T) {
+	root := t.TempDir()
+	principles := []gam.GoldenPrinciple{
+		{Name: "passes", Rule: "always fine", LintCheck: "true", Remediation: "nothing", Enabled: true},
+		{Name: "no-println", Rule: "Use the logger", LintCheck: `! grep -Hn Println "$@"`, Remediation: "Replace fmt.Println with log calls.", Enabled: true},
+		{Name: "disabled", Rule: "ignored", LintCheck: "false", Remediation: "n/a", Enabled: false},
+	}
+
+	src := filepath.Join(root, "main.go")
+	os.WriteFile(src, []byte("package main\n\nfunc main() { fmt.Println(\"hi\") }\n"), 0644)
+
+	findings := lintPrinciples(context.Background(), root, principles, "app.main", []string{"main.go"})
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.Category != "golden_principle" || f.RegionPath != "app.main" {
+		t.Errorf("finding = %+v", f)
+	}
+	if !f.Mechanical {
+		t.Error("a region-scoped finding should be mechanical")
+	}
+	if f.Fix != "Replace fmt.Println with log calls." {
+		t.Errorf("Fix = %q, want the principle remediation", f.Fix)
+	}
+	if !strings.Contains(f.Description, "no-println") || !strings.Contains(f.Description, "main.go:3") {
+		t.Errorf("Description = %q, want principle name and lint output", f.Description)
+	}
+}
+
+func TestRegionFiles(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "search"), 0755)
+	os.WriteFile(filepath.Join(root, "search", "a.go"), []byte("// @region:app.search.sources\npackage search\n// @endregion:app.search.sources\n"), 0644)
+	os.WriteFile(filepath.Join(root, "search", "b.go"), []byte("// @region:app.search\npackage search\n// @endregion:app.search\n"), 0644)
+	os.WriteFile(filepath.Join(root, "billing.go"), []byte("// @region:app.billing\npackage billing\n// @endregion:app.billing\n"), 0644)
+
+	got := regionFiles(root, "app.search")
+	want := []string{filepath.Join("search", "a.go"), filepath.Join("search", "b.go")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("regionFiles = %v, want %v", got, want)
+	}
+}
+
+func TestPrincipleInDocs(t *testing.T) {
+	root := t.TempDir()
+	if PrincipleInDocs(root, "no-println") {
+		t.Error("expected false without exported docs")
+	}
+
+	os.MkdirAll(filepath.Join(root, "docs", "quality"), 0755)
+	os.WriteFile(filepath.Join(root, "docs", "quality", "golden-principles.md"),
+		[]byte("# Golden Principles\n\n## no-println (enabled)\n\n**Rule**: Use the logger\n\n"), 0644)
+
+	if !PrincipleInDocs(root, "no-println") {
+		t.Error("expected no-println to be found in docs")
+	}
+	if PrincipleInDocs(root, "no-print") {
+		t.Error("expected prefix name not to match")
+	}
+}
+
+func TestPrincipleToggleAndRemove(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	pool.Exec(ctx, `
+		INSERT INTO golden_principles (name, rule, remediation, enabled)
+		VALUES ('test-principle', 'rule', 'fix it', true)
+		ON CONFLICT (name) DO UPDATE SET enabled = true
+	`)
+	defer pool.Exec(ctx, `DELETE FROM golden_principles WHERE name = 'test-principle'`)
+
+	enabled := func() bool {
+		var e bool
+		if err := pool.QueryRow(ctx, `SELECT enabled FROM golden_principles WHERE name = 'test-principle'`).Scan(&e); err != nil {
+			t.Fatalf("query enabled: %v", err)
+		}
+		return e
+	}
+
+	if err := SetPrincipleEnabled(ctx, pool, "test-principle", false); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	if enabled() {
+		t.Error("principle still enabled after disable")
+	}
+	if err := SetPrincipleEnabled(ctx, pool, "test-principle", true); err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+	if !enabled() {
+		t.Error("principle still disabled after enable")
+	}
+
+	if err := RemovePrinciple(ctx, pool, "test-principle"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := RemovePrinciple(ctx, pool, "test-principle"); err == nil {
+		t.Error("expected not-found error removing twice")
+	}
+	if err := SetPrincipleEnabled(ctx, pool, "test-principle", true); err == nil {
+		t.Error("expected not-found error toggling a removed principle")
+	}
+}
+
+func TestBlockingPrincipleFailsGate(t *testing.T) {
+	principles := []gam.GoldenPrinciple{
+		{Name: "warn-only", Rule: "prefer small files", LintCheck: "false", Remediation: "split it", Severity: SeverityWarn, Enabled: true},
+		{Name: "no-secrets", Rule: "never commit keys", LintCheck: "false", Remediation: "remove the key", Severity: SeverityBlock, Enabled: true},
+	}
+
+	findings := lintPrinciples(context.Background(), t.TempDir(), principles, "", nil)
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2", len(findings))
+	}
+
+	blocking := BlockingFindings(findings)
+	if len(blocking) != 1 || !strings.Contains(blocking[0].Description, "no-secrets") {
+		t.Errorf("blocking = %+v, want only no-secrets", blocking)
+	}
+}
+
+func TestWarnPrincipleDoesNotFailGate(t *testing.T) {
+	principles := []gam.GoldenPrinciple{
+		{Name: "warn-only", Rule: "prefer small files", LintCheck: "false", Remediation: "split it", Severity: SeverityWarn, Enabled: true},
+		{Name: "unset-severity", Rule: "legacy", LintCheck: "false", Remediation: "n/a", Enabled: true},
+		{Name: "passing-block", Rule: "never commit keys", LintCheck: "true", Remediation: "n/a", Severity: SeverityBlock, Enabled: true},
+	}
+
+	findings := lintPrinciples(context.Background(), t.TempDir(), principles, "", nil)
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2", len(findings))
+	}
+	for _, f := range findings {
+		if f.Severity != SeverityWarn {
+			t.Errorf("%s: Severity = %q, want warn", f.Description, f.Severity)
+		}
+	}
+	if blocking := BlockingFindings(findings); len(blocking) != 0 {
+		t.Errorf("blocking = %+v, want none", blocking)
+	}
+}
+
+func TestProjectWidePrincipleFindingsAreNotMechanical(t *testing.T) {
+	principles := []gam.GoldenPrinciple{
+		{Name: "no-todo", Rule: "no TODOs", LintCheck: "false", Remediation: "resolve it", Enabled: true},
+	}
+
+	// The gardener lints the whole project; a fix-up turn needs a region.
+	findings := lintPrinciples(context.Background(), t.TempDir(), principles, "", nil)
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1", len(findings))
+	}
+	if findings[0].RegionPath != "" || findings[0].Mechanical {
+		t.Errorf("finding = %+v, want a region-less finding that is not queued", findings[0])
+	}
+}
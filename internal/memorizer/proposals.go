@@ -0,0 +1,156 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/queue"
+)
+
+// RetryProposal resets a REJECTED proposal to PENDING, clears its rejection,
+// and increments its attempt counter, then hands the message to push so the
+// Memorizer validates the proposal again. The reset commits only after push
+// succeeds, so a failed push leaves the proposal REJECTED and retryable.
+func RetryProposal(ctx context.Context, pool *pgxpool.Pool, id string, push func(context.Context, queue.ProposalMessage) error) (*queue.ProposalMessage, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	msg := &queue.ProposalMessage{ProposalID: id}
+	var turnID *string
+	err = tx.QueryRow(ctx, `
+		UPDATE proposals p
+		SET status = 'PENDING', attempts = p.attempts + 1,
+		    validation_error_code = NULL, violation_details = NULL, rejection_reason = NULL,
+		    validation_result = NULL
+		FROM regions r
+		WHERE p.id = $1 AND p.status = 'REJECTED' AND r.id = p.region_id
+		RETURNING p.turn_id, r.path::text, p.attempts
+	`, id).Scan(&turnID, &msg.RegionPath, &msg.Attempt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		var status string
+		if pool.QueryRow(ctx, `SELECT status::text FROM proposals WHERE id = $1`, id).Scan(&status) != nil {
+			return nil, fmt.Errorf("proposal %s not found", id)
+		}
+		return nil, fmt.Errorf("proposal %s is %s; only REJECTED proposals can be retried", id, status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if turnID != nil {
+		msg.TurnID = *turnID
+	}
+
+	if err := push(ctx, *msg); err != nil {
+		return nil, fmt.Errorf("queue retry: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// ProposalResult returns the ValidationResult recorded for a proposal's most
+// recent validation. Proposals rejected before results were stored are
+// reconstructed from their error code, violation details, and briefing.
+func ProposalResult(ctx context.Context, pool *pgxpool.Pool, id string) (*gam.ValidationResult, error) {
+	var status, reason string
+	var code *int
+	var resultJSON, detailsJSON []byte
+	err := pool.QueryRow(ctx, `
+		SELECT status::text, validation_result, validation_error_code, violation_details, COALESCE(rejection_reason, '')
+		FROM proposals WHERE id = $1
+	`, id).Scan(&status, &resultJSON, &code, &detailsJSON, &reason)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("proposal %s not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resultJSON != nil {
+		var result gam.ValidationResult
+		if err := json.Unmarshal(resultJSON, &result); err != nil {
+			return nil, fmt.Errorf("decode validation result for proposal %s: %w", id, err)
+		}
+		return &result, nil
+	}
+	if status == "REJECTED" && code != nil {
+		return legacyResult(*code, detailsJSON, reason), nil
+	}
+	return nil, fmt.Errorf("proposal %s is %s and has no recorded validation result", id, status)
+}
+
+// legacyResult rebuilds a rejected ValidationResult from the columns written
+// by rejectProposal: the briefing starts "REJECTION (Tier N, Code C)" and its
+// second line is the result message.
+func legacyResult(code int, detailsJSON []byte, briefing string) *gam.ValidationResult {
+	result := &gam.ValidationResult{Code: code}
+	header, rest, _ := strings.Cut(briefing, "\n")
+	var headerCode int
+	fmt.Sscanf(header, "REJECTION (Tier %d, Code %d)", &result.Tier, &headerCode)
+	result.Message, _, _ = strings.Cut(rest, "\n")
+	json.Unmarshal(detailsJSON, &result.Details)
+	return result
+}
+
+// LoadProposal returns a proposal's status, region, and review state for
+// display: the review iteration count, review history, and any rejection or
+// escalation reason, along with its evidence and the branch and commit it
+// was made on.
+func LoadProposal(ctx context.Context, pool *pgxpool.Pool, id string) (*gam.Proposal, error) {
+	var p gam.Proposal
+	var historyJSON, evidenceJSON []byte
+	err := pool.QueryRow(ctx, `
+		SELECT p.id, COALESCE(p.turn_id, ''), r.path::text, p.action_taken,
+		       COALESCE(p.current_state, ''), COALESCE(p.proposed_state, ''), p.status::text,
+		       COALESCE(p.review_iterations, 0), p.review_history, COALESCE(p.rejection_reason, ''), p.created_at,
+		       p.evidence, COALESCE(p.branch_name, ''), COALESCE(p.commit_sha, '')
+		FROM proposals p
+		JOIN regions r ON r.id = p.region_id
+		WHERE p.id = $1
+	`, id).Scan(&p.ID, &p.TurnID, &p.RegionPath, &p.ActionTaken, &p.CurrentState, &p.ProposedState,
+		&p.Status, &p.ReviewIterations, &historyJSON, &p.RejectionReason, &p.CreatedAt,
+		&evidenceJSON, &p.BranchName, &p.CommitSHA)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("proposal %s not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if historyJSON != nil {
+		if err := json.Unmarshal(historyJSON, &p.ReviewHistory); err != nil {
+			return nil, fmt.Errorf("decode review history for proposal %s: %w", id, err)
+		}
+	}
+	if err := json.Unmarshal(evidenceJSON, &p.Evidence); err != nil {
+		return nil, fmt.Errorf("decode evidence for proposal %s: %w", id, err)
+	}
+	return &p, nil
+}
+
+// LinkProposal records the git branch and commit a proposal's changes live
+// on. Empty values leave the stored field unchanged.
+func LinkProposal(ctx context.Context, pool *pgxpool.Pool, id, branch, commit string) error {
+	tag, err := pool.Exec(ctx, `
+		UPDATE proposals
+		SET branch_name = COALESCE(NULLIF($2, ''), branch_name),
+		    commit_sha = COALESCE(NULLIF($3, ''), commit_sha)
+		WHERE id = $1
+	`, id, branch, commit)
+	if err != nil {
+		return fmt.Errorf("link proposal %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("proposal %s not found", id)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/queue"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+	"github.com/sbenjam1n/gamsync/internal/validator"
+)
+
+func TestRetryRejectedProposal(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	m := &Memorizer{
+		db:          pool,
+		validator:   validator.New(pool, dir),
+		hooks:       NewHookRegistry(pool, dir),
+		projectRoot: dir,
+	}
+
+	var regionID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO regions (path) VALUES ('retrytest') ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path RETURNING id
+	`).Scan(&regionID); err != nil {
+		t.Fatalf("insert region: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'retrytest'`)
+
+	// The evidence points at a file that lacks region markers.
+	file := filepath.Join(dir, "retry.go")
+	os.WriteFile(file, []byte("package retry\n"), 0644)
+	evidence, _ := json.Marshal(gam.ProposalEvidence{
+		ModifiedRegions: []gam.ModifiedRegion{{Path: "retrytest", File: file}},
+		Summary:         "retry test",
+	})
+
+	var id string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO proposals (region_id, action_taken, evidence) VALUES ($1, 'modify', $2) RETURNING id
+	`, regionID, evidence).Scan(&id); err != nil {
+		t.Fatalf("insert proposal: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM proposals WHERE id = $1`, id)
+
+	status := func() string {
+		var s string
+		pool.QueryRow(ctx, `SELECT status::text FROM proposals WHERE id = $1`, id).Scan(&s)
+		return s
+	}
+
+	if err := m.processProposal(ctx, id, "retrytest"); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+	if got := status(); got != "REJECTED" {
+		t.Fatalf("status = %s, want REJECTED before the fix", got)
+	}
+
+	checkRejectionJSON(t, ctx, pool, id)
+
+	// A retry whose push fails must leave the proposal REJECTED so it can be
+	// retried again.
+	failPush := func(context.Context, queue.ProposalMessage) error { return errors.New("redis down") }
+	if _, err := RetryProposal(ctx, pool, id, failPush); err == nil {
+		t.Fatal("expected error when the retry push fails")
+	}
+	if got := status(); got != "REJECTED" {
+		t.Fatalf("status after failed push = %s, want REJECTED", got)
+	}
+	if _, err := ProposalResult(ctx, pool, id); err != nil {
+		t.Errorf("failed push cleared the rejection: %v", err)
+	}
+
+	if _, err := RetryProposal(ctx, pool, id, noPush); err != nil {
+		t.Fatalf("RetryProposal: %v", err)
+	}
+	if _, err := ProposalResult(ctx, pool, id); err == nil {
+		t.Error("retried proposal should have no result until validated again")
+	}
+	if m.processProposal(ctx, id, "retrytest"); status() != "REJECTED" {
+		t.Fatalf("unfixed retry should be rejected again, got %s", status())
+	}
+
+	// Fix the underlying condition, then retry.
+	os.WriteFile(file, []byte("package retry\n\n// @region:retrytest\nfunc F() {}\n// @endregion:retrytest\n"), 0644)
+
+	msg, err := RetryProposal(ctx, pool, id, noPush)
+	if err != nil {
+		t.Fatalf("RetryProposal: %v", err)
+	}
+	if msg.Attempt != 3 || msg.RegionPath != "retrytest" {
+		t.Errorf("retry message = %+v, want attempt 3 on retrytest", msg)
+	}
+	if got := status(); got != "PENDING" {
+		t.Errorf("status after retry = %s, want PENDING", got)
+	}
+
+	if err := m.processProposal(ctx, id, msg.RegionPath); err != nil {
+		t.Fatalf("process retry: %v", err)
+	}
+	if got := status(); got != "APPROVED" {
+		t.Errorf("status = %s, want APPROVED after fix and retry", got)
+	}
+
+	if _, err := RetryProposal(ctx, pool, id, noPush); err == nil {
+		t.Error("expected error retrying an approved proposal")
+	}
+	if result, err := ProposalResult(ctx, pool, id); err != nil || !result.Passed {
+		t.Errorf("approved proposal result = %+v, %v; want passed", result, err)
+	}
+}
+
+// noPush stands in for a queue push that always succeeds.
+func noPush(context.Context, queue.ProposalMessage) error { return nil }
+
+// checkRejectionJSON asserts the stored result of a rejected proposal encodes
+// a fix for every failed detail.
+func checkRejectionJSON(t *testing.T, ctx context.Context, pool *pgxpool.Pool, id string) {
+	t.Helper()
+	result, err := ProposalResult(ctx, pool, id)
+	if err != nil {
+		t.Fatalf("ProposalResult: %v", err)
+	}
+	if result.Passed || result.Code != 3 || result.Tier != 0 {
+		t.Errorf("result = %+v, want tier 0 code 3 rejection", result)
+	}
+
+	data, _ := json.Marshal(result)
+	var decoded struct {
+		Details []map[string]any `json:"details"`
+	}
+	json.Unmarshal(data, &decoded)
+	failed := 0
+	for _, d := range decoded.Details {
+		if d["passed"] == true {
+			continue
+		}
+		failed++
+		if fix, _ := d["fix"].(string); fix == "" {
+			t.Errorf("failed detail %v has no fix in JSON", d)
+		}
+	}
+	if failed == 0 {
+		t.Errorf("no failed details in %s", data)
+	}
+}
+
+func TestLegacyResult(t *testing.T) {
+	briefing := "REJECTION (Tier 1, Code -2)\nIllegal transition: a -> b via x in concept C\n  Check: state_transition | Expected: e | Got: g\n  Fix: use y"
+	details := []byte(`[{"check":"state_transition","passed":false,"expected":"e","got":"g","fix":"use y"}]`)
+
+	got := legacyResult(-2, details, briefing)
+	if got.Tier != 1 || got.Code != -2 || got.Passed {
+		t.Errorf("tier/code/passed = %d/%d/%v", got.Tier, got.Code, got.Passed)
+	}
+	if got.Message != "Illegal transition: a -> b via x in concept C" {
+		t.Errorf("message = %q", got.Message)
+	}
+	if len(got.Details) != 1 || got.Details[0].Fix != "use y" {
+		t.Errorf("details = %+v", got.Details)
+	}
+}
+
+func TestLinkProposal(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	var regionID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO regions (path) VALUES ('linktest') ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path RETURNING id
+	`).Scan(&regionID); err != nil {
+		t.Fatalf("insert region: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'linktest'`)
+
+	var id string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO proposals (region_id, action_taken, evidence) VALUES ($1, 'modify', '{}') RETURNING id
+	`, regionID).Scan(&id); err != nil {
+		t.Fatalf("insert proposal: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM proposals WHERE id = $1`, id)
+
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	if err := LinkProposal(ctx, pool, id, "proposal/linktest", sha); err != nil {
+		t.Fatalf("LinkProposal: %v", err)
+	}
+	// Linking only a new branch keeps the commit.
+	if err := LinkProposal(ctx, pool, id, "proposal/linktest-v2", ""); err != nil {
+		t.Fatalf("LinkProposal branch only: %v", err)
+	}
+
+	p, err := LoadProposal(ctx, pool, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.BranchName != "proposal/linktest-v2" || p.CommitSHA != sha {
+		t.Errorf("branch=%q commit=%q", p.BranchName, p.CommitSHA)
+	}
+
+	if err := LinkProposal(ctx, pool, "00000000-0000-0000-0000-000000000000", "b", ""); err == nil {
+		t.Error("expected error for unknown proposal")
+	}
+}
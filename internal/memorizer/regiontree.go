@@ -0,0 +1,142 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/region"
+)
+
+// DBRegionMarkers converts the regions table into RegionMarkers so the
+// authoritative DB hierarchy can be rendered with region.BuildTree. Each
+// marker is annotated with its lifecycle state and assigned concept count.
+func DBRegionMarkers(ctx context.Context, pool *pgxpool.Pool) ([]*region.RegionMarker, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT r.path::text, COALESCE(r.lifecycle_state, 'draft'), COUNT(cra.concept_id)
+		FROM regions r
+		LEFT JOIN concept_region_assignments cra ON cra.region_id = r.id
+		GROUP BY r.id, r.path, r.lifecycle_state
+		ORDER BY r.path
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var markers []*region.RegionMarker
+	for rows.Next() {
+		var path, lifecycle string
+		var concepts int
+		if err := rows.Scan(&path, &lifecycle, &concepts); err != nil {
+			return nil, err
+		}
+		markers = append(markers, &region.RegionMarker{
+			Path: path,
+			Note: fmt.Sprintf("%s, %d concept(s)", lifecycle, concepts),
+		})
+	}
+	return markers, rows.Err()
+}
+
+// RegionOwners returns the owner of every region that has one, keyed by path.
+func RegionOwners(ctx context.Context, pool *pgxpool.Pool) (map[string]string, error) {
+	rows, err := pool.Query(ctx, `SELECT path::text, owner FROM regions WHERE owner IS NOT NULL ORDER BY path`)
+	if err != nil {
+		return nil, fmt.Errorf("load region owners: %w", err)
+	}
+	defer rows.Close()
+
+	owners := make(map[string]string)
+	for rows.Next() {
+		var path, owner string
+		if err := rows.Scan(&path, &owner); err != nil {
+			return nil, fmt.Errorf("load region owners: %w", err)
+		}
+		owners[path] = owner
+	}
+	return owners, rows.Err()
+}
+
+// SetRegionOwner sets or, when owner is empty, clears the owner of a region.
+func SetRegionOwner(ctx context.Context, pool *pgxpool.Pool, path, owner string) error {
+	tag, err := pool.Exec(ctx, `
+		UPDATE regions SET owner = NULLIF($2, ''), updated_at = NOW() WHERE path = $1
+	`, path, owner)
+	if err != nil {
+		return fmt.Errorf("set owner of %s: %w", path, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("region %s not found", path)
+	}
+	return nil
+}
+
+// SetRegionDescription sets or, when desc is empty, clears the description
+// of a region.
+func SetRegionDescription(ctx context.Context, pool *pgxpool.Pool, path, desc string) error {
+	tag, err := pool.Exec(ctx, `
+		UPDATE regions SET description = NULLIF($2, ''), updated_at = NOW() WHERE path = $1
+	`, path, desc)
+	if err != nil {
+		return fmt.Errorf("set description of %s: %w", path, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("region %s not found", path)
+	}
+	return nil
+}
+
+// KnownRegionFiles returns the source files that held markers for regionPath
+// or its descendants in the most recent turn's tree_after snapshot. It returns
+// nil when no turn has recorded a snapshot or the region is not in it.
+func KnownRegionFiles(ctx context.Context, pool *pgxpool.Pool, regionPath string) ([]string, error) {
+	var snapJSON []byte
+	err := pool.QueryRow(ctx, `
+		SELECT tree_after FROM turns
+		WHERE tree_after IS NOT NULL
+		ORDER BY completed_at DESC NULLS LAST, created_at DESC
+		LIMIT 1
+	`).Scan(&snapJSON)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load tree snapshot: %w", err)
+	}
+
+	var snapshot map[string][]string
+	if err := json.Unmarshal(snapJSON, &snapshot); err != nil {
+		return nil, fmt.Errorf("parse tree snapshot: %w", err)
+	}
+	return snapshotFiles(snapshot, regionPath), nil
+}
+
+// snapshotFiles extracts the distinct files, in sorted order, from the
+// "file:start-end" locations recorded for regionPath and its descendants.
+func snapshotFiles(snapshot map[string][]string, regionPath string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for path, locations := range snapshot {
+		if path != regionPath && !strings.HasPrefix(path, regionPath+".") {
+			continue
+		}
+		for _, loc := range locations {
+			file := loc
+			if i := strings.LastIndex(loc, ":"); i >= 0 {
+				file = loc[:i]
+			}
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,75 @@
+package memorizer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestDBRegionMarkersTree(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	for _, r := range []struct{ path, lifecycle string }{
+		{"treetest", "active"},
+		{"treetest.api", "draft"},
+		{"treetest.api.handlers", "draft"},
+		{"treetest.store", "deprecated"},
+	} {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO regions (path, lifecycle_state) VALUES ($1, $2)
+			ON CONFLICT (path) DO UPDATE SET lifecycle_state = $2
+		`, r.path, r.lifecycle); err != nil {
+			t.Fatalf("insert region %s: %v", r.path, err)
+		}
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'treetest'`)
+
+	markers, err := DBRegionMarkers(ctx, pool)
+	if err != nil {
+		t.Fatalf("DBRegionMarkers: %v", err)
+	}
+
+	tree := region.BuildTree(markers)
+	var top *region.TreeNode
+	for _, n := range tree.Children {
+		if n.FullPath == "treetest" {
+			top = n
+		}
+	}
+	if top == nil {
+		t.Fatal("treetest missing from tree")
+	}
+	if len(top.Children) != 2 || top.Children[0].Name != "api" || top.Children[1].Name != "store" {
+		t.Fatalf("treetest children = %+v, want api and store", top.Children)
+	}
+	api := top.Children[0]
+	if len(api.Children) != 1 || api.Children[0].FullPath != "treetest.api.handlers" {
+		t.Errorf("api children = %+v, want handlers", api.Children)
+	}
+
+	out := region.FormatTree(top, "", true)
+	if !strings.Contains(out, "store    (deprecated, 0 concept(s))") {
+		t.Errorf("tree output missing annotation:\n%s", out)
+	}
+}
+
+func TestSnapshotFiles(t *testing.T) {
+	snapshot := map[string][]string{
+		"app.search":       {"/p/search/search.go:1-40"},
+		"app.search.query": {"/p/search/query.go:3-9", "/p/search/search.go:10-20"},
+		"app.searchx":      {"/p/searchx.go:1-2"},
+		"app.billing":      {"/p/billing.go:1-5"},
+	}
+	got := snapshotFiles(snapshot, "app.search")
+	want := []string{"/p/search/query.go", "/p/search/search.go"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("snapshotFiles = %v, want %v", got, want)
+	}
+	if got := snapshotFiles(snapshot, "app.unknown"); got != nil {
+		t.Errorf("unknown region: got %v, want nil", got)
+	}
+}
@@ -0,0 +1,246 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/queue"
+)
+
+// DefaultMaxReviewIterations bounds the Tier 3 review loop when no limit is
+// configured.
+const DefaultMaxReviewIterations = 3
+
+// Review comment severities (gam.ReviewComment.Severity).
+const (
+	ReviewRequestChanges = "request_changes"
+	ReviewReject         = "reject"
+	ReviewEscalateHuman  = "escalate_human"
+)
+
+// Escalation categories (proposals.escalation_category).
+const (
+	EscalationReviewLimit    = "review_limit"
+	EscalationHumanRequested = "human_requested"
+)
+
+// EscalationDetails is stored in proposals.escalation_details alongside the
+// category, so escalations can be triaged without parsing rejection_reason.
+type EscalationDetails struct {
+	Iteration     int    `json:"iteration"`
+	MaxIterations int    `json:"max_iterations"`
+	Concern       string `json:"concern"`
+	Remediation   string `json:"remediation,omitempty"`
+}
+
+// escalatedPrefix marks a PENDING proposal's rejection_reason as awaiting
+// human review; gam queue escalated lists these.
+const escalatedPrefix = "ESCALATED"
+
+// ReviewOutcome is what RecordReview did with a review comment.
+type ReviewOutcome struct {
+	Iteration int    `json:"iteration"`
+	Status    string `json:"status"`
+	Escalated bool   `json:"escalated"`
+	Category  string `json:"category,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// reviewDecision applies a comment at the given iteration. A request for
+// changes on the last allowed iteration escalates instead, so a proposal
+// goes through at most max review rounds before a human looks at it.
+func reviewDecision(severity string, iteration, max int) (status string, escalated bool, err error) {
+	switch severity {
+	case ReviewRequestChanges:
+		if iteration >= max {
+			return "PENDING", true, nil
+		}
+		return "PENDING", false, nil
+	case ReviewReject:
+		return "REJECTED", false, nil
+	case ReviewEscalateHuman:
+		return "PENDING", true, nil
+	}
+	return "", false, fmt.Errorf("unknown review severity %q (valid: %s, %s, %s)",
+		severity, ReviewRequestChanges, ReviewReject, ReviewEscalateHuman)
+}
+
+// RecordReview appends comment to a PENDING proposal's review history as the
+// next iteration and applies it: reject rejects the proposal, escalate_human
+// escalates it, and request_changes leaves it pending for another round until
+// maxIterations is reached, when it escalates. Values below 1 mean
+// DefaultMaxReviewIterations.
+func RecordReview(ctx context.Context, pool *pgxpool.Pool, id string, comment gam.ReviewComment, maxIterations int) (*ReviewOutcome, error) {
+	if maxIterations < 1 {
+		maxIterations = DefaultMaxReviewIterations
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var status string
+	var iterations int
+	err = tx.QueryRow(ctx, `
+		SELECT status::text, COALESCE(review_iterations, 0) FROM proposals WHERE id = $1 FOR UPDATE
+	`, id).Scan(&status, &iterations)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("proposal %s not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status != "PENDING" {
+		return nil, fmt.Errorf("proposal %s is %s; only PENDING proposals can be reviewed", id, status)
+	}
+
+	out := &ReviewOutcome{Iteration: iterations + 1}
+	out.Status, out.Escalated, err = reviewDecision(comment.Severity, out.Iteration, maxIterations)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.ProposalID = id
+	comment.Iteration = out.Iteration
+	if comment.Tier == 0 {
+		comment.Tier = 3
+	}
+	commentJSON, _ := json.Marshal([]gam.ReviewComment{comment})
+
+	var reason *string
+	switch {
+	case out.Escalated && comment.Severity == ReviewRequestChanges:
+		out.Category = EscalationReviewLimit
+		out.Reason = fmt.Sprintf("%s: review iteration limit (%d) reached: %s", escalatedPrefix, maxIterations, comment.Concern)
+	case out.Escalated:
+		out.Category = EscalationHumanRequested
+		out.Reason = fmt.Sprintf("%s: %s", escalatedPrefix, comment.Concern)
+	case out.Status == "REJECTED":
+		out.Reason = fmt.Sprintf("REVIEW (Tier %d, iteration %d)\n%s", comment.Tier, out.Iteration, comment.Concern)
+		if comment.Remediation != "" {
+			out.Reason += "\n  Fix: " + comment.Remediation
+		}
+	}
+	if out.Reason != "" {
+		reason = &out.Reason
+	}
+	var category *string
+	var detailsJSON []byte
+	if out.Escalated {
+		category = &out.Category
+		detailsJSON, _ = json.Marshal(EscalationDetails{
+			Iteration:     out.Iteration,
+			MaxIterations: maxIterations,
+			Concern:       comment.Concern,
+			Remediation:   comment.Remediation,
+		})
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE proposals
+		SET review_iterations = $2,
+		    review_history = COALESCE(review_history, '[]'::jsonb) || $3::jsonb,
+		    status = $4::proposal_status,
+		    rejection_reason = COALESCE($5, rejection_reason),
+		    escalation_category = COALESCE($6, escalation_category),
+		    escalation_details = COALESCE($7::jsonb, escalation_details)
+		WHERE id = $1
+	`, id, out.Iteration, commentJSON, out.Status, reason, category, detailsJSON); err != nil {
+		return nil, err
+	}
+	return out, tx.Commit(ctx)
+}
+
+// ReviewProposal records a review comment with the configured iteration
+// limit. When changes are requested and the proposal is not escalated, a
+// review_response task on a fresh Researcher turn carries the concern back,
+// with the proposal id as its context reference.
+func (m *Memorizer) ReviewProposal(ctx context.Context, id string, comment gam.ReviewComment) (*ReviewOutcome, error) {
+	out, err := RecordReview(ctx, m.db, id, comment, m.maxReviewIterations)
+	if err != nil {
+		return nil, err
+	}
+	if out.Escalated || out.Status != "PENDING" {
+		return out, nil
+	}
+
+	var regionPath string
+	if err := m.db.QueryRow(ctx, `
+		SELECT r.path::text FROM proposals p JOIN regions r ON r.id = p.region_id WHERE p.id = $1
+	`, id).Scan(&regionPath); err != nil {
+		return out, fmt.Errorf("queue review response: %w", err)
+	}
+
+	turnID := GenerateTurnID()
+	if _, err := m.db.Exec(ctx, `
+		INSERT INTO turns (id, agent_role, scope_path, status, task_type)
+		VALUES ($1, 'researcher', $2, 'ACTIVE', 'review_response')
+	`, turnID, regionPath); err != nil {
+		return out, fmt.Errorf("queue review response: %w", err)
+	}
+	if _, err := m.queue.PushTask(ctx, queue.TaskMessage{
+		TurnID:     turnID,
+		RegionPath: regionPath,
+		ContextRef: id,
+		TaskType:   "review_response",
+		Prompt:     comment.Remediation,
+		Review:     comment.Concern,
+	}); err != nil {
+		return out, fmt.Errorf("queue review response: %w", err)
+	}
+	return out, nil
+}
+
+// EscalatedProposal is a PENDING proposal awaiting human review.
+// Escalations recorded before categories existed have an empty Category.
+type EscalatedProposal struct {
+	ID         string             `json:"id"`
+	RegionPath string             `json:"region_path"`
+	Category   string             `json:"category,omitempty"`
+	Reason     string             `json:"reason"`
+	Details    *EscalationDetails `json:"details,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// EscalatedProposals lists escalated PENDING proposals, newest first, limited
+// to one category when category is non-empty.
+func EscalatedProposals(ctx context.Context, pool *pgxpool.Pool, category string) ([]EscalatedProposal, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT p.id, r.path::text, COALESCE(p.escalation_category, ''), p.rejection_reason,
+		       p.escalation_details, p.created_at
+		FROM proposals p
+		JOIN regions r ON r.id = p.region_id
+		WHERE p.status = 'PENDING' AND p.rejection_reason LIKE $1 || '%'
+		  AND ($2 = '' OR p.escalation_category = $2)
+		ORDER BY p.created_at DESC
+	`, escalatedPrefix, category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []EscalatedProposal
+	for rows.Next() {
+		var e EscalatedProposal
+		var detailsJSON []byte
+		if err := rows.Scan(&e.ID, &e.RegionPath, &e.Category, &e.Reason, &detailsJSON, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if detailsJSON != nil {
+			e.Details = &EscalationDetails{}
+			if err := json.Unmarshal(detailsJSON, e.Details); err != nil {
+				return nil, fmt.Errorf("decode escalation details for proposal %s: %w", e.ID, err)
+			}
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,136 @@
+package memorizer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestReviewDecision(t *testing.T) {
+	for _, tc := range []struct {
+		severity  string
+		iteration int
+		status    string
+		escalated bool
+	}{
+		{ReviewRequestChanges, 1, "PENDING", false},
+		{ReviewRequestChanges, 2, "PENDING", false},
+		{ReviewRequestChanges, 3, "PENDING", true},
+		{ReviewReject, 1, "REJECTED", false},
+		{ReviewEscalateHuman, 1, "PENDING", true},
+	} {
+		status, escalated, err := reviewDecision(tc.severity, tc.iteration, 3)
+		if err != nil || status != tc.status || escalated != tc.escalated {
+			t.Errorf("%s at %d: got %s escalated=%v err=%v, want %s escalated=%v",
+				tc.severity, tc.iteration, status, escalated, err, tc.status, tc.escalated)
+		}
+	}
+	if _, _, err := reviewDecision("approve", 1, 3); err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
+
+func TestRecordReviewEscalatesPastLimit(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	var regionID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO regions (path) VALUES ('reviewtest') ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path RETURNING id
+	`).Scan(&regionID); err != nil {
+		t.Fatalf("insert region: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'reviewtest'`)
+
+	var id string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO proposals (region_id, action_taken, evidence) VALUES ($1, 'modify', '{}') RETURNING id
+	`, regionID).Scan(&id); err != nil {
+		t.Fatalf("insert proposal: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM proposals WHERE id = $1`, id)
+
+	comment := gam.ReviewComment{Concern: "naming is unclear", Severity: ReviewRequestChanges}
+	for i := 1; i <= 2; i++ {
+		out, err := RecordReview(ctx, pool, id, comment, 2)
+		if err != nil {
+			t.Fatalf("review %d: %v", i, err)
+		}
+		if out.Iteration != i || out.Escalated != (i == 2) {
+			t.Errorf("review %d: outcome %+v", i, out)
+		}
+	}
+
+	p, err := LoadProposal(ctx, pool, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != "PENDING" || !strings.HasPrefix(p.RejectionReason, "ESCALATED") {
+		t.Errorf("status=%s reason=%q, want an escalated pending proposal", p.Status, p.RejectionReason)
+	}
+	if p.ReviewIterations != 2 || len(p.ReviewHistory) != 2 || p.ReviewHistory[1].Iteration != 2 || p.ReviewHistory[1].Tier != 3 {
+		t.Errorf("iterations=%d history=%+v", p.ReviewIterations, p.ReviewHistory)
+	}
+}
+
+func TestEscalationCategoryQueryable(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	var regionID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO regions (path) VALUES ('escalationtest') ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path RETURNING id
+	`).Scan(&regionID); err != nil {
+		t.Fatalf("insert region: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path = 'escalationtest'`)
+
+	var id string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO proposals (region_id, action_taken, evidence) VALUES ($1, 'modify', '{}') RETURNING id
+	`, regionID).Scan(&id); err != nil {
+		t.Fatalf("insert proposal: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM proposals WHERE id = $1`, id)
+
+	comment := gam.ReviewComment{Concern: "changes a public contract", Remediation: "ask the owner", Severity: ReviewEscalateHuman}
+	out, err := RecordReview(ctx, pool, id, comment, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Escalated || out.Category != EscalationHumanRequested {
+		t.Fatalf("outcome %+v, want human_requested escalation", out)
+	}
+
+	find := func(category string) *EscalatedProposal {
+		t.Helper()
+		escalated, err := EscalatedProposals(ctx, pool, category)
+		if err != nil {
+			t.Fatalf("EscalatedProposals(%q): %v", category, err)
+		}
+		for i := range escalated {
+			if escalated[i].ID == id {
+				return &escalated[i]
+			}
+		}
+		return nil
+	}
+
+	e := find(EscalationHumanRequested)
+	if e == nil {
+		t.Fatal("escalation missing from human_requested listing")
+	}
+	if e.RegionPath != "escalationtest" || e.Details == nil ||
+		e.Details.Concern != comment.Concern || e.Details.Iteration != 1 || e.Details.MaxIterations != 3 {
+		t.Errorf("escalation = %+v details=%+v", e, e.Details)
+	}
+	if find(EscalationReviewLimit) != nil {
+		t.Error("human_requested escalation listed under review_limit")
+	}
+	if find("") == nil {
+		t.Error("escalation missing from unfiltered listing")
+	}
+}
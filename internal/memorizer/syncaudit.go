@@ -0,0 +1,118 @@
+package memorizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/db"
+)
+
+// Sync audit actions recorded in sync_audit.
+const (
+	SyncAuditAdd     = "add"
+	SyncAuditUpdate  = "update"
+	SyncAuditDelete  = "delete"
+	SyncAuditImport  = "import"
+	SyncAuditEnable  = "enable"
+	SyncAuditDisable = "disable"
+)
+
+// SyncAuditEntry is one recorded change to a synchronization.
+type SyncAuditEntry struct {
+	SyncName  string    `json:"sync_name"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RecordSyncAudit appends an audit row for a change to the named sync. exec
+// should be the transaction making the change so the row commits with it.
+func RecordSyncAudit(ctx context.Context, exec db.Execer, name, action, actor, detail string) error {
+	_, err := exec.Exec(ctx, `
+		INSERT INTO sync_audit (sync_name, action, actor, detail)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+	`, name, action, actor, detail)
+	if err != nil {
+		return fmt.Errorf("record sync audit: %w", err)
+	}
+	return nil
+}
+
+// SyncExists reports whether a synchronization with the given name exists.
+func SyncExists(ctx context.Context, exec db.Execer, name string) (bool, error) {
+	var exists bool
+	err := exec.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM synchronizations WHERE name = $1)`, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("look up sync %s: %w", name, err)
+	}
+	return exists, nil
+}
+
+// SetSyncEnabled enables or disables the named sync and records who did it.
+// It reports whether the state changed; setting a sync to the state it is
+// already in writes no audit row.
+func SetSyncEnabled(ctx context.Context, pool *pgxpool.Pool, name string, enabled bool, actor string) (bool, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var was bool
+	err = tx.QueryRow(ctx, `SELECT COALESCE(enabled, true) FROM synchronizations WHERE name = $1 FOR UPDATE`, name).Scan(&was)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("sync not found: %s", name)
+	}
+	if err != nil {
+		return false, fmt.Errorf("look up sync %s: %w", name, err)
+	}
+	if was == enabled {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE synchronizations SET enabled = $2, updated_at = NOW() WHERE name = $1`, name, enabled); err != nil {
+		return false, fmt.Errorf("update sync %s: %w", name, err)
+	}
+	action := SyncAuditDisable
+	if enabled {
+		action = SyncAuditEnable
+	}
+	if err := RecordSyncAudit(ctx, tx, name, action, actor, ""); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit sync %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// SyncAudit returns the most recent audit entries, newest first, for the
+// named sync or for every sync when name is empty. limit <= 0 means no limit.
+func SyncAudit(ctx context.Context, pool *pgxpool.Pool, name string, limit int) ([]SyncAuditEntry, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT sync_name, action, actor, COALESCE(detail, ''), created_at
+		FROM sync_audit
+		WHERE $1 = '' OR sync_name = $1
+		ORDER BY created_at DESC
+		LIMIT NULLIF($2, 0)
+	`, name, max(limit, 0))
+	if err != nil {
+		return nil, fmt.Errorf("query sync audit: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []SyncAuditEntry
+	for rows.Next() {
+		var e SyncAuditEntry
+		if err := rows.Scan(&e.SyncName, &e.Action, &e.Actor, &e.Detail, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,64 @@
+package memorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestSetSyncEnabledWritesAudit(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	sc := gam.Synchronization{
+		Name:       "TestAuditToggle",
+		WhenClause: []gam.WhenPattern{{Concept: "Web", Action: "request"}},
+		ThenClause: []gam.ThenAction{{Concept: "Web", Action: "respond"}},
+	}
+	if err := UpsertSync(ctx, pool, sc); err != nil {
+		t.Fatalf("upsert sync: %v", err)
+	}
+	pool.Exec(ctx, "DELETE FROM sync_audit WHERE sync_name = $1", sc.Name)
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM synchronizations WHERE name = $1", sc.Name)
+		pool.Exec(ctx, "DELETE FROM sync_audit WHERE sync_name = $1", sc.Name)
+	})
+
+	changed, err := SetSyncEnabled(ctx, pool, sc.Name, false, "alice")
+	if err != nil || !changed {
+		t.Fatalf("disable: changed=%v err=%v", changed, err)
+	}
+	// A no-op toggle records nothing.
+	if changed, err := SetSyncEnabled(ctx, pool, sc.Name, false, "alice"); err != nil || changed {
+		t.Fatalf("repeat disable: changed=%v err=%v", changed, err)
+	}
+	if _, err := SetSyncEnabled(ctx, pool, sc.Name, true, "bob"); err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+
+	entries, err := SyncAudit(ctx, pool, sc.Name, 0)
+	if err != nil {
+		t.Fatalf("SyncAudit: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2: %+v", len(entries), entries)
+	}
+	if entries[0].Action != SyncAuditEnable || entries[0].Actor != "bob" {
+		t.Errorf("newest entry = %+v, want enable by bob", entries[0])
+	}
+	if entries[1].Action != SyncAuditDisable || entries[1].Actor != "alice" {
+		t.Errorf("oldest entry = %+v, want disable by alice", entries[1])
+	}
+
+	var enabled bool
+	pool.QueryRow(ctx, "SELECT enabled FROM synchronizations WHERE name = $1", sc.Name).Scan(&enabled)
+	if !enabled {
+		t.Error("sync still disabled after enable")
+	}
+
+	if _, err := SetSyncEnabled(ctx, pool, "TestAuditMissing", true, "alice"); err == nil {
+		t.Error("expected error for unknown sync")
+	}
+}
@@ -0,0 +1,105 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/db"
+	"github.com/sbenjam1n/gamsync/internal/gam"
+)
+
+// ReindexSyncRefs rebuilds sync_refs from the stored clauses of the named
+// synchronization, or of every synchronization when name is empty, in a single
+// transaction. It returns the number of syncs reindexed.
+func ReindexSyncRefs(ctx context.Context, pool *pgxpool.Pool, name string) (int, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `SELECT name, when_clause, where_clause, then_clause FROM synchronizations`
+	var queryArgs []any
+	if name != "" {
+		query += ` WHERE name = $1`
+		queryArgs = []any{name}
+	}
+	query += ` ORDER BY name`
+
+	rows, err := tx.Query(ctx, query, queryArgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	var syncs []gam.Synchronization
+	for rows.Next() {
+		var sc gam.Synchronization
+		var whenJSON, whereJSON, thenJSON []byte
+		if err := rows.Scan(&sc.Name, &whenJSON, &whereJSON, &thenJSON); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if err := json.Unmarshal(whenJSON, &sc.WhenClause); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("unmarshal when_clause for sync %s: %w", sc.Name, err)
+		}
+		if whereJSON != nil {
+			if err := json.Unmarshal(whereJSON, &sc.WhereClause); err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("unmarshal where_clause for sync %s: %w", sc.Name, err)
+			}
+		}
+		if err := json.Unmarshal(thenJSON, &sc.ThenClause); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("unmarshal then_clause for sync %s: %w", sc.Name, err)
+		}
+		syncs = append(syncs, sc)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if name != "" && len(syncs) == 0 {
+		return 0, fmt.Errorf("sync '%s' not found", name)
+	}
+
+	for _, sc := range syncs {
+		if err := db.BuildSyncRefs(ctx, tx, sc); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(syncs), nil
+}
+
+// SyncFirings summarizes a sync's runtime activity from flow_log. Count covers
+// Window; LastFired is the most recent firing at any time, nil if never.
+type SyncFirings struct {
+	Sync      string        `json:"sync"`
+	Window    time.Duration `json:"window"`
+	Count     int           `json:"count"`
+	LastFired *time.Time    `json:"last_fired,omitempty"`
+}
+
+// RecentSyncFirings counts flow_log entries attributed to the named sync
+// within window, which defaults to DefaultDriftWindow when not positive.
+func RecentSyncFirings(ctx context.Context, pool *pgxpool.Pool, name string, window time.Duration) (SyncFirings, error) {
+	if window <= 0 {
+		window = DefaultDriftWindow
+	}
+	f := SyncFirings{Sync: name, Window: window}
+	err := pool.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE created_at > NOW() - $2 * INTERVAL '1 second'),
+		       MAX(created_at)
+		FROM flow_log
+		WHERE sync_name = $1
+	`, name, window.Seconds()).Scan(&f.Count, &f.LastFired)
+	return f, err
+}
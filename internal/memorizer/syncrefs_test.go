@@ -0,0 +1,130 @@
+package memorizer
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestReindexSyncRefs(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	sc := gam.Synchronization{
+		Name: "TestReindexFanOut",
+		WhenClause: []gam.WhenPattern{
+			{Concept: "Web", Action: "request"},
+		},
+		WhereClause: []gam.WherePattern{
+			{Concept: "SearchSource", Pattern: map[string]any{"?s": map[string]any{"enabled": true}}},
+		},
+		ThenClause: []gam.ThenAction{
+			{Concept: "SearchSource", Action: "query"},
+		},
+	}
+	whenJSON, _ := json.Marshal(sc.WhenClause)
+	whereJSON, _ := json.Marshal(sc.WhereClause)
+	thenJSON, _ := json.Marshal(sc.ThenClause)
+
+	var syncID string
+	err := pool.QueryRow(ctx, `
+		INSERT INTO synchronizations (name, when_clause, where_clause, then_clause)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE SET when_clause = $2, where_clause = $3, then_clause = $4
+		RETURNING id
+	`, sc.Name, whenJSON, whereJSON, thenJSON).Scan(&syncID)
+	if err != nil {
+		t.Fatalf("insert sync: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM synchronizations WHERE id = $1", syncID)
+	})
+
+	// Corrupt the index: drop the real refs and add one that matches no clause.
+	pool.Exec(ctx, "DELETE FROM sync_refs WHERE sync_id = $1", syncID)
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO sync_refs (sync_id, concept_name, action_name, clause_type)
+		VALUES ($1, 'Bogus', 'stale', 'when')
+	`, syncID); err != nil {
+		t.Fatalf("corrupt refs: %v", err)
+	}
+
+	n, err := ReindexSyncRefs(ctx, pool, sc.Name)
+	if err != nil {
+		t.Fatalf("ReindexSyncRefs: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("reindexed count: want 1, got %d", n)
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT clause_type || ':' || concept_name || '/' || COALESCE(action_name, '') || '.' || COALESCE(state_field, '')
+		FROM sync_refs WHERE sync_id = $1
+	`, syncID)
+	if err != nil {
+		t.Fatalf("query refs: %v", err)
+	}
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var ref string
+		rows.Scan(&ref)
+		got = append(got, ref)
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"then:SearchSource/query.",
+		"when:Web/request.",
+		"where:SearchSource/.enabled",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("refs: want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ref %d: want %s, got %s", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRecentSyncFirings(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	const name = "TraceTestSync"
+	for _, age := range []string{"1 hour", "2 days", "3 days", "30 days"} {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO flow_log (flow_token, concept_name, action_name, sync_name, created_at)
+			VALUES (gen_random_uuid(), 'Trace', 'fire', $1, NOW() - $2::interval)
+		`, name, age); err != nil {
+			t.Fatalf("insert flow_log: %v", err)
+		}
+	}
+	defer pool.Exec(ctx, `DELETE FROM flow_log WHERE sync_name = $1`, name)
+
+	f, err := RecentSyncFirings(ctx, pool, name, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("RecentSyncFirings: %v", err)
+	}
+	if f.Count != 3 {
+		t.Errorf("count = %d, want 3 within 7d", f.Count)
+	}
+	if f.LastFired == nil || time.Since(*f.LastFired) > 2*time.Hour {
+		t.Errorf("last fired = %v, want about an hour ago", f.LastFired)
+	}
+
+	never, err := RecentSyncFirings(ctx, pool, "TraceTestNever", 0)
+	if err != nil {
+		t.Fatalf("RecentSyncFirings: %v", err)
+	}
+	if never.Count != 0 || never.LastFired != nil || never.Window != DefaultDriftWindow {
+		t.Errorf("never-fired sync = %+v", never)
+	}
+}
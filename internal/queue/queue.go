@@ -3,7 +3,10 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -18,8 +21,27 @@ const (
 	GroupResearcher = "researcher_pool"
 	// GroupMemorizer is the consumer group for Memorizer agents.
 	GroupMemorizer = "memorizer_pool"
+
+	// processedPrefix namespaces the idempotency keys recorded by Claim.
+	processedPrefix = "gam:processed:"
+
+	// drainKey is set while Memorizer proposal reads are paused for a drain.
+	drainKey = "gam:drain"
 )
 
+// ProcessedTTL is how long a claimed idempotency key is remembered. A
+// message redelivered after this window is processed again.
+const ProcessedTTL = 7 * 24 * time.Hour
+
+// pollBlock bounds each blocking stream read. go-redis does not interrupt a
+// blocked XREADGROUP when its context is cancelled, so reads wake at least
+// this often to notice cancellation.
+const pollBlock = 2 * time.Second
+
+// ErrNoMessages is returned by a batch read whose poll window elapsed with
+// nothing to deliver.
+var ErrNoMessages = errors.New("no messages")
+
 // TaskMessage is the payload pushed to the agent_tasks stream.
 type TaskMessage struct {
 	TurnID     string `json:"turn_id"`
@@ -28,6 +50,19 @@ type TaskMessage struct {
 	TaskType   string `json:"task_type"`
 	Prompt     string `json:"prompt,omitempty"`
 	Review     string `json:"review,omitempty"` // for review_response tasks
+	Replay     string `json:"replay,omitempty"` // replay token; empty for the original push
+}
+
+// IdempotencyKey identifies a task by turn and task type, so a retried push
+// of the same work is processed once. A replayed task's key also carries its
+// replay token, so the replay is not skipped as a duplicate of the push it
+// replaces.
+func (m TaskMessage) IdempotencyKey() string {
+	key := "task:" + m.TurnID + ":" + m.TaskType
+	if m.Replay != "" {
+		key += ":replay:" + m.Replay
+	}
+	return key
 }
 
 // ProposalMessage is the payload pushed to the agent_proposals stream.
@@ -35,6 +70,17 @@ type ProposalMessage struct {
 	TurnID     string `json:"turn_id"`
 	ProposalID string `json:"proposal_id"`
 	RegionPath string `json:"region_path"`
+	Attempt    int    `json:"attempt,omitempty"` // validation attempt; 0 or 1 for the first
+}
+
+// IdempotencyKey identifies a proposal message by proposal id and, for
+// retries, the attempt number, so a retried proposal is not mistaken for a
+// duplicate of its first submission.
+func (m ProposalMessage) IdempotencyKey() string {
+	if m.Attempt > 1 {
+		return fmt.Sprintf("proposal:%s:%d", m.ProposalID, m.Attempt)
+	}
+	return "proposal:" + m.ProposalID
 }
 
 // Queue manages Redis streams for inter-agent communication.
@@ -78,13 +124,15 @@ func (q *Queue) PushTask(ctx context.Context, msg TaskMessage) (string, error) {
 	result, err := q.client.XAdd(ctx, &redis.XAddArgs{
 		Stream: StreamTasks,
 		Values: map[string]any{
-			"turn_id":     msg.TurnID,
-			"region_path": msg.RegionPath,
-			"context_ref": msg.ContextRef,
-			"task_type":   msg.TaskType,
-			"prompt":      msg.Prompt,
-			"review":      msg.Review,
-			"payload":     string(msgJSON),
+			"turn_id":         msg.TurnID,
+			"region_path":     msg.RegionPath,
+			"context_ref":     msg.ContextRef,
+			"task_type":       msg.TaskType,
+			"prompt":          msg.Prompt,
+			"review":          msg.Review,
+			"replay":          msg.Replay,
+			"payload":         string(msgJSON),
+			"idempotency_key": msg.IdempotencyKey(),
 		},
 	}).Result()
 	if err != nil {
@@ -98,9 +146,11 @@ func (q *Queue) PushProposal(ctx context.Context, msg ProposalMessage) (string,
 	result, err := q.client.XAdd(ctx, &redis.XAddArgs{
 		Stream: StreamProposals,
 		Values: map[string]any{
-			"turn_id":     msg.TurnID,
-			"proposal_id": msg.ProposalID,
-			"region_path": msg.RegionPath,
+			"turn_id":         msg.TurnID,
+			"proposal_id":     msg.ProposalID,
+			"region_path":     msg.RegionPath,
+			"attempt":         msg.Attempt,
+			"idempotency_key": msg.IdempotencyKey(),
 		},
 	}).Result()
 	if err != nil {
@@ -109,59 +159,165 @@ func (q *Queue) PushProposal(ctx context.Context, msg ProposalMessage) (string,
 	return result, nil
 }
 
-// ReadTask reads one task message from the agent_tasks stream (blocking).
+// ReadTask reads one task message from the agent_tasks stream, blocking
+// until one arrives or ctx is cancelled. It claims each task's idempotency
+// key and acks and skips tasks already claimed, so a retried push is
+// delivered once. A consumer that fails a task calls Release with its
+// IdempotencyKey so the task can be pushed again.
 func (q *Queue) ReadTask(ctx context.Context, consumer string) (*TaskMessage, string, error) {
-	streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
-		Group:    GroupResearcher,
-		Consumer: consumer,
-		Streams:  []string{StreamTasks, ">"},
-		Count:    1,
-		Block:    0,
-	}).Result()
-	if err != nil {
-		return nil, "", fmt.Errorf("read task: %w", err)
-	}
+	for {
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    GroupResearcher,
+			Consumer: consumer,
+			Streams:  []string{StreamTasks, ">"},
+			Count:    1,
+			Block:    pollBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			if ctx.Err() != nil {
+				return nil, "", ctx.Err()
+			}
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("read task: %w", err)
+		}
 
-	for _, stream := range streams {
-		for _, msg := range stream.Messages {
-			task := &TaskMessage{
-				TurnID:     getString(msg.Values, "turn_id"),
-				RegionPath: getString(msg.Values, "region_path"),
-				ContextRef: getString(msg.Values, "context_ref"),
-				TaskType:   getString(msg.Values, "task_type"),
-				Prompt:     getString(msg.Values, "prompt"),
-				Review:     getString(msg.Values, "review"),
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				task := &TaskMessage{
+					TurnID:     getString(msg.Values, "turn_id"),
+					RegionPath: getString(msg.Values, "region_path"),
+					ContextRef: getString(msg.Values, "context_ref"),
+					TaskType:   getString(msg.Values, "task_type"),
+					Prompt:     getString(msg.Values, "prompt"),
+					Review:     getString(msg.Values, "review"),
+					Replay:     getString(msg.Values, "replay"),
+				}
+				key := getString(msg.Values, "idempotency_key")
+				if key == "" {
+					key = task.IdempotencyKey()
+				}
+				first, err := q.Claim(ctx, key)
+				if err != nil {
+					return nil, "", err
+				}
+				if !first {
+					q.AckTask(ctx, msg.ID)
+					continue
+				}
+				return task, msg.ID, nil
 			}
-			return task, msg.ID, nil
 		}
 	}
-	return nil, "", fmt.Errorf("no messages")
 }
 
-// ReadProposal reads one proposal message from the agent_proposals stream (blocking).
+// ReadProposal reads one proposal message from the agent_proposals stream,
+// blocking until one arrives or ctx is cancelled.
 func (q *Queue) ReadProposal(ctx context.Context, consumer string) (*ProposalMessage, string, error) {
+	for {
+		msgs, ids, err := q.ReadProposals(ctx, consumer, 1)
+		if errors.Is(err, ErrNoMessages) {
+			if ctx.Err() != nil {
+				return nil, "", ctx.Err()
+			}
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return msgs[0], ids[0], nil
+	}
+}
+
+// ReadProposals reads up to n proposal messages from the agent_proposals
+// stream in one round trip. It waits up to a short poll window for the first
+// message and returns ErrNoMessages if none arrived, so callers can check
+// ctx between polls. The returned ids are parallel to the messages.
+func (q *Queue) ReadProposals(ctx context.Context, consumer string, n int) ([]*ProposalMessage, []string, error) {
+	return q.readProposals(ctx, consumer, n, ">", pollBlock)
+}
+
+// ReadPendingProposals returns up to n proposal messages already delivered
+// to consumer but never acked, oldest first, without blocking. A consumer
+// calls it on startup to finish the batch it was working on when it last
+// stopped; entries stay pending until acked, so callers read again after
+// acking until it returns ErrNoMessages. Entries whose message was trimmed
+// from the stream are acked and skipped, so a batch may come back empty.
+func (q *Queue) ReadPendingProposals(ctx context.Context, consumer string, n int) ([]*ProposalMessage, []string, error) {
+	return q.readProposals(ctx, consumer, n, "0", -1)
+}
+
+// readProposals reads up to n messages from the memorizer group starting at
+// id: ">" for new messages, "0" for consumer's own pending entries. A
+// negative block does not wait.
+func (q *Queue) readProposals(ctx context.Context, consumer string, n int, id string, block time.Duration) ([]*ProposalMessage, []string, error) {
+	if n < 1 {
+		n = 1
+	}
 	streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    GroupMemorizer,
 		Consumer: consumer,
-		Streams:  []string{StreamProposals, ">"},
-		Count:    1,
-		Block:    0,
+		Streams:  []string{StreamProposals, id},
+		Count:    int64(n),
+		Block:    block,
 	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil, ErrNoMessages
+	}
 	if err != nil {
-		return nil, "", fmt.Errorf("read proposal: %w", err)
+		return nil, nil, fmt.Errorf("read proposal: %w", err)
 	}
 
+	var msgs []*ProposalMessage
+	var ids []string
+	delivered := 0
 	for _, stream := range streams {
+		delivered += len(stream.Messages)
 		for _, msg := range stream.Messages {
-			proposal := &ProposalMessage{
+			if len(msg.Values) == 0 {
+				// Pending entry whose message was trimmed; nothing to process.
+				q.AckProposal(ctx, msg.ID)
+				continue
+			}
+			attempt, _ := strconv.Atoi(getString(msg.Values, "attempt"))
+			msgs = append(msgs, &ProposalMessage{
 				TurnID:     getString(msg.Values, "turn_id"),
 				ProposalID: getString(msg.Values, "proposal_id"),
 				RegionPath: getString(msg.Values, "region_path"),
-			}
-			return proposal, msg.ID, nil
+				Attempt:    attempt,
+			})
+			ids = append(ids, msg.ID)
 		}
 	}
-	return nil, "", fmt.Errorf("no messages")
+	if delivered == 0 {
+		return nil, nil, ErrNoMessages
+	}
+	return msgs, ids, nil
+}
+
+// Claim records key as processed and reports whether this caller is the
+// first to claim it within ProcessedTTL. Consumers call it with a message's
+// IdempotencyKey before processing and ack without processing when it
+// returns false; if processing then fails they call Release, so a re-push
+// of the message is not skipped as a duplicate. Each key is its own expiring
+// entry rather than a member of one Redis set, since set members cannot
+// expire individually.
+func (q *Queue) Claim(ctx context.Context, key string) (bool, error) {
+	ok, err := q.client.SetNX(ctx, processedPrefix+key, time.Now().UTC().Format(time.RFC3339), ProcessedTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("claim %s: %w", key, err)
+	}
+	return ok, nil
+}
+
+// Release forgets a key recorded by Claim, so the next message carrying it
+// is processed.
+func (q *Queue) Release(ctx context.Context, key string) error {
+	if err := q.client.Del(ctx, processedPrefix+key).Err(); err != nil {
+		return fmt.Errorf("release %s: %w", key, err)
+	}
+	return nil
 }
 
 // AckTask acknowledges a task message.
@@ -174,6 +330,123 @@ func (q *Queue) AckProposal(ctx context.Context, msgID string) error {
 	return q.client.XAck(ctx, StreamProposals, GroupMemorizer, msgID).Err()
 }
 
+// PendingMessage is a delivered but unacknowledged message in a consumer
+// group's pending entries list.
+type PendingMessage struct {
+	Stream     string
+	ID         string
+	Consumer   string
+	Idle       time.Duration
+	Deliveries int64
+}
+
+// GroupFor returns the consumer group reading stream.
+func GroupFor(stream string) (string, error) {
+	switch stream {
+	case StreamTasks:
+		return GroupResearcher, nil
+	case StreamProposals:
+		return GroupMemorizer, nil
+	}
+	return "", fmt.Errorf("unknown stream %q", stream)
+}
+
+// Pending lists up to count pending messages for stream's consumer group,
+// oldest first.
+func (q *Queue) Pending(ctx context.Context, stream string, count int64) ([]PendingMessage, error) {
+	group, err := GroupFor(stream)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  group,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("pending %s: %w", stream, err)
+	}
+
+	pending := make([]PendingMessage, 0, len(entries))
+	for _, e := range entries {
+		pending = append(pending, PendingMessage{
+			Stream:     stream,
+			ID:         e.ID,
+			Consumer:   e.Consumer,
+			Idle:       e.Idle,
+			Deliveries: e.RetryCount,
+		})
+	}
+	return pending, nil
+}
+
+// SetDraining pauses (true) or resumes (false) Memorizer proposal reads.
+// Consumers check Draining before each read; messages already delivered
+// are still processed and acked.
+func (q *Queue) SetDraining(ctx context.Context, draining bool) error {
+	var err error
+	if draining {
+		err = q.client.Set(ctx, drainKey, time.Now().UTC().Format(time.RFC3339), 0).Err()
+	} else {
+		err = q.client.Del(ctx, drainKey).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("set draining: %w", err)
+	}
+	return nil
+}
+
+// Draining reports whether proposal reads are paused by SetDraining.
+func (q *Queue) Draining(ctx context.Context) (bool, error) {
+	n, err := q.client.Exists(ctx, drainKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("check draining: %w", err)
+	}
+	return n > 0, nil
+}
+
+// PendingCount returns how many messages on stream are delivered to its
+// consumer group but not yet acked.
+func (q *Queue) PendingCount(ctx context.Context, stream string) (int64, error) {
+	group, err := GroupFor(stream)
+	if err != nil {
+		return 0, err
+	}
+	summary, err := q.client.XPending(ctx, stream, group).Result()
+	if err != nil {
+		return 0, fmt.Errorf("pending %s: %w", stream, err)
+	}
+	return summary.Count, nil
+}
+
+// WaitDrained calls count every interval until it reports zero pending
+// messages or ctx ends, and returns the last count. When ctx ends first the
+// count is returned with ctx's error.
+func WaitDrained(ctx context.Context, interval time.Duration, count func(context.Context) (int64, error)) (int64, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last int64 = -1
+	for {
+		n, err := count(ctx)
+		if err != nil && ctx.Err() == nil {
+			return last, err
+		}
+		if err == nil {
+			last = n
+			if n == 0 {
+				return 0, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // Status returns pending message counts for both streams.
 func (q *Queue) Status(ctx context.Context) (tasks, proposals int64, err error) {
 	tasksLen, err := q.client.XLen(ctx, StreamTasks).Result()
@@ -0,0 +1,338 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// testQueue connects to the Redis named by GAM_TEST_REDIS_URL, skipping the
+// test when it is unset.
+func testQueue(t *testing.T) *Queue {
+	t.Helper()
+	url := os.Getenv("GAM_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("GAM_TEST_REDIS_URL not set")
+	}
+	client, err := ConnectRedis(url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	q := New(client)
+	if err := q.EnsureStreams(context.Background()); err != nil {
+		t.Fatalf("ensure streams: %v", err)
+	}
+	return q
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	p := ProposalMessage{TurnID: "t1", ProposalID: "p1", RegionPath: "app"}
+	if got := p.IdempotencyKey(); got != "proposal:p1" {
+		t.Errorf("proposal key = %q", got)
+	}
+	retry := ProposalMessage{ProposalID: "p1", Attempt: 2}
+	if retry.IdempotencyKey() == p.IdempotencyKey() {
+		t.Error("a retried proposal must not share the first attempt's key")
+	}
+
+	implement := TaskMessage{TurnID: "t1", TaskType: "implement"}
+	review := TaskMessage{TurnID: "t1", TaskType: "review_response"}
+	if implement.IdempotencyKey() == review.IdempotencyKey() {
+		t.Error("tasks of different types on the same turn must have distinct keys")
+	}
+	if implement.IdempotencyKey() != (TaskMessage{TurnID: "t1", TaskType: "implement", Prompt: "retry"}).IdempotencyKey() {
+		t.Error("task key should depend only on turn id and task type")
+	}
+	replay := TaskMessage{TurnID: "t1", TaskType: "implement", Replay: "1"}
+	if replay.IdempotencyKey() == implement.IdempotencyKey() {
+		t.Error("a replayed task must not share the original push's key")
+	}
+	if replay.IdempotencyKey() == (TaskMessage{TurnID: "t1", TaskType: "implement", Replay: "2"}).IdempotencyKey() {
+		t.Error("each replay must have its own key")
+	}
+}
+
+func TestDuplicateProposalProcessedOnce(t *testing.T) {
+	q := testQueue(t)
+	ctx := context.Background()
+
+	id := fmt.Sprintf("dup-%d", time.Now().UnixNano())
+	msg := ProposalMessage{TurnID: "turn-" + id, ProposalID: id, RegionPath: "app"}
+	t.Cleanup(func() { q.client.Del(context.Background(), processedPrefix+msg.IdempotencyKey()) })
+
+	// A retried push enqueues the same proposal twice.
+	for i := 0; i < 2; i++ {
+		if _, err := q.PushProposal(ctx, msg); err != nil {
+			t.Fatalf("push: %v", err)
+		}
+	}
+
+	processed, seen := 0, 0
+	readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	for seen < 2 {
+		got, msgID, err := q.ReadProposal(readCtx, "test_consumer")
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		q.AckProposal(ctx, msgID)
+		if got.ProposalID != id {
+			continue
+		}
+		seen++
+
+		first, err := q.Claim(ctx, got.IdempotencyKey())
+		if err != nil {
+			t.Fatalf("claim: %v", err)
+		}
+		if first {
+			processed++
+		}
+	}
+
+	if processed != 1 {
+		t.Errorf("proposal processed %d times, want 1", processed)
+	}
+}
+
+func TestDuplicateTaskReadOnce(t *testing.T) {
+	q := testQueue(t)
+	ctx := context.Background()
+
+	task := TaskMessage{TurnID: fmt.Sprintf("dup-task-%d", time.Now().UnixNano()), RegionPath: "app", TaskType: "implement"}
+	t.Cleanup(func() { q.client.Del(context.Background(), processedPrefix+task.IdempotencyKey()) })
+	for i := 0; i < 2; i++ {
+		if _, err := q.PushTask(ctx, task); err != nil {
+			t.Fatalf("push: %v", err)
+		}
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	delivered := 0
+	for {
+		got, msgID, err := q.ReadTask(readCtx, "test_researcher")
+		if err != nil {
+			break
+		}
+		q.AckTask(ctx, msgID)
+		if got.TurnID == task.TurnID {
+			delivered++
+		}
+	}
+	if delivered != 1 {
+		t.Errorf("task delivered %d times, want 1", delivered)
+	}
+}
+
+func TestReleaseAllowsReclaim(t *testing.T) {
+	q := testQueue(t)
+	ctx := context.Background()
+
+	key := fmt.Sprintf("proposal:release-%d", time.Now().UnixNano())
+	t.Cleanup(func() { q.client.Del(context.Background(), processedPrefix+key) })
+	if first, err := q.Claim(ctx, key); err != nil || !first {
+		t.Fatalf("first claim = %v, %v", first, err)
+	}
+	if err := q.Release(ctx, key); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if first, err := q.Claim(ctx, key); err != nil || !first {
+		t.Errorf("claim after release = %v, %v; want a fresh claim", first, err)
+	}
+}
+
+func TestReadProposalsBatch(t *testing.T) {
+	q := testQueue(t)
+	ctx := context.Background()
+
+	// Drain anything left by earlier runs so the batch holds only ours.
+	q.client.XTrimMaxLen(ctx, StreamProposals, 0)
+
+	prefix := fmt.Sprintf("batch-%d-", time.Now().UnixNano())
+	for i := 0; i < 5; i++ {
+		msg := ProposalMessage{TurnID: "turn", ProposalID: fmt.Sprintf("%s%d", prefix, i), RegionPath: "app"}
+		if _, err := q.PushProposal(ctx, msg); err != nil {
+			t.Fatalf("push: %v", err)
+		}
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	msgs, ids, err := q.ReadProposals(readCtx, "test_consumer", 3)
+	if err != nil {
+		t.Fatalf("ReadProposals: %v", err)
+	}
+	if len(msgs) != 3 || len(ids) != 3 {
+		t.Fatalf("got %d messages and %d ids, want 3", len(msgs), len(ids))
+	}
+	for i, m := range msgs {
+		if want := fmt.Sprintf("%s%d", prefix, i); m.ProposalID != want {
+			t.Errorf("message %d = %s, want %s", i, m.ProposalID, want)
+		}
+		q.AckProposal(ctx, ids[i])
+	}
+
+	rest, ids, err := q.ReadProposals(readCtx, "test_consumer", 10)
+	if err != nil {
+		t.Fatalf("ReadProposals rest: %v", err)
+	}
+	if len(rest) != 2 {
+		t.Errorf("second batch = %d messages, want the remaining 2", len(rest))
+	}
+	for _, id := range ids {
+		q.AckProposal(ctx, id)
+	}
+}
+
+func TestPendingShowsUnackedMessage(t *testing.T) {
+	q := testQueue(t)
+	ctx := context.Background()
+
+	q.client.XTrimMaxLen(ctx, StreamProposals, 0)
+
+	id := fmt.Sprintf("pending-%d", time.Now().UnixNano())
+	if _, err := q.PushProposal(ctx, ProposalMessage{TurnID: "turn", ProposalID: id, RegionPath: "app"}); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, msgID, err := q.ReadProposal(readCtx, "stuck_consumer")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	defer q.AckProposal(ctx, msgID)
+
+	pending, err := q.Pending(ctx, StreamProposals, 100)
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	var found *PendingMessage
+	for i := range pending {
+		if pending[i].ID == msgID {
+			found = &pending[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("read-but-unacked message %s not pending: %+v", msgID, pending)
+	}
+	if found.Consumer != "stuck_consumer" || found.Deliveries != 1 {
+		t.Errorf("pending entry = %+v, want consumer stuck_consumer with 1 delivery", *found)
+	}
+
+	q.AckProposal(ctx, msgID)
+	pending, _ = q.Pending(ctx, StreamProposals, 100)
+	for _, p := range pending {
+		if p.ID == msgID {
+			t.Error("acked message still pending")
+		}
+	}
+}
+
+func TestReadPendingProposalsRedeliversUnacked(t *testing.T) {
+	q := testQueue(t)
+	ctx := context.Background()
+
+	q.client.XTrimMaxLen(ctx, StreamProposals, 0)
+
+	consumer := fmt.Sprintf("restart_%d", time.Now().UnixNano())
+	for i := 0; i < 3; i++ {
+		msg := ProposalMessage{TurnID: "turn", ProposalID: fmt.Sprintf("%s-%d", consumer, i), RegionPath: "app"}
+		if _, err := q.PushProposal(ctx, msg); err != nil {
+			t.Fatalf("push: %v", err)
+		}
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	msgs, ids, err := q.ReadProposals(readCtx, consumer, 3)
+	if err != nil || len(msgs) != 3 {
+		t.Fatalf("ReadProposals = %d messages, %v", len(msgs), err)
+	}
+	// Only the first is finished before the consumer stops.
+	q.AckProposal(ctx, ids[0])
+
+	pending, pendingIDs, err := q.ReadPendingProposals(ctx, consumer, 10)
+	if err != nil {
+		t.Fatalf("ReadPendingProposals: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ProposalID != msgs[1].ProposalID || pending[1].ProposalID != msgs[2].ProposalID {
+		t.Fatalf("pending = %+v, want the 2 unacked proposals in order", pending)
+	}
+	for _, id := range pendingIDs {
+		q.AckProposal(ctx, id)
+	}
+
+	if _, _, err := q.ReadPendingProposals(ctx, consumer, 10); !errors.Is(err, ErrNoMessages) {
+		t.Errorf("after acking, ReadPendingProposals err = %v, want ErrNoMessages", err)
+	}
+}
+
+func TestGroupFor(t *testing.T) {
+	if g, _ := GroupFor(StreamTasks); g != GroupResearcher {
+		t.Errorf("GroupFor(tasks) = %s", g)
+	}
+	if g, _ := GroupFor(StreamProposals); g != GroupMemorizer {
+		t.Errorf("GroupFor(proposals) = %s", g)
+	}
+	if _, err := GroupFor("other"); err == nil {
+		t.Error("expected error for unknown stream")
+	}
+}
+
+func TestWaitDrainedBlocksUntilEmpty(t *testing.T) {
+	counts := []int64{3, 2, 0}
+	calls := 0
+	n, err := WaitDrained(context.Background(), time.Millisecond, func(context.Context) (int64, error) {
+		c := counts[calls]
+		calls++
+		return c, nil
+	})
+	if err != nil || n != 0 || calls != 3 {
+		t.Errorf("WaitDrained = %d, %v after %d calls; want 0, nil after 3", n, err, calls)
+	}
+}
+
+func TestWaitDrainedStopsAtTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	n, err := WaitDrained(ctx, 5*time.Millisecond, func(context.Context) (int64, error) {
+		return 2, nil
+	})
+	if err != context.DeadlineExceeded || n != 2 {
+		t.Errorf("WaitDrained = %d, %v; want 2, deadline exceeded", n, err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Errorf("returned after %s, want at the timeout", elapsed)
+	}
+}
+
+func TestDrainFlag(t *testing.T) {
+	q := testQueue(t)
+	ctx := context.Background()
+	t.Cleanup(func() { q.SetDraining(context.Background(), false) })
+
+	if err := q.SetDraining(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if on, err := q.Draining(ctx); err != nil || !on {
+		t.Errorf("Draining = %v, %v after SetDraining(true)", on, err)
+	}
+	if err := q.SetDraining(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if on, _ := q.Draining(ctx); on {
+		t.Error("still draining after SetDraining(false)")
+	}
+	if _, err := q.PendingCount(ctx, StreamProposals); err != nil {
+		t.Errorf("PendingCount: %v", err)
+	}
+}
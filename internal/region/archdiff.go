@@ -0,0 +1,47 @@
+package region
+
+import "sort"
+
+// ArchDiffEntry records which of the three namespace sources contain a path.
+type ArchDiffEntry struct {
+	Path     string `json:"path"`
+	InArch   bool   `json:"in_arch"`
+	InSource bool   `json:"in_source"`
+	InDB     bool   `json:"in_db"`
+}
+
+// Drifted reports whether the path is missing from at least one source.
+func (e ArchDiffEntry) Drifted() bool {
+	return !(e.InArch && e.InSource && e.InDB)
+}
+
+// DiffArch computes a three-way diff of namespace paths declared in arch.md,
+// found in source region markers, and stored in the regions table. Entries
+// are sorted by path.
+func DiffArch(archPaths, sourcePaths, dbPaths []string) []ArchDiffEntry {
+	byPath := make(map[string]*ArchDiffEntry)
+	get := func(p string) *ArchDiffEntry {
+		e, ok := byPath[p]
+		if !ok {
+			e = &ArchDiffEntry{Path: p}
+			byPath[p] = e
+		}
+		return e
+	}
+	for _, p := range archPaths {
+		get(p).InArch = true
+	}
+	for _, p := range sourcePaths {
+		get(p).InSource = true
+	}
+	for _, p := range dbPaths {
+		get(p).InDB = true
+	}
+
+	entries := make([]ArchDiffEntry, 0, len(byPath))
+	for _, e := range byPath {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries
+}
@@ -0,0 +1,32 @@
+package region
+
+import "testing"
+
+func TestDiffArchFlagsPartialPaths(t *testing.T) {
+	arch := []string{"app", "app.search", "app.billing"}
+	source := []string{"app", "app.search", "app.auth"}
+	db := []string{"app", "app.billing", "app.auth"}
+
+	entries := DiffArch(arch, source, db)
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d: %+v", len(entries), entries)
+	}
+
+	want := map[string]ArchDiffEntry{
+		"app":         {Path: "app", InArch: true, InSource: true, InDB: true},
+		"app.auth":    {Path: "app.auth", InSource: true, InDB: true},
+		"app.billing": {Path: "app.billing", InArch: true, InDB: true},
+		"app.search":  {Path: "app.search", InArch: true, InSource: true},
+	}
+	for _, e := range entries {
+		if e != want[e.Path] {
+			t.Errorf("%s: got %+v, want %+v", e.Path, e, want[e.Path])
+		}
+		if e.Drifted() != (e.Path != "app") {
+			t.Errorf("%s: Drifted() = %v", e.Path, e.Drifted())
+		}
+	}
+	if entries[0].Path != "app" || entries[3].Path != "app.search" {
+		t.Errorf("entries not sorted by path: %+v", entries)
+	}
+}
@@ -0,0 +1,143 @@
+package region
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ArchFix is a single repair that brings arch.md and source markers into
+// alignment.
+type ArchFix struct {
+	Kind string // "scaffold" adds markers to File; "append" adds Path to arch.md
+	Path string
+	File string // project-relative target file for scaffold fixes
+}
+
+func (f ArchFix) String() string {
+	if f.Kind == "scaffold" {
+		return fmt.Sprintf("scaffold @region:%s in %s", f.Path, f.File)
+	}
+	return fmt.Sprintf("append %s to arch.md", f.Path)
+}
+
+// PlanArchFixes computes the fixes for arch.md/source misalignment. Regions
+// declared in arch.md without source markers are scaffolded into the file
+// given by fileMap; those with no mapping are returned as unresolved. Source
+// regions missing from arch.md are appended to it.
+func PlanArchFixes(projectRoot string, fileMap map[string]string) ([]ArchFix, []string, error) {
+	archPaths, err := ParseArchMd(projectRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse arch.md: %w", err)
+	}
+	archSet := make(map[string]bool)
+	for _, p := range archPaths {
+		archSet[p] = true
+	}
+
+	markers, _, _ := ScanDirectory(projectRoot, ParseGamignore(projectRoot))
+	sourceSet := make(map[string]bool)
+	for _, m := range markers {
+		sourceSet[m.Path] = true
+	}
+
+	var fixes []ArchFix
+	var unresolved []string
+	for _, p := range archPaths {
+		if sourceSet[p] {
+			continue
+		}
+		if file, ok := fileMap[p]; ok {
+			fixes = append(fixes, ArchFix{Kind: "scaffold", Path: p, File: file})
+		} else {
+			unresolved = append(unresolved, p)
+		}
+	}
+
+	var missing []string
+	for p := range sourceSet {
+		if !archSet[p] {
+			missing = append(missing, p)
+		}
+	}
+	sort.Strings(missing)
+	for _, p := range missing {
+		fixes = append(fixes, ArchFix{Kind: "append", Path: p})
+	}
+	return fixes, unresolved, nil
+}
+
+// ApplyArchFixes scaffolds region markers and appends arch.md entries.
+func ApplyArchFixes(projectRoot string, fixes []ArchFix) error {
+	var appendPaths []string
+	for _, f := range fixes {
+		switch f.Kind {
+		case "scaffold":
+			if err := ScaffoldRegion(filepath.Join(projectRoot, f.File), f.Path); err != nil {
+				return fmt.Errorf("scaffold %s: %w", f.Path, err)
+			}
+		case "append":
+			appendPaths = append(appendPaths, f.Path)
+		}
+	}
+	if len(appendPaths) == 0 {
+		return nil
+	}
+	return AppendArchEntries(projectRoot, appendPaths)
+}
+
+// AppendArchEntries appends @region/@endregion entries for paths to arch.md,
+// creating the file if needed.
+func AppendArchEntries(projectRoot string, paths []string) error {
+	archFile := filepath.Join(projectRoot, "arch.md")
+	data, err := os.ReadFile(archFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	for _, p := range paths {
+		content += fmt.Sprintf("# @region:%s\n# @endregion:%s\n", p, p)
+	}
+	return os.WriteFile(archFile, []byte(content), 0644)
+}
+
+// SetArchDescription replaces the one-line description on the @region line
+// for path in arch.md, keeping the line's comment prefix and any closer. An
+// empty desc removes the description.
+func SetArchDescription(projectRoot, path, desc string) error {
+	archFile := filepath.Join(projectRoot, "arch.md")
+	data, err := os.ReadFile(archFile)
+	if err != nil {
+		return err
+	}
+	desc = strings.Join(strings.Fields(desc), " ")
+
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		if p, ok := extractRegionPath(strings.TrimSpace(line), "region"); !ok || p != path {
+			continue
+		}
+		marker := "@region:" + path
+		idx := strings.Index(line, marker)
+		rest := strings.TrimRight(line[idx+len(marker):], " \t")
+		closer := ""
+		for _, c := range []string{"-->", "*/"} {
+			if strings.HasSuffix(rest, c) {
+				closer = " " + c
+				break
+			}
+		}
+		updated := line[:idx+len(marker)]
+		if desc != "" {
+			updated += " " + desc
+		}
+		lines[i] = updated + closer
+		return os.WriteFile(archFile, []byte(strings.Join(lines, "\n")), 0644)
+	}
+	return fmt.Errorf("region %s not found in arch.md", path)
+}
@@ -0,0 +1,96 @@
+package region
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArchFixScaffoldsDeclaredRegion(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "arch.md"), []byte("# @region:app\n# @endregion:app\n# @region:app.search\n# @endregion:app.search\n# @region:app.billing\n# @endregion:app.billing\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "app.go"), []byte("// @region:app\npackage app\n// @endregion:app\n"), 0644)
+
+	fixes, unresolved, err := PlanArchFixes(dir, map[string]string{"app.search": "search/search.go"})
+	if err != nil {
+		t.Fatalf("PlanArchFixes: %v", err)
+	}
+	if len(fixes) != 1 || fixes[0] != (ArchFix{Kind: "scaffold", Path: "app.search", File: "search/search.go"}) {
+		t.Fatalf("fixes = %+v", fixes)
+	}
+	if len(unresolved) != 1 || unresolved[0] != "app.billing" {
+		t.Errorf("unresolved = %v, want [app.billing]", unresolved)
+	}
+
+	// Planning is a dry run: nothing is written.
+	if _, err := os.Stat(filepath.Join(dir, "search", "search.go")); !os.IsNotExist(err) {
+		t.Fatal("PlanArchFixes should not create files")
+	}
+
+	if err := ApplyArchFixes(dir, fixes); err != nil {
+		t.Fatalf("ApplyArchFixes: %v", err)
+	}
+	if !FileHasRegionMarkers(filepath.Join(dir, "search", "search.go"), "app.search") {
+		t.Error("app.search markers not scaffolded")
+	}
+}
+
+func TestArchFixAppendsSourceRegionToArchMd(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "arch.md"), []byte("# @region:app\n# @endregion:app"), 0644)
+	os.WriteFile(filepath.Join(dir, "app.go"), []byte("// @region:app\npackage app\n// @endregion:app\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "auth.go"), []byte("// @region:app.auth\npackage app\n// @endregion:app.auth\n"), 0644)
+
+	fixes, unresolved, err := PlanArchFixes(dir, nil)
+	if err != nil {
+		t.Fatalf("PlanArchFixes: %v", err)
+	}
+	if len(unresolved) != 0 {
+		t.Errorf("unresolved = %v", unresolved)
+	}
+	if len(fixes) != 1 || fixes[0] != (ArchFix{Kind: "append", Path: "app.auth"}) {
+		t.Fatalf("fixes = %+v", fixes)
+	}
+
+	if err := ApplyArchFixes(dir, fixes); err != nil {
+		t.Fatalf("ApplyArchFixes: %v", err)
+	}
+	paths, _ := ParseArchMd(dir)
+	if strings.Join(paths, ",") != "app,app.auth" {
+		t.Errorf("arch.md paths = %v, want [app app.auth]", paths)
+	}
+
+	fixes, _, _ = PlanArchFixes(dir, nil)
+	if len(fixes) != 0 {
+		t.Errorf("expected no fixes after apply, got %+v", fixes)
+	}
+}
+
+func TestSetArchDescription(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "arch.md"), []byte(
+		"# @region:app Application\n"+
+			"<!-- @region:app.search Old search text -->\n"+
+			"<!-- @endregion:app.search -->\n"+
+			"# @endregion:app\n"), 0644)
+
+	if err := SetArchDescription(dir, "app.search", "  Query index   providers "); err != nil {
+		t.Fatalf("SetArchDescription: %v", err)
+	}
+	if err := SetArchDescription(dir, "app", ""); err != nil {
+		t.Fatalf("clear description: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "arch.md"))
+	want := "# @region:app\n" +
+		"<!-- @region:app.search Query index providers -->\n" +
+		"<!-- @endregion:app.search -->\n" +
+		"# @endregion:app\n"
+	if string(data) != want {
+		t.Errorf("arch.md =\n%s\nwant\n%s", data, want)
+	}
+
+	if err := SetArchDescription(dir, "app.missing", "x"); err == nil {
+		t.Error("expected error for a region not in arch.md")
+	}
+}
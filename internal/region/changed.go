@@ -0,0 +1,51 @@
+package region
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// FilterChanged keeps the markers that live in one of changedFiles, plus the
+// markers of their ancestor regions so the filtered tree keeps its context.
+// Relative marker files are resolved against root, as ScanDirectory records
+// them; paths are compared after conversion to absolute form.
+func FilterChanged(root string, markers []*RegionMarker, changedFiles []string) []*RegionMarker {
+	changed := make(map[string]bool, len(changedFiles))
+	for _, f := range changedFiles {
+		changed[absPath(f)] = true
+	}
+
+	keep := make(map[string]bool)
+	for _, m := range markers {
+		file := filepath.FromSlash(m.File)
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(root, file)
+		}
+		if !changed[absPath(file)] {
+			continue
+		}
+		for p := m.Path; ; {
+			keep[p] = true
+			i := strings.LastIndex(p, ".")
+			if i < 0 {
+				break
+			}
+			p = p[:i]
+		}
+	}
+
+	var out []*RegionMarker
+	for _, m := range markers {
+		if keep[m.Path] {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func absPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return filepath.Clean(p)
+}
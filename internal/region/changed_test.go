@@ -0,0 +1,35 @@
+package region
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFilterChanged(t *testing.T) {
+	dir := t.TempDir()
+	markers := []*RegionMarker{
+		{Path: "app", File: "app.go", StartLine: 1, EndLine: 9},
+		{Path: "app.search", File: "pkg/search.go", StartLine: 1, EndLine: 20},
+		{Path: "app.search.query", File: "pkg/search.go", StartLine: 5, EndLine: 10},
+		{Path: "app.auth", File: "pkg/auth.go", StartLine: 1, EndLine: 8},
+	}
+
+	got := FilterChanged(dir, markers, []string{filepath.Join(dir, "pkg", "search.go")})
+	var paths []string
+	for _, m := range got {
+		paths = append(paths, m.Path)
+	}
+	if strings.Join(paths, ",") != "app,app.search,app.search.query" {
+		t.Errorf("filtered paths = %v", paths)
+	}
+
+	out := FormatTree(BuildTree(got), "", true)
+	if strings.Contains(out, "auth") {
+		t.Errorf("unchanged region rendered:\n%s", out)
+	}
+
+	if len(FilterChanged(dir, markers, nil)) != 0 {
+		t.Error("no changed files should yield no markers")
+	}
+}
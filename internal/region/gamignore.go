@@ -0,0 +1,50 @@
+package region
+
+import (
+	"path/filepath"
+)
+
+// nestedIgnores tracks .gamignore files found below a walk root. Patterns in
+// a nested file apply only to its own subtree and are matched against paths
+// relative to the directory holding it, as with nested .gitignore files.
+type nestedIgnores struct {
+	root  string
+	byDir map[string][]string
+}
+
+func newNestedIgnores(root string) *nestedIgnores {
+	return &nestedIgnores{root: root, byDir: make(map[string][]string)}
+}
+
+// visit records dir's .gamignore, if any. Call it for each directory as the
+// walk enters it. The walk root is skipped: its patterns come from the caller.
+func (n *nestedIgnores) visit(dir string) {
+	if dir == n.root {
+		return
+	}
+	if patterns := ParseGamignore(dir); len(patterns) > 0 {
+		n.byDir[dir] = patterns
+	}
+}
+
+// ignored reports whether path is excluded by a .gamignore in one of its
+// ancestor directories below the walk root.
+func (n *nestedIgnores) ignored(path string) bool {
+	if len(n.byDir) == 0 {
+		return false
+	}
+	for dir := filepath.Dir(path); dir != n.root; {
+		if patterns, ok := n.byDir[dir]; ok {
+			rel, _ := filepath.Rel(dir, path)
+			if IsIgnored(filepath.ToSlash(rel), patterns) {
+				return true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package region
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LintIssue is a single marker hygiene problem found by LintFile.
+type LintIssue struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+	Fix     string `json:"fix"`
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s:%d: %s (fix: %s)", i.File, i.Line, i.Message, i.Fix)
+}
+
+// lintMarker is a region or endregion marker as it appeared on its line.
+type lintMarker struct {
+	line int
+	path string
+	end  bool
+	raw  string
+}
+
+// LintFile checks a source file's region markers for problems ScanFile
+// tolerates or only warns about: closers before their opener, duplicate
+// closers, unclosed regions, markers sharing a line with code, and
+// whitespace between the tag and the path. Issues are sorted by line.
+func LintFile(filename string) ([]LintIssue, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var markers []lintMarker
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if path, ok := extractRegionPath(line, "region"); ok {
+			markers = append(markers, lintMarker{line: lineNum, path: path, raw: line})
+		}
+		if path, ok := extractRegionPath(line, "endregion"); ok {
+			markers = append(markers, lintMarker{line: lineNum, path: path, end: true, raw: line})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lintMarkers(filename, markers), nil
+}
+
+func lintMarkers(filename string, markers []lintMarker) []LintIssue {
+	var issues []LintIssue
+	add := func(line int, msg, fix string) {
+		issues = append(issues, LintIssue{File: filename, Line: line, Message: msg, Fix: fix})
+	}
+
+	prefix := GetCommentPrefix(filename)
+	open := make(map[string]int)   // path -> start line of the open region
+	closed := make(map[string]int) // path -> line of the most recent closer
+	for i, m := range markers {
+		tag := "@region:"
+		if m.end {
+			tag = "@endregion:"
+		}
+
+		if !strings.HasPrefix(m.raw, prefix) {
+			add(m.line, fmt.Sprintf("%s%s is not on its own line", tag, m.path),
+				"move the marker onto a line containing only the comment")
+		}
+		if idx := strings.Index(m.raw, tag); idx >= 0 {
+			rest := m.raw[idx+len(tag):]
+			if rest != strings.TrimLeft(rest, " \t") {
+				add(m.line, fmt.Sprintf("whitespace between %s and path %s", tag, m.path),
+					fmt.Sprintf("write %s%s with no space", tag, m.path))
+			}
+		}
+
+		if !m.end {
+			open[m.path] = m.line
+			continue
+		}
+		if _, ok := open[m.path]; ok {
+			delete(open, m.path)
+			closed[m.path] = m.line
+			continue
+		}
+		if prev, ok := closed[m.path]; ok {
+			add(m.line, fmt.Sprintf("duplicate @endregion:%s (already closed at line %d)", m.path, prev),
+				"delete this closer")
+			continue
+		}
+		if opener := laterOpener(markers[i+1:], m.path); opener > 0 {
+			add(m.line, fmt.Sprintf("@endregion:%s appears before its @region at line %d", m.path, opener),
+				fmt.Sprintf("move this closer below line %d", opener))
+			continue
+		}
+		add(m.line, fmt.Sprintf("@endregion:%s without matching @region", m.path),
+			"delete the closer or add the missing @region above it")
+	}
+
+	for path, start := range open {
+		// An out-of-order closer already reported this region.
+		if reportedOutOfOrder(issues, path) {
+			continue
+		}
+		add(start, fmt.Sprintf("@region:%s never closed", path),
+			fmt.Sprintf("add %s after the region body", GetEndRegionTag(path, filename)))
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues
+}
+
+// laterOpener returns the line of the first @region for path in markers, or 0.
+func laterOpener(markers []lintMarker, path string) int {
+	for _, m := range markers {
+		if !m.end && m.path == path {
+			return m.line
+		}
+	}
+	return 0
+}
+
+func reportedOutOfOrder(issues []LintIssue, path string) bool {
+	want := fmt.Sprintf("@endregion:%s appears before its @region", path)
+	for _, i := range issues {
+		if strings.HasPrefix(i.Message, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// LintDirectory runs LintFile over every scannable, non-ignored file under dir.
+func LintDirectory(dir string, gamignorePatterns []string) ([]LintIssue, error) {
+	var all []LintIssue
+	nested := newNestedIgnores(dir)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			base := filepath.Base(path)
+			if base == ".git" || base == "node_modules" || base == "vendor" {
+				return filepath.SkipDir
+			}
+			nested.visit(path)
+			return nil
+		}
+		if !IsScannable(path) {
+			return nil
+		}
+		relPath, _ := filepath.Rel(dir, path)
+		if IsIgnored(filepath.ToSlash(relPath), gamignorePatterns) || nested.ignored(path) {
+			return nil
+		}
+		issues, err := LintFile(path)
+		if err != nil {
+			return nil
+		}
+		for i := range issues {
+			issues[i].File = NormalizePath(dir, path)
+		}
+		all = append(all, issues...)
+		return nil
+	})
+	return all, err
+}
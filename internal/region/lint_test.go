@@ -0,0 +1,87 @@
+package region
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func lintContent(t *testing.T, name, content string) []LintIssue {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := LintFile(file)
+	if err != nil {
+		t.Fatalf("LintFile error: %v", err)
+	}
+	return issues
+}
+
+func TestLintFileOutOfOrder(t *testing.T) {
+	issues := lintContent(t, "order.go", `package test
+// @endregion:app.order
+func f() {}
+// @region:app.order
+`)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %v", len(issues), issues)
+	}
+	if issues[0].Line != 2 || !strings.Contains(issues[0].Message, "before its @region at line 4") {
+		t.Errorf("unexpected issue: %s", issues[0])
+	}
+	if issues[0].Fix == "" {
+		t.Error("expected a fix hint")
+	}
+}
+
+func TestLintFileDuplicateCloser(t *testing.T) {
+	issues := lintContent(t, "dup.go", `// @region:app.dup
+package test
+// @endregion:app.dup
+// @endregion:app.dup
+`)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %v", len(issues), issues)
+	}
+	if issues[0].Line != 4 || !strings.Contains(issues[0].Message, "duplicate @endregion:app.dup (already closed at line 3)") {
+		t.Errorf("unexpected issue: %s", issues[0])
+	}
+}
+
+func TestLintFileHygiene(t *testing.T) {
+	issues := lintContent(t, "smell.py", `x = 1  # @region:app.inline
+# @endregion: app.inline
+# @region:app.open
+`)
+	want := []struct {
+		line int
+		msg  string
+	}{
+		{1, "not on its own line"},
+		{2, "whitespace between @endregion: and path"},
+		{3, "never closed"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %d: %v", len(want), len(issues), issues)
+	}
+	for i, w := range want {
+		if issues[i].Line != w.line || !strings.Contains(issues[i].Message, w.msg) {
+			t.Errorf("issue %d = %s, want line %d containing %q", i, issues[i], w.line, w.msg)
+		}
+	}
+}
+
+func TestLintFileClean(t *testing.T) {
+	issues := lintContent(t, "ok.go", `// @region:app.a
+// @region:app.a.b
+package test
+// @endregion:app.a.b
+// @endregion:app.a
+`)
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
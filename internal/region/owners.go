@@ -0,0 +1,71 @@
+package region
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OwnersEntry maps a source file to the owners of the regions it contains.
+type OwnersEntry struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// ResolveOwner returns the owner of path, inheriting from the nearest
+// ancestor namespace when path itself has none.
+func ResolveOwner(path string, owners map[string]string) string {
+	for p := path; p != ""; {
+		if o := owners[p]; o != "" {
+			return o
+		}
+		i := strings.LastIndex(p, ".")
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	return ""
+}
+
+// CodeOwners maps every file containing region markers to the owners of those
+// regions. Patterns are root-relative and anchored with a leading slash as in
+// a GitHub CODEOWNERS file. Files whose regions have no owner are omitted.
+// Entries are sorted by pattern and owners are sorted within an entry.
+func CodeOwners(root string, markers []*RegionMarker, owners map[string]string) []OwnersEntry {
+	byFile := make(map[string]map[string]bool)
+	for _, m := range markers {
+		owner := ResolveOwner(m.Path, owners)
+		if owner == "" {
+			continue
+		}
+		pattern := "/" + NormalizePath(root, filepath.FromSlash(m.File))
+		if byFile[pattern] == nil {
+			byFile[pattern] = make(map[string]bool)
+		}
+		byFile[pattern][owner] = true
+	}
+
+	entries := make([]OwnersEntry, 0, len(byFile))
+	for pattern, set := range byFile {
+		e := OwnersEntry{Pattern: pattern}
+		for o := range set {
+			e.Owners = append(e.Owners, o)
+		}
+		sort.Strings(e.Owners)
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Pattern < entries[j].Pattern })
+	return entries
+}
+
+// FormatCodeOwners renders entries as CODEOWNERS lines.
+func FormatCodeOwners(entries []OwnersEntry) string {
+	var b strings.Builder
+	b.WriteString("# Generated by gam export owners from region owners. Do not edit.\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%s %s\n", e.Pattern, strings.Join(e.Owners, " "))
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package region
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCodeOwners(t *testing.T) {
+	root := "/repo"
+	markers := []*RegionMarker{
+		{Path: "app.auth", File: filepath.Join(root, "auth/login.go")},
+		{Path: "app.auth.password", File: filepath.Join(root, "auth/password.go")},
+		{Path: "app.search", File: filepath.Join(root, "search/query.go")},
+		{Path: "app.billing", File: filepath.Join(root, "search/query.go")},
+		{Path: "lib.util", File: filepath.Join(root, "util/strings.go")},
+	}
+	owners := map[string]string{
+		"app":         "@org/platform",
+		"app.auth":    "@org/identity",
+		"app.billing": "@org/payments",
+	}
+
+	got := CodeOwners(root, markers, owners)
+	want := []OwnersEntry{
+		{Pattern: "/auth/login.go", Owners: []string{"@org/identity"}},
+		{Pattern: "/auth/password.go", Owners: []string{"@org/identity"}},
+		{Pattern: "/search/query.go", Owners: []string{"@org/payments", "@org/platform"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CodeOwners = %+v, want %+v", got, want)
+	}
+
+	wantFile := "# Generated by gam export owners from region owners. Do not edit.\n" +
+		"/auth/login.go @org/identity\n" +
+		"/auth/password.go @org/identity\n" +
+		"/search/query.go @org/payments @org/platform\n"
+	if s := FormatCodeOwners(got); s != wantFile {
+		t.Errorf("FormatCodeOwners =\n%s\nwant\n%s", s, wantFile)
+	}
+}
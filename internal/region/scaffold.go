@@ -0,0 +1,68 @@
+package region
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ScaffoldEntry is the outcome of scaffolding one arch.md path.
+type ScaffoldEntry struct {
+	Path   string `json:"path"`
+	File   string `json:"file,omitempty"`
+	Reason string `json:"reason,omitempty"` // why a path was skipped
+}
+
+// ScaffoldReport lists the arch.md paths ScaffoldArch created markers for and
+// those it skipped.
+type ScaffoldReport struct {
+	Created []ScaffoldEntry `json:"created"`
+	Skipped []ScaffoldEntry `json:"skipped"`
+}
+
+// LoadFileMap reads a JSON object mapping region paths to project-relative
+// files, e.g. {"app.search": "src/search/search.go"}.
+func LoadFileMap(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fileMap map[string]string
+	if err := json.Unmarshal(data, &fileMap); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return fileMap, nil
+}
+
+// ScaffoldArch scaffolds markers for every arch.md entry into the file
+// fileMap assigns it, creating files as needed. Entries are processed in
+// arch.md order, so regions sharing a file appear in that order. Paths with
+// no mapping, or whose file already has their markers, are skipped.
+func ScaffoldArch(projectRoot string, fileMap map[string]string) (ScaffoldReport, error) {
+	var report ScaffoldReport
+	entries, err := ParseArchMdEntries(projectRoot)
+	if err != nil {
+		return report, fmt.Errorf("parse arch.md: %w", err)
+	}
+	for _, e := range entries {
+		file, ok := fileMap[e.Path]
+		if !ok {
+			report.Skipped = append(report.Skipped, ScaffoldEntry{Path: e.Path, Reason: "no file mapped"})
+			continue
+		}
+		target := file
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(projectRoot, file)
+		}
+		if FileHasRegionMarkers(target, e.Path) {
+			report.Skipped = append(report.Skipped, ScaffoldEntry{Path: e.Path, File: file, Reason: "markers already present"})
+			continue
+		}
+		if err := ScaffoldRegion(target, e.Path); err != nil {
+			return report, fmt.Errorf("scaffold %s in %s: %w", e.Path, file, err)
+		}
+		report.Created = append(report.Created, ScaffoldEntry{Path: e.Path, File: file})
+	}
+	return report, nil
+}
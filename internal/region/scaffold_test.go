@@ -0,0 +1,66 @@
+package region
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScaffoldArch(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "arch.md"), []byte(`# @region:app Application
+# @region:app.search Search
+# @endregion:app.search
+# @region:app.billing Billing
+# @endregion:app.billing
+# @endregion:app
+# @region:app.unmapped
+# @endregion:app.unmapped
+`), 0644)
+	os.WriteFile(filepath.Join(dir, "app.go"), []byte("// @region:app\npackage app\n// @endregion:app\n"), 0644)
+
+	mapFile := filepath.Join(dir, "map.json")
+	os.WriteFile(mapFile, []byte(`{
+  "app": "app.go",
+  "app.search": "search/search.go",
+  "app.billing": "billing/billing.py"
+}`), 0644)
+	fileMap, err := LoadFileMap(mapFile)
+	if err != nil {
+		t.Fatalf("LoadFileMap: %v", err)
+	}
+
+	report, err := ScaffoldArch(dir, fileMap)
+	if err != nil {
+		t.Fatalf("ScaffoldArch: %v", err)
+	}
+	if len(report.Created) != 2 || report.Created[0].Path != "app.search" || report.Created[1].Path != "app.billing" {
+		t.Errorf("created = %+v, want app.search, app.billing", report.Created)
+	}
+	wantSkipped := map[string]string{"app": "markers already present", "app.unmapped": "no file mapped"}
+	if len(report.Skipped) != len(wantSkipped) {
+		t.Errorf("skipped = %+v", report.Skipped)
+	}
+	for _, s := range report.Skipped {
+		if wantSkipped[s.Path] != s.Reason {
+			t.Errorf("skipped %s for %q, want %q", s.Path, s.Reason, wantSkipped[s.Path])
+		}
+	}
+
+	if !FileHasRegionMarkers(filepath.Join(dir, "search", "search.go"), "app.search") {
+		t.Error("app.search markers not created")
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "billing", "billing.py"))
+	if string(data) != "# @region:app.billing\n\n# @endregion:app.billing\n" {
+		t.Errorf("billing.py = %q", data)
+	}
+
+	// A second run creates nothing.
+	report, err = ScaffoldArch(dir, fileMap)
+	if err != nil {
+		t.Fatalf("ScaffoldArch rerun: %v", err)
+	}
+	if len(report.Created) != 0 {
+		t.Errorf("rerun created %+v, want nothing", report.Created)
+	}
+}
@@ -3,7 +3,9 @@ package region
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -15,6 +17,7 @@ type RegionMarker struct {
 	File      string // source file path
 	StartLine int    // line number of @region marker
 	EndLine   int    // line number of @endregion marker (0 if unclosed)
+	Note      string // annotation shown in tree views (e.g., lifecycle state)
 	Children  []*RegionMarker
 }
 
@@ -25,6 +28,7 @@ type TreeNode struct {
 	File     string
 	Start    int
 	End      int
+	Note     string
 	Children []*TreeNode
 }
 
@@ -62,6 +66,14 @@ var HTMLStyleExtensions = map[string]bool{
 	".svelte": true,
 }
 
+// IsScannable reports whether a file has an extension the scanner reads for
+// region markers.
+func IsScannable(filename string) bool {
+	ext := filepath.Ext(filename)
+	_, ok := CommentStyle[ext]
+	return ok || HTMLStyleExtensions[ext]
+}
+
 // GetCommentPrefix returns the comment prefix for a file extension.
 func GetCommentPrefix(filename string) string {
 	ext := filepath.Ext(filename)
@@ -100,19 +112,46 @@ func GetEndRegionTag(path, filename string) string {
 	return fmt.Sprintf("%s @endregion:%s", prefix, path)
 }
 
-// ScanFile scans a source file for region markers and returns them.
+// NormalizePath returns path relative to root with forward slashes, the form
+// markers, warnings and tree snapshots record so they compare equal across
+// operating systems and checkouts. A path outside root keeps its own
+// location, with forward slashes.
+func NormalizePath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// ScanFile scans a source file for region markers and returns them. Marker
+// and warning file names are filename with forward slashes.
 func ScanFile(filename string) ([]*RegionMarker, []string, error) {
+	return scanFile(filename, filepath.ToSlash(filename))
+}
+
+// scanFile scans filename, recording it as name in markers and warnings.
+func scanFile(filename, name string) ([]*RegionMarker, []string, error) {
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer f.Close()
+	return ScanReader(f, name)
+}
 
+// ScanReader scans source read from r for region markers, recording name as
+// the file in markers and warnings. It serves content that is not on disk,
+// such as a file at another git revision.
+func ScanReader(r io.Reader, name string) ([]*RegionMarker, []string, error) {
 	var markers []*RegionMarker
 	var warnings []string
 	openRegions := make(map[string]*RegionMarker)
+	// Closers with no open region, held until EOF so a closer that sits
+	// above its opener is reported once as an inversion.
+	var strayEnds []*RegionMarker
+	inverted := make(map[*RegionMarker]*RegionMarker)
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	lineNum := 0
 	for scanner.Scan() {
 		lineNum++
@@ -121,11 +160,17 @@ func ScanFile(filename string) ([]*RegionMarker, []string, error) {
 		if path, ok := extractRegionPath(line, "region"); ok {
 			marker := &RegionMarker{
 				Path:      path,
-				File:      filename,
+				File:      name,
 				StartLine: lineNum,
 			}
 			openRegions[path] = marker
 			markers = append(markers, marker)
+			for _, end := range strayEnds {
+				if end.Path == path && inverted[end] == nil {
+					inverted[end] = marker
+					break
+				}
+			}
 		}
 
 		if path, ok := extractRegionPath(line, "endregion"); ok {
@@ -133,18 +178,34 @@ func ScanFile(filename string) ([]*RegionMarker, []string, error) {
 				m.EndLine = lineNum
 				delete(openRegions, path)
 			} else {
-				warnings = append(warnings, fmt.Sprintf(
-					"%s:%d: @endregion:%s without matching @region",
-					filename, lineNum, path,
-				))
+				strayEnds = append(strayEnds, &RegionMarker{Path: path, File: name, EndLine: lineNum})
 			}
 		}
 	}
 
+	invertedStarts := make(map[*RegionMarker]bool)
+	for _, end := range strayEnds {
+		if start := inverted[end]; start != nil {
+			invertedStarts[start] = true
+			warnings = append(warnings, fmt.Sprintf(
+				"%s:%d: @endregion:%s before its @region at line %d",
+				name, end.EndLine, end.Path, start.StartLine,
+			))
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"%s:%d: @endregion:%s without matching @region",
+			name, end.EndLine, end.Path,
+		))
+	}
+
 	for path, m := range openRegions {
+		if invertedStarts[m] {
+			continue
+		}
 		warnings = append(warnings, fmt.Sprintf(
 			"%s:%d: @region:%s never closed",
-			filename, m.StartLine, path,
+			name, m.StartLine, path,
 		))
 	}
 
@@ -173,10 +234,14 @@ func extractRegionPath(line, tag string) (string, bool) {
 }
 
 // ScanDirectory scans all source files in a directory tree for region markers.
+// Marker files are recorded relative to dir with forward slashes.
+// gamignorePatterns are matched relative to dir; a .gamignore in a
+// subdirectory additionally excludes paths within that subdirectory.
 func ScanDirectory(dir string, gamignorePatterns []string) ([]*RegionMarker, []string, error) {
 	var allMarkers []*RegionMarker
 	var allWarnings []string
 
+	nested := newNestedIgnores(dir)
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -186,24 +251,22 @@ func ScanDirectory(dir string, gamignorePatterns []string) ([]*RegionMarker, []s
 			if base == ".git" || base == "node_modules" || base == "vendor" {
 				return filepath.SkipDir
 			}
+			nested.visit(path)
 			return nil
 		}
 
 		// Check if file has a known extension
-		ext := filepath.Ext(path)
-		if _, ok := CommentStyle[ext]; !ok {
-			if !HTMLStyleExtensions[ext] {
-				return nil
-			}
+		if !IsScannable(path) {
+			return nil
 		}
 
 		// Check gamignore
 		relPath, _ := filepath.Rel(dir, path)
-		if isIgnored(relPath, gamignorePatterns) {
+		if IsIgnored(filepath.ToSlash(relPath), gamignorePatterns) || nested.ignored(path) {
 			return nil
 		}
 
-		markers, warnings, err := ScanFile(path)
+		markers, warnings, err := scanFile(path, NormalizePath(dir, path))
 		if err != nil {
 			return nil
 		}
@@ -215,6 +278,39 @@ func ScanDirectory(dir string, gamignorePatterns []string) ([]*RegionMarker, []s
 	return allMarkers, allWarnings, err
 }
 
+// ScanRegion scans only knownFiles (relative to root or absolute) for region
+// markers when they still contain a marker for regionPath itself, avoiding a
+// walk of the whole project. It falls back to ScanDirectory when knownFiles
+// is empty or stale, including when only descendants' markers remain there
+// because the region's own markers moved; scoped reports which path was taken.
+func ScanRegion(root, regionPath string, knownFiles, gamignorePatterns []string) (markers []*RegionMarker, warnings []string, scoped bool, err error) {
+	found := false
+	for _, f := range knownFiles {
+		f = filepath.FromSlash(f)
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(root, f)
+		}
+		fileMarkers, fileWarnings, scanErr := scanFile(f, NormalizePath(root, f))
+		if scanErr != nil {
+			found = false
+			break
+		}
+		for _, m := range fileMarkers {
+			if m.Path == regionPath {
+				found = true
+			}
+		}
+		markers = append(markers, fileMarkers...)
+		warnings = append(warnings, fileWarnings...)
+	}
+	if found {
+		return markers, warnings, true, nil
+	}
+
+	markers, warnings, err = ScanDirectory(root, gamignorePatterns)
+	return markers, warnings, false, err
+}
+
 // BuildTree constructs a tree from a flat list of region markers.
 func BuildTree(markers []*RegionMarker) *TreeNode {
 	root := &TreeNode{Name: "root", FullPath: ""}
@@ -242,6 +338,7 @@ func BuildTree(markers []*RegionMarker) *TreeNode {
 				child.File = m.File
 				child.Start = m.StartLine
 				child.End = m.EndLine
+				child.Note = m.Note
 			}
 			current.Children = append(current.Children, child)
 			nodeMap[fullPath] = child
@@ -254,16 +351,61 @@ func BuildTree(markers []*RegionMarker) *TreeNode {
 
 // FormatTree produces a text tree view from a TreeNode.
 func FormatTree(node *TreeNode, prefix string, isLast bool) string {
+	return formatTree(node, prefix, isLast, FormatTreeOpts{})
+}
+
+// FormatTreeOpts controls FormatTreeWithOpts. The zero value produces the
+// same plain output as FormatTree, which is what pipes and non-TTY output use.
+type FormatTreeOpts struct {
+	Color    bool                     // color annotations by lifecycle state
+	Width    int                      // truncate lines to this many columns (0 = no limit)
+	Annotate func(path string) string // annotation for a region path; overrides TreeNode.Note
+}
+
+// lifecycleColors maps lifecycle states to ANSI color codes. Annotations
+// beginning with a state (e.g., "draft, 2 concept(s)") take its color.
+var lifecycleColors = map[string]string{
+	"active":     "32", // green
+	"draft":      "33", // yellow
+	"deprecated": "31", // red
+}
+
+// FormatTreeWithOpts produces a text tree view with optional lifecycle
+// coloring, injected annotations, and width truncation.
+func FormatTreeWithOpts(node *TreeNode, opts FormatTreeOpts) string {
+	return formatTree(node, "", true, opts)
+}
+
+// treeSegment is a piece of a tree line rendered with an optional color.
+type treeSegment struct {
+	text  string
+	color string
+}
+
+func formatTree(node *TreeNode, prefix string, isLast bool, opts FormatTreeOpts) string {
 	var sb strings.Builder
 	if node.Name != "root" {
 		connector := "├── "
 		if isLast {
 			connector = "└── "
 		}
-		sb.WriteString(prefix + connector + node.Name)
+		segs := []treeSegment{{text: prefix + connector + node.Name}}
 		if node.File != "" {
-			sb.WriteString(fmt.Sprintf("    [%s:%d-%d]", node.File, node.Start, node.End))
+			segs = append(segs, treeSegment{text: fmt.Sprintf("    [%s:%d-%d]", node.File, node.Start, node.End)})
+		}
+		note := node.Note
+		if opts.Annotate != nil {
+			note = opts.Annotate(node.FullPath)
 		}
+		if note != "" {
+			var color string
+			if opts.Color {
+				state, _, _ := strings.Cut(note, ",")
+				color = lifecycleColors[strings.TrimSpace(state)]
+			}
+			segs = append(segs, treeSegment{text: fmt.Sprintf("    (%s)", note), color: color})
+		}
+		sb.WriteString(renderSegments(segs, opts.Width))
 		sb.WriteString("\n")
 	}
 
@@ -278,12 +420,46 @@ func FormatTree(node *TreeNode, prefix string, isLast bool) string {
 
 	for i, child := range node.Children {
 		isChildLast := i == len(node.Children)-1
-		sb.WriteString(FormatTree(child, childPrefix, isChildLast))
+		sb.WriteString(formatTree(child, childPrefix, isChildLast, opts))
 	}
 
 	return sb.String()
 }
 
+// renderSegments joins segs, cutting the line to width visible columns with
+// a trailing ellipsis. Color escapes do not count toward the width.
+func renderSegments(segs []treeSegment, width int) string {
+	var sb strings.Builder
+	remaining := width
+	for _, seg := range segs {
+		text := seg.text
+		truncated := false
+		if width > 0 {
+			runes := []rune(text)
+			if len(runes) > remaining {
+				if remaining > 0 {
+					text = string(runes[:remaining-1]) + "…"
+				} else {
+					text = ""
+				}
+				truncated = true
+			}
+			remaining -= len(runes)
+		}
+		if text != "" {
+			if seg.color != "" {
+				sb.WriteString("\x1b[" + seg.color + "m" + text + "\x1b[0m")
+			} else {
+				sb.WriteString(text)
+			}
+		}
+		if truncated {
+			break
+		}
+	}
+	return sb.String()
+}
+
 // FileHasRegionMarkers checks if a file contains region markers for a given path.
 func FileHasRegionMarkers(filename, regionPath string) bool {
 	markers, _, err := ScanFile(filename)
@@ -300,8 +476,43 @@ func FileHasRegionMarkers(filename, regionPath string) bool {
 
 // ScaffoldRegion creates or appends region markers in a file.
 func ScaffoldRegion(filename, regionPath string) error {
-	startTag := GetRegionTag(regionPath, filename)
-	endTag := GetEndRegionTag(regionPath, filename)
+	return scaffoldRegion(filename, regionPath, filename)
+}
+
+// ScaffoldRegionLang is ScaffoldRegion with the comment style chosen by lang
+// (an extension such as go, py, sql or html) instead of filename's own
+// extension, for files with an unusual or missing extension.
+func ScaffoldRegionLang(filename, regionPath, lang string) error {
+	ext, err := LangExtension(lang)
+	if err != nil {
+		return err
+	}
+	return scaffoldRegion(filename, regionPath, "file"+ext)
+}
+
+// LangExtension validates lang against the known comment styles and returns
+// it as an extension with a leading dot.
+func LangExtension(lang string) (string, error) {
+	ext := "." + strings.TrimPrefix(strings.ToLower(lang), ".")
+	if _, ok := CommentStyle[ext]; ok || HTMLStyleExtensions[ext] {
+		return ext, nil
+	}
+	var known []string
+	for e := range CommentStyle {
+		known = append(known, e[1:])
+	}
+	for e := range HTMLStyleExtensions {
+		known = append(known, e[1:])
+	}
+	sort.Strings(known)
+	return "", fmt.Errorf("unknown lang %q (known: %s)", lang, strings.Join(known, ", "))
+}
+
+// scaffoldRegion writes markers for regionPath into filename using the
+// comment style of styleFile.
+func scaffoldRegion(filename, regionPath, styleFile string) error {
+	startTag := GetRegionTag(regionPath, styleFile)
+	endTag := GetEndRegionTag(regionPath, styleFile)
 
 	// Check if file exists
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
@@ -311,7 +522,7 @@ func ScaffoldRegion(filename, regionPath string) error {
 			return fmt.Errorf("create directory: %w", err)
 		}
 
-		ext := filepath.Ext(filename)
+		ext := filepath.Ext(styleFile)
 		var content string
 		if ext == ".go" {
 			pkg := filepath.Base(filepath.Dir(filename))
@@ -357,9 +568,11 @@ func ParseGamignore(projectRoot string) []string {
 	return patterns
 }
 
-func isIgnored(relPath string, patterns []string) bool {
+// IsIgnored reports whether a project-relative, slash-separated path matches
+// a .gamignore pattern. Patterns use forward slashes on every OS.
+func IsIgnored(relPath string, patterns []string) bool {
 	for _, pattern := range patterns {
-		matched, err := filepath.Match(pattern, relPath)
+		matched, err := path.Match(pattern, relPath)
 		if err == nil && matched {
 			return true
 		}
@@ -459,8 +672,8 @@ func extractArchDescription(line, path string) string {
 	return rest
 }
 
-// isValidNamespace checks if a string is a valid dot-separated namespace path.
-func isValidNamespace(s string) bool {
+// IsValidNamespace reports whether a string is a valid dot-separated namespace path.
+func IsValidNamespace(s string) bool {
 	if s == "" {
 		return false
 	}
@@ -515,24 +728,95 @@ func ValidateArchNamespaces(projectRoot string) []string {
 	return issues
 }
 
+// ShapeOpts limits the shape of the arch.md namespace tree. Zero values
+// disable the corresponding check.
+type ShapeOpts struct {
+	MaxDepth      int      // maximum number of path segments
+	MaxChildren   int      // maximum direct children per namespace
+	RequiredRoots []string // top-level namespaces that must be defined
+}
+
+// ValidateArchShape checks arch.md namespaces against depth, fan-out, and
+// required-root limits.
+func ValidateArchShape(projectRoot string, opts ShapeOpts) []string {
+	entries, err := ParseArchMdEntries(projectRoot)
+	if err != nil {
+		return []string{fmt.Sprintf("cannot read arch.md: %v", err)}
+	}
+
+	lines := make(map[string]int)
+	children := make(map[string][]string)
+	var parents []string
+	for _, e := range entries {
+		lines[e.Path] = e.Line
+		segments := strings.Split(e.Path, ".")
+		if len(segments) <= 1 {
+			continue
+		}
+		parent := strings.Join(segments[:len(segments)-1], ".")
+		if children[parent] == nil {
+			parents = append(parents, parent)
+		}
+		children[parent] = append(children[parent], e.Path)
+	}
+
+	var issues []string
+	for _, root := range opts.RequiredRoots {
+		if _, ok := lines[root]; !ok {
+			issues = append(issues, fmt.Sprintf("arch.md: required root namespace %s is not defined", root))
+		}
+	}
+
+	if opts.MaxDepth > 0 {
+		for _, e := range entries {
+			if depth := len(strings.Split(e.Path, ".")); depth > opts.MaxDepth {
+				issues = append(issues, fmt.Sprintf(
+					"arch.md:%d: namespace %s has depth %d (max %d)",
+					e.Line, e.Path, depth, opts.MaxDepth,
+				))
+			}
+		}
+	}
+
+	if opts.MaxChildren > 0 {
+		for _, parent := range parents {
+			kids := children[parent]
+			if len(kids) <= opts.MaxChildren {
+				continue
+			}
+			line, ok := lines[parent]
+			if !ok {
+				line = lines[kids[0]]
+			}
+			issues = append(issues, fmt.Sprintf(
+				"arch.md:%d: namespace %s has %d children (max %d)",
+				line, parent, len(kids), opts.MaxChildren,
+			))
+		}
+	}
+	return issues
+}
+
 // FindUnregionedCode finds source files with code not inside any region markers.
 func FindUnregionedCode(dir string, gamignorePatterns []string) ([]string, error) {
 	var unregioned []string
 
+	nested := newNestedIgnores(dir)
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
+		if err != nil {
 			return err
 		}
+		if info.IsDir() {
+			nested.visit(path)
+			return nil
+		}
 
-		ext := filepath.Ext(path)
-		if _, ok := CommentStyle[ext]; !ok {
-			if !HTMLStyleExtensions[ext] {
-				return nil
-			}
+		if !IsScannable(path) {
+			return nil
 		}
 
 		relPath, _ := filepath.Rel(dir, path)
-		if isIgnored(relPath, gamignorePatterns) {
+		if IsIgnored(filepath.ToSlash(relPath), gamignorePatterns) || nested.ignored(path) {
 			return nil
 		}
 
@@ -542,10 +826,23 @@ func FindUnregionedCode(dir string, gamignorePatterns []string) ([]string, error
 		}
 
 		if len(markers) == 0 {
-			unregioned = append(unregioned, relPath)
+			unregioned = append(unregioned, filepath.ToSlash(relPath))
 		}
 		return nil
 	})
 
 	return unregioned, err
 }
+
+// RegionLOC returns the lines of code enclosed by each region's markers,
+// summed across files. Lines of nested regions count toward their ancestors
+// too; unclosed markers are skipped.
+func RegionLOC(markers []*RegionMarker) map[string]int {
+	loc := make(map[string]int)
+	for _, m := range markers {
+		if m.EndLine > m.StartLine {
+			loc[m.Path] += m.EndLine - m.StartLine - 1
+		}
+	}
+	return loc
+}
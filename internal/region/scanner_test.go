@@ -152,6 +152,31 @@ package test
 	}
 }
 
+func TestScanFileInvertedMarkers(t *testing.T) {
+	dir := t.TempDir()
+
+	content := `package test
+// @endregion:app.inverted
+func f() {}
+// @region:app.inverted
+`
+	file := filepath.Join(dir, "inverted.go")
+	os.WriteFile(file, []byte(content), 0644)
+
+	_, warnings, err := ScanFile(file)
+	if err != nil {
+		t.Fatalf("ScanFile error: %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d: %v", len(warnings), warnings)
+	}
+	want := file + ":2: @endregion:app.inverted before its @region at line 4"
+	if warnings[0] != want {
+		t.Errorf("warning = %q, want %q", warnings[0], want)
+	}
+}
+
 func TestBuildTree(t *testing.T) {
 	markers := []*RegionMarker{
 		{Path: "app.search", File: "search.go", StartLine: 1, EndLine: 50},
@@ -192,6 +217,74 @@ func TestFormatTree(t *testing.T) {
 	}
 }
 
+func TestFormatTreeNote(t *testing.T) {
+	markers := []*RegionMarker{
+		{Path: "app", Note: "active, 2 concept(s)"},
+		{Path: "app.api", Note: "draft, 0 concept(s)"},
+	}
+	output := FormatTree(BuildTree(markers), "", true)
+	if !strings.Contains(output, "app    (active, 2 concept(s))") {
+		t.Errorf("expected app annotation in output:\n%s", output)
+	}
+	if !strings.Contains(output, "api    (draft, 0 concept(s))") {
+		t.Errorf("expected api annotation in output:\n%s", output)
+	}
+}
+
+func TestFormatTreeWithOptsAnnotate(t *testing.T) {
+	tree := BuildTree([]*RegionMarker{
+		{Path: "app", Note: "from source"},
+		{Path: "app.api"},
+		{Path: "app.old"},
+	})
+	states := map[string]string{"app": "active", "app.api": "draft", "app.old": "deprecated"}
+	annotate := func(path string) string { return states[path] }
+
+	plain := FormatTreeWithOpts(tree, FormatTreeOpts{Annotate: annotate})
+	if strings.Contains(plain, "from source") {
+		t.Errorf("Annotate should override node notes:\n%s", plain)
+	}
+	if !strings.Contains(plain, "api    (draft)") || strings.Contains(plain, "\x1b[") {
+		t.Errorf("expected uncolored annotation:\n%s", plain)
+	}
+
+	colored := FormatTreeWithOpts(tree, FormatTreeOpts{Color: true, Annotate: annotate})
+	for _, want := range []string{"\x1b[32m    (active)\x1b[0m", "\x1b[33m    (draft)\x1b[0m", "\x1b[31m    (deprecated)\x1b[0m"} {
+		if !strings.Contains(colored, want) {
+			t.Errorf("expected %q in colored output:\n%s", want, colored)
+		}
+	}
+
+	if got, want := FormatTreeWithOpts(tree, FormatTreeOpts{}), FormatTree(tree, "", true); got != want {
+		t.Errorf("zero opts output differs from FormatTree:\n%s\nvs\n%s", got, want)
+	}
+}
+
+func TestFormatTreeWithOptsWidth(t *testing.T) {
+	tree := BuildTree([]*RegionMarker{
+		{Path: "application", File: "main.go", StartLine: 1, EndLine: 200},
+	})
+
+	out := FormatTreeWithOpts(tree, FormatTreeOpts{Width: 20})
+	line := strings.TrimSuffix(out, "\n")
+	if n := len([]rune(line)); n != 20 {
+		t.Errorf("line width = %d, want 20: %q", n, line)
+	}
+	if !strings.HasSuffix(line, "…") {
+		t.Errorf("truncated line should end with ellipsis: %q", line)
+	}
+
+	colored := FormatTreeWithOpts(tree, FormatTreeOpts{Width: 30, Color: true, Annotate: func(string) string { return "draft" }})
+	if strings.Contains(colored, "draft") {
+		t.Errorf("annotation past width should be dropped: %q", colored)
+	}
+
+	wide := FormatTreeWithOpts(tree, FormatTreeOpts{Width: 200})
+	if strings.Contains(wide, "…") {
+		t.Errorf("line within width should not be truncated: %q", wide)
+	}
+}
+
 func TestScaffoldRegion(t *testing.T) {
 	dir := t.TempDir()
 
@@ -341,9 +434,9 @@ func TestIsValidNamespace(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		got := isValidNamespace(tt.input)
+		got := IsValidNamespace(tt.input)
 		if got != tt.want {
-			t.Errorf("isValidNamespace(%q) = %v, want %v", tt.input, got, tt.want)
+			t.Errorf("IsValidNamespace(%q) = %v, want %v", tt.input, got, tt.want)
 		}
 	}
 }
@@ -387,6 +480,89 @@ func TestValidateArchNamespaces(t *testing.T) {
 	}
 }
 
+func TestScaffoldRegionLang(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "migrate")
+
+	if err := ScaffoldRegionLang(file, "app.migrate", "sql"); err != nil {
+		t.Fatalf("ScaffoldRegionLang error: %v", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read file error: %v", err)
+	}
+	want := "-- @region:app.migrate\n\n-- @endregion:app.migrate\n"
+	if string(data) != want {
+		t.Errorf("content = %q, want %q", data, want)
+	}
+
+	// The forced style also applies when appending to an existing file.
+	if err := ScaffoldRegionLang(file, "app.seed", ".SQL"); err != nil {
+		t.Fatalf("ScaffoldRegionLang append error: %v", err)
+	}
+	data, _ = os.ReadFile(file)
+	if !strings.Contains(string(data), "-- @region:app.seed") {
+		t.Errorf("appended region should use -- comments:\n%s", data)
+	}
+
+	if err := ScaffoldRegionLang(filepath.Join(dir, "x"), "app.x", "cobol"); err == nil || !strings.Contains(err.Error(), "known: ") {
+		t.Errorf("expected unknown lang error listing styles, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "x")); !os.IsNotExist(err) {
+		t.Error("an unknown lang should not create the file")
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator)+"work", "project")
+	tests := []struct {
+		path string
+		want string
+	}{
+		{filepath.Join(root, "pkg", "search", "query.go"), "pkg/search/query.go"},
+		{filepath.Join(root, "main.go"), "main.go"},
+		{filepath.Join(string(filepath.Separator)+"elsewhere", "x.go"), filepath.ToSlash(filepath.Join(string(filepath.Separator)+"elsewhere", "x.go"))},
+	}
+	for _, tt := range tests {
+		if got := NormalizePath(root, tt.path); got != tt.want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestScanDirectoryNormalizesPaths(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "pkg", "search")
+	os.MkdirAll(sub, 0755)
+	os.WriteFile(filepath.Join(sub, "query.go"), []byte("// @region:app.search.query\npackage search\n// @endregion:app.search.query\n"), 0644)
+	os.WriteFile(filepath.Join(sub, "open.go"), []byte("// @region:app.search.open\npackage search\n"), 0644)
+
+	markers, warnings, err := ScanDirectory(dir, nil)
+	if err != nil {
+		t.Fatalf("ScanDirectory: %v", err)
+	}
+	files := make(map[string]string)
+	for _, m := range markers {
+		files[m.Path] = m.File
+	}
+	if files["app.search.query"] != "pkg/search/query.go" || files["app.search.open"] != "pkg/search/open.go" {
+		t.Errorf("marker files = %v, want root-relative forward-slash paths", files)
+	}
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "pkg/search/open.go:1:") {
+		t.Errorf("warnings = %v, want one for pkg/search/open.go", warnings)
+	}
+
+	// Scoped scans take snapshot-style forward-slash files and record the
+	// same form.
+	scopedMarkers, _, scoped, err := ScanRegion(dir, "app.search.query", []string{"pkg/search/query.go"}, nil)
+	if err != nil || !scoped {
+		t.Fatalf("ScanRegion scoped=%v err=%v", scoped, err)
+	}
+	if len(scopedMarkers) != 1 || scopedMarkers[0].File != "pkg/search/query.go" {
+		t.Errorf("scoped markers = %+v", scopedMarkers)
+	}
+}
+
 func TestParseGamignore(t *testing.T) {
 	dir := t.TempDir()
 	content := `# Comment
@@ -402,6 +578,56 @@ testdata/
 	}
 }
 
+func TestScanDirectoryNestedGamignore(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		".gamignore":               "generated.go\n",
+		"generated.go":             "// @region:app.generated\n// @endregion:app.generated\n",
+		"keep.go":                  "// @region:app.keep\n// @endregion:app.keep\n",
+		"svc/.gamignore":           "# only applies under svc/\nfixtures/\nkeep.go\n",
+		"svc/keep.go":              "// @region:svc.keep\n// @endregion:svc.keep\n",
+		"svc/main.go":              "// @region:svc.main\n// @endregion:svc.main\n",
+		"svc/fixtures/fixture.go":  "// @region:svc.fixture\n// @endregion:svc.fixture\n",
+		"svc/sub/generated.go":     "// @region:svc.sub.generated\n// @endregion:svc.sub.generated\n",
+		"other/fixtures/helper.go": "// @region:other.fixture\n// @endregion:other.fixture\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	markers, _, err := ScanDirectory(dir, ParseGamignore(dir))
+	if err != nil {
+		t.Fatalf("ScanDirectory: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, m := range markers {
+		got[m.Path] = true
+	}
+
+	// The root file excludes generated.go at the root only; svc/.gamignore
+	// excludes svc/fixtures/ and svc/keep.go but not the root keep.go or
+	// other/fixtures/.
+	for path, want := range map[string]bool{
+		"app.generated":     false,
+		"app.keep":          true,
+		"svc.keep":          false,
+		"svc.main":          true,
+		"svc.fixture":       false,
+		"svc.sub.generated": true,
+		"other.fixture":     true,
+	} {
+		if got[path] != want {
+			t.Errorf("region %s scanned = %v, want %v", path, got[path], want)
+		}
+	}
+}
+
 func TestIsIgnored(t *testing.T) {
 	patterns := []string{"vendor/", "*.pb.go", "testdata/"}
 
@@ -417,9 +643,127 @@ func TestIsIgnored(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		got := isIgnored(tt.path, patterns)
+		got := IsIgnored(tt.path, patterns)
 		if got != tt.want {
-			t.Errorf("isIgnored(%q) = %v, want %v", tt.path, got, tt.want)
+			t.Errorf("IsIgnored(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestValidateArchShapeDepth(t *testing.T) {
+	dir := t.TempDir()
+	content := `# @region:app
+# @region:app.search
+# @region:app.search.sources
+# @region:app.search.sources.btv2
+# @endregion:app.search.sources.btv2
+# @endregion:app.search.sources
+# @endregion:app.search
+# @endregion:app
+`
+	os.WriteFile(filepath.Join(dir, "arch.md"), []byte(content), 0644)
+
+	issues := ValidateArchShape(dir, ShapeOpts{MaxDepth: 3})
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %v", len(issues), issues)
+	}
+	if !strings.Contains(issues[0], "app.search.sources.btv2 has depth 4 (max 3)") {
+		t.Errorf("unexpected issue: %s", issues[0])
+	}
+
+	if issues := ValidateArchShape(dir, ShapeOpts{}); len(issues) != 0 {
+		t.Errorf("expected no issues with zero limits, got %v", issues)
+	}
+}
+
+func TestValidateArchShapeFanOutAndRoots(t *testing.T) {
+	dir := t.TempDir()
+	content := `# @region:app
+# @region:app.search
+# @endregion:app.search
+# @region:app.billing
+# @endregion:app.billing
+# @region:app.auth
+# @endregion:app.auth
+# @region:app.auth.session
+# @endregion:app.auth.session
+# @endregion:app
+`
+	os.WriteFile(filepath.Join(dir, "arch.md"), []byte(content), 0644)
+
+	issues := ValidateArchShape(dir, ShapeOpts{MaxChildren: 2, RequiredRoots: []string{"app", "infra"}})
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	if !strings.Contains(issues[0], "required root namespace infra") {
+		t.Errorf("expected missing root issue, got: %s", issues[0])
+	}
+	if !strings.Contains(issues[1], "arch.md:1: namespace app has 3 children (max 2)") {
+		t.Errorf("expected fan-out issue, got: %s", issues[1])
+	}
+}
+
+func TestScanRegionReadsKnownFilesOnly(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "search"), 0755)
+	os.MkdirAll(filepath.Join(dir, "billing"), 0755)
+	os.WriteFile(filepath.Join(dir, "search", "query.go"),
+		[]byte("// @region:app.search\npackage search\n// @region:app.search.query\n// @endregion:app.search.query\n// @endregion:app.search\n"), 0644)
+	// An unclosed marker warns whenever this file is read.
+	os.WriteFile(filepath.Join(dir, "billing", "invoice.go"),
+		[]byte("// @region:app.billing\npackage billing\n"), 0644)
+
+	markers, warnings, scoped, err := ScanRegion(dir, "app.search", []string{"search/query.go"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !scoped {
+		t.Fatal("expected a scoped scan for a known region")
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unrelated file was read: %v", warnings)
+	}
+	for _, m := range markers {
+		if filepath.Base(m.File) != "query.go" {
+			t.Errorf("marker %s from %s, want only query.go", m.Path, m.File)
+		}
+	}
+	if len(markers) != 2 {
+		t.Errorf("got %d markers, want 2", len(markers))
+	}
+
+	// Unknown or stale file lists fall back to a full scan.
+	for _, known := range [][]string{nil, {"billing/invoice.go"}, {"gone.go"}} {
+		markers, _, scoped, _ = ScanRegion(dir, "app.search", known, nil)
+		if scoped || len(markers) != 3 {
+			t.Errorf("known=%v: scoped=%v markers=%d, want full scan with 3", known, scoped, len(markers))
+		}
+	}
+}
+
+func TestScanRegionFallsBackWhenOwnMarkersMoved(t *testing.T) {
+	dir := t.TempDir()
+	// The snapshot knew app.search in old.go; its markers have since moved to
+	// new.go and only a child's markers remain in old.go.
+	os.WriteFile(filepath.Join(dir, "old.go"),
+		[]byte("// @region:app.search.query\npackage search\n// @endregion:app.search.query\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "new.go"),
+		[]byte("// @region:app.search\npackage search\n// @endregion:app.search\n"), 0644)
+
+	markers, _, scoped, err := ScanRegion(dir, "app.search", []string{"old.go"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scoped {
+		t.Error("scoped scan trusted a file holding only a descendant's markers")
+	}
+	found := false
+	for _, m := range markers {
+		if m.Path == "app.search" {
+			found = true
 		}
 	}
+	if !found {
+		t.Errorf("app.search markers not found after they moved: %+v", markers)
+	}
 }
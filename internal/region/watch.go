@@ -0,0 +1,140 @@
+package region
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Watcher reports changes to files that affect region scanning. Each value
+// received from Events is the path of a changed file; bursts may be coalesced
+// into a single event, so consumers should rescan rather than diff by path.
+type Watcher interface {
+	Events() <-chan string
+	Close() error
+}
+
+// NewWatcher watches root with the platform's native file notifications,
+// falling back to polling every interval where they are unavailable.
+func NewWatcher(root string, gamignorePatterns []string, interval time.Duration) (Watcher, error) {
+	if w, err := newNotifyWatcher(root, gamignorePatterns); err == nil {
+		return w, nil
+	}
+	return NewPollWatcher(root, gamignorePatterns, interval), nil
+}
+
+// WatchRelevant reports whether a change to path should trigger a rescan:
+// a scannable source file, arch.md, or .gamignore that is not ignored.
+func WatchRelevant(root, path string, gamignorePatterns []string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	if rel == "arch.md" || rel == ".gamignore" {
+		return true
+	}
+	return IsScannable(path) && !IsIgnored(filepath.ToSlash(rel), gamignorePatterns)
+}
+
+// skipWatchDir reports whether a directory is never scanned for regions.
+func skipWatchDir(root, dir string, gamignorePatterns []string) bool {
+	switch filepath.Base(dir) {
+	case ".git", "node_modules", "vendor":
+		return true
+	}
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && rel != "." && IsIgnored(filepath.ToSlash(rel), gamignorePatterns)
+}
+
+// PollWatcher detects changes by comparing file modification times and sizes
+// on a fixed interval. It works on every platform and filesystem.
+type PollWatcher struct {
+	root     string
+	patterns []string
+	events   chan string
+	done     chan struct{}
+}
+
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+// NewPollWatcher starts polling root every interval.
+func NewPollWatcher(root string, gamignorePatterns []string, interval time.Duration) *PollWatcher {
+	w := &PollWatcher{
+		root:     root,
+		patterns: gamignorePatterns,
+		events:   make(chan string, 1),
+		done:     make(chan struct{}),
+	}
+	go w.loop(interval)
+	return w
+}
+
+// Events returns the channel of changed paths.
+func (w *PollWatcher) Events() <-chan string { return w.events }
+
+// Close stops polling.
+func (w *PollWatcher) Close() error {
+	close(w.done)
+	return nil
+}
+
+func (w *PollWatcher) loop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prev := w.snapshot()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		}
+		cur := w.snapshot()
+		if changed, ok := changedPath(prev, cur); ok {
+			select {
+			case w.events <- changed:
+			default:
+			}
+		}
+		prev = cur
+	}
+}
+
+func (w *PollWatcher) snapshot() map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	filepath.Walk(w.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if skipWatchDir(w.root, path, w.patterns) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if WatchRelevant(w.root, path, w.patterns) {
+			stamps[path] = fileStamp{mod: info.ModTime(), size: info.Size()}
+		}
+		return nil
+	})
+	return stamps
+}
+
+// changedPath returns a path that was added, removed, or modified between two
+// snapshots.
+func changedPath(prev, cur map[string]fileStamp) (string, bool) {
+	for path, s := range cur {
+		if p, ok := prev[path]; !ok || p != s {
+			return path, true
+		}
+	}
+	for path := range prev {
+		if _, ok := cur[path]; !ok {
+			return path, true
+		}
+	}
+	return "", false
+}
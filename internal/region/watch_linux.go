@@ -0,0 +1,115 @@
+package region
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE |
+	syscall.IN_MODIFY | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+// notifyWatcher watches every scanned directory under root with inotify and
+// adds watches for directories created after it starts.
+type notifyWatcher struct {
+	root     string
+	patterns []string
+	file     *os.File
+	events   chan string
+
+	mu   sync.Mutex
+	dirs map[int32]string
+}
+
+func newNotifyWatcher(root string, gamignorePatterns []string) (Watcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	w := &notifyWatcher{
+		root:     root,
+		patterns: gamignorePatterns,
+		file:     os.NewFile(uintptr(fd), "inotify"),
+		events:   make(chan string, 1),
+		dirs:     make(map[int32]string),
+	}
+	if err := w.addTree(root); err != nil {
+		w.file.Close()
+		return nil, err
+	}
+	go w.loop()
+	return w, nil
+}
+
+// Events returns the channel of changed paths.
+func (w *notifyWatcher) Events() <-chan string { return w.events }
+
+// Close releases the inotify instance and ends the event loop.
+func (w *notifyWatcher) Close() error { return w.file.Close() }
+
+func (w *notifyWatcher) addTree(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+		if skipWatchDir(w.root, path, w.patterns) {
+			return filepath.SkipDir
+		}
+		wd, err := syscall.InotifyAddWatch(int(w.file.Fd()), path, inotifyMask)
+		if err != nil {
+			return err
+		}
+		w.mu.Lock()
+		w.dirs[int32(wd)] = path
+		w.mu.Unlock()
+		return nil
+	})
+}
+
+func (w *notifyWatcher) loop() {
+	defer close(w.events)
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+		for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			nameBytes := buf[off+syscall.SizeofInotifyEvent : off+syscall.SizeofInotifyEvent+int(ev.Len)]
+			off += syscall.SizeofInotifyEvent + int(ev.Len)
+
+			w.mu.Lock()
+			dir := w.dirs[ev.Wd]
+			w.mu.Unlock()
+			if dir == "" {
+				continue
+			}
+			path := filepath.Join(dir, string(trimNUL(nameBytes)))
+
+			if ev.Mask&syscall.IN_ISDIR != 0 {
+				if ev.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 {
+					w.addTree(path)
+				}
+				continue
+			}
+			if WatchRelevant(w.root, path, w.patterns) {
+				select {
+				case w.events <- path:
+				default:
+				}
+			}
+		}
+	}
+}
+
+func trimNUL(b []byte) []byte {
+	for i, c := range b {
+		if c == 0 {
+			return b[:i]
+		}
+	}
+	return b
+}
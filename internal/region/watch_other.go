@@ -0,0 +1,9 @@
+//go:build !linux
+
+package region
+
+import "errors"
+
+func newNotifyWatcher(root string, gamignorePatterns []string) (Watcher, error) {
+	return nil, errors.New("native file notifications are not supported on this platform")
+}
@@ -0,0 +1,120 @@
+// Package retry runs outbound calls (hook commands, the Tier 2 validator)
+// with exponential backoff so transient failures don't abort processing.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os/exec"
+	"time"
+)
+
+// ExitTempFail is the exit status (sysexits EX_TEMPFAIL) an external command
+// uses to ask for a retry. Any other non-zero exit is treated as fatal.
+const ExitTempFail = 75
+
+// Policy controls how many times Do calls fn and how long it waits between
+// attempts.
+type Policy struct {
+	Attempts   int           // total calls including the first; below 1 means 1
+	Initial    time.Duration // delay before the first retry
+	Max        time.Duration // cap on any single delay; zero means no cap
+	Multiplier float64       // growth per retry; below 1 means 2
+	Jitter     float64       // fraction of each delay randomized away, 0 to 1
+}
+
+// DefaultPolicy is used for hook commands and the Tier 2 validator.
+var DefaultPolicy = Policy{
+	Attempts:   3,
+	Initial:    200 * time.Millisecond,
+	Max:        5 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// Backoff returns the delay before retry n (1 for the first retry). With
+// jitter j the delay is drawn from [d*(1-j), d], so the cap is never exceeded.
+func (p Policy) Backoff(n int) time.Duration {
+	mult := p.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+	d := float64(p.Initial)
+	for i := 1; i < n; i++ {
+		d *= mult
+		if p.Max > 0 && d >= float64(p.Max) {
+			break
+		}
+	}
+	if p.Max > 0 && d > float64(p.Max) {
+		d = float64(p.Max)
+	}
+	if j := min(max(p.Jitter, 0), 1); j > 0 {
+		d -= d * j * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as fatal so Do returns it without retrying.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Do calls fn until it succeeds, returns a Permanent error, or the policy's
+// attempts are used up, sleeping Backoff between calls. It stops early when
+// ctx is cancelled, returning ctx's error joined with the last failure. The
+// returned error is never wrapped in Permanent.
+func Do(ctx context.Context, p Policy, fn func(context.Context) error) error {
+	attempts := max(p.Attempts, 1)
+	var err error
+	for n := 1; ; n++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+		if n >= attempts {
+			if attempts > 1 {
+				return fmt.Errorf("after %d attempts: %w", attempts, err)
+			}
+			return err
+		}
+
+		timer := time.NewTimer(p.Backoff(n))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// Exec classifies an error from running an external command: a non-zero exit
+// other than ExitTempFail is Permanent; anything else (failing to start, a
+// timeout, a signal) stays retryable.
+func Exec(err error) error {
+	var exit *exec.ExitError
+	if errors.As(err, &exit) && exit.ExitCode() > 0 && exit.ExitCode() != ExitTempFail {
+		return Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestBackoffGrowsAndCaps(t *testing.T) {
+	p := Policy{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 3}
+	want := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := p.Backoff(i + 1); got != w {
+			t.Errorf("Backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+
+	// A multiplier below 1 falls back to doubling.
+	if got := (Policy{Initial: time.Second}).Backoff(3); got != 4*time.Second {
+		t.Errorf("default multiplier Backoff(3) = %s, want 4s", got)
+	}
+}
+
+func TestBackoffJitterStaysInRange(t *testing.T) {
+	p := Policy{Initial: time.Second, Max: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		d := p.Backoff(2)
+		if d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("jittered delay %s outside [500ms, 1s]", d)
+		}
+	}
+}
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), Policy{Attempts: 5, Initial: time.Millisecond}, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("Do = %v after %d calls, want nil after 3", err, calls)
+	}
+}
+
+func TestDoStopsOnPermanentAndExhaustion(t *testing.T) {
+	fatal := errors.New("bad config")
+	calls := 0
+	err := Do(context.Background(), Policy{Attempts: 5, Initial: time.Millisecond}, func(context.Context) error {
+		calls++
+		return Permanent(fatal)
+	})
+	if calls != 1 || err != fatal || IsPermanent(err) {
+		t.Errorf("permanent: calls=%d err=%v, want 1 call returning the unwrapped error", calls, err)
+	}
+
+	transient := errors.New("connection reset")
+	calls = 0
+	err = Do(context.Background(), Policy{Attempts: 3, Initial: time.Millisecond}, func(context.Context) error {
+		calls++
+		return transient
+	})
+	if calls != 3 || !errors.Is(err, transient) {
+		t.Errorf("exhausted: calls=%d err=%v, want 3 calls wrapping the last error", calls, err)
+	}
+}
+
+func TestDoStopsWhenContextCancelledMidRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	transient := errors.New("timeout")
+	calls := 0
+	start := time.Now()
+	err := Do(ctx, Policy{Attempts: 10, Initial: time.Hour}, func(context.Context) error {
+		calls++
+		time.AfterFunc(10*time.Millisecond, cancel)
+		return transient
+	})
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, transient) {
+		t.Errorf("err = %v, want context.Canceled joined with the last failure", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Do kept sleeping after cancellation")
+	}
+}
+
+func TestExecClassifiesExitStatus(t *testing.T) {
+	for _, tc := range []struct {
+		script    string
+		permanent bool
+	}{
+		{"exit 1", true},
+		{"exit 75", false},
+	} {
+		err := exec.Command("sh", "-c", tc.script).Run()
+		if got := IsPermanent(Exec(err)); got != tc.permanent {
+			t.Errorf("%s: permanent = %v, want %v", tc.script, got, tc.permanent)
+		}
+	}
+	if IsPermanent(Exec(exec.ErrNotFound)) {
+		t.Error("start failures should stay retryable")
+	}
+}
@@ -0,0 +1,44 @@
+// Package testdb provides the shared Postgres pool for tests that need a
+// database.
+package testdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sbenjam1n/gamsync/internal/db"
+)
+
+// Pool connects to GAM_TEST_DATABASE_URL and applies the repository's
+// migrations, skipping the test when the variable is unset. The pool is
+// closed when the test finishes.
+func Pool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv("GAM_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("GAM_TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := db.Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	if err := db.Migrate(ctx, pool, migrationsDir()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return pool
+}
+
+// migrationsDir returns the repository's migrations directory, found
+// relative to this file so callers in any package resolve the same path.
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations")
+}
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/retry"
+)
+
+// DefaultExternalTimeout bounds a Tier 2 command when no timeout is set.
+const DefaultExternalTimeout = 60 * time.Second
+
+// SetExternalCheck configures the Tier 2 command. It runs through sh in the
+// project root with the proposal JSON on stdin and must print a
+// ValidationResult as JSON on stdout. An empty command disables Tier 2;
+// a non-positive timeout means DefaultExternalTimeout. The timeout applies
+// to each attempt.
+func (v *Validator) SetExternalCheck(command string, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultExternalTimeout
+	}
+	v.externalCmd = command
+	v.externalTimeout = timeout
+}
+
+// Tier2External runs the configured external command against the proposal.
+// Each attempt is bounded by the configured timeout; timeouts and exits with
+// retry.ExitTempFail are retried with backoff. A command that still times
+// out, exits non-zero without a result, or prints unparseable output fails
+// the tier.
+func (v *Validator) Tier2External(ctx context.Context, p *gam.Proposal) *gam.ValidationResult {
+	result := &gam.ValidationResult{Tier: 2, Passed: true, Code: 0}
+	if v.externalCmd == "" {
+		return result
+	}
+
+	input, err := json.Marshal(p)
+	if err != nil {
+		return tier2Failure(result, "marshal proposal", "the proposal encodes as JSON", err.Error())
+	}
+
+	var ext *gam.ValidationResult
+	err = retry.Do(ctx, v.retry, func(ctx context.Context) error {
+		var err error
+		ext, err = v.runExternal(ctx, input)
+		return err
+	})
+	if err != nil {
+		var ce *externalCheckError
+		if errors.As(err, &ce) {
+			return tier2Failure(result, ce.check, ce.expected, ce.got)
+		}
+		return tier2Failure(result, "external check", externalOutputExpected, err.Error())
+	}
+
+	result.Passed = ext.Passed
+	result.Code = ext.Code
+	result.Message = ext.Message
+	result.Details = ext.Details
+	if !result.Passed {
+		if result.Code == 0 {
+			result.Code = 1
+		}
+		if result.Message == "" {
+			result.Message = "External check failed"
+		}
+	}
+	return result
+}
+
+// externalOutputExpected is what a Tier 2 command is expected to produce.
+const externalOutputExpected = "a ValidationResult as JSON on stdout"
+
+// externalCheckError describes one failed Tier 2 attempt as a detail entry.
+type externalCheckError struct {
+	check, expected, got string
+}
+
+func (e *externalCheckError) Error() string { return e.check + ": " + e.got }
+
+// runExternal makes a single Tier 2 attempt. Errors are marked
+// retry.Permanent unless the command timed out or asked for a retry.
+func (v *Validator) runExternal(ctx context.Context, input []byte) (*gam.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.externalTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", v.externalCmd)
+	cmd.Dir = v.projectRoot
+	cmd.WaitDelay = time.Second // don't wait on grandchildren still holding stdout
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, &externalCheckError{
+			check:    "external check timed out",
+			expected: fmt.Sprintf("a result within %s", v.externalTimeout),
+			got:      fmt.Sprintf("no result after %s (timed out)", v.externalTimeout),
+		}
+	}
+
+	var ext gam.ValidationResult
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &ext); err != nil {
+		got := strings.TrimSpace(stderr.String())
+		if runErr != nil {
+			got = strings.TrimSpace(runErr.Error() + ": " + got)
+		}
+		if got == "" {
+			got = "invalid JSON: " + err.Error()
+		}
+		ce := &externalCheckError{check: "external check output", expected: externalOutputExpected, got: got}
+		if runErr != nil {
+			var exit *exec.ExitError
+			if errors.As(runErr, &exit) && exit.ExitCode() == retry.ExitTempFail {
+				return nil, ce
+			}
+		}
+		return nil, retry.Permanent(ce)
+	}
+	return &ext, nil
+}
+
+func tier2Failure(result *gam.ValidationResult, check, expected, got string) *gam.ValidationResult {
+	result.Passed = false
+	result.Code = 1
+	result.Message = "External check failed: " + check
+	result.Details = append(result.Details, gam.ValidationDetail{
+		Check:    check,
+		Passed:   false,
+		Expected: expected,
+		Got:      got,
+		Fix:      "Check the command configured in GAM_TIER2_COMMAND",
+	})
+	return result
+}
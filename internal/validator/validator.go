@@ -4,29 +4,41 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sbenjam1n/gamsync/internal/gam"
 	"github.com/sbenjam1n/gamsync/internal/region"
+	"github.com/sbenjam1n/gamsync/internal/retry"
 )
 
-// Validator runs Tier 0 (structural) and Tier 1 (state machine + sync integrity) validation.
+// Validator runs Tier 0 (structural), Tier 1 (state machine + sync integrity),
+// and the optional Tier 2 (external command) validation.
 type Validator struct {
-	db          *pgxpool.Pool
-	projectRoot string
+	db              *pgxpool.Pool
+	projectRoot     string
+	externalCmd     string        // Tier 2 command; empty skips the tier
+	externalTimeout time.Duration // upper bound on each Tier 2 attempt
+	retry           retry.Policy  // Tier 2 retries on timeout or ExitTempFail
 }
 
 // New creates a new Validator.
 func New(db *pgxpool.Pool, projectRoot string) *Validator {
-	return &Validator{db: db, projectRoot: projectRoot}
+	return &Validator{db: db, projectRoot: projectRoot, externalTimeout: DefaultExternalTimeout, retry: retry.DefaultPolicy}
 }
 
-// Validate runs Tier 0 and Tier 1 validation on a proposal.
+// Validate runs Tier 0, Tier 1, and (when configured) Tier 2 validation on a
+// proposal. Each tier gates the next.
 func (v *Validator) Validate(ctx context.Context, p *gam.Proposal) (*gam.ValidationResult, error) {
 	if result := v.Tier0Structural(ctx, p); !result.Passed {
 		return result, nil
 	}
-	return v.Tier1StateMachine(ctx, p)
+	result, err := v.Tier1StateMachine(ctx, p)
+	if err != nil || !result.Passed || v.externalCmd == "" {
+		return result, err
+	}
+	return v.Tier2External(ctx, p), nil
 }
 
 // Tier0Structural performs structural checks: region exists, scope check, region markers present.
@@ -60,12 +72,13 @@ func (v *Validator) Tier0Structural(ctx context.Context, p *gam.Proposal) *gam.V
 		return result
 	}
 
-	// Check scope: is proposal region under the turn's declared scope?
-	if p.TurnID != "" {
+	// Check scope: is proposal region under the declared scope, or else the
+	// turn's? Without either the check is skipped.
+	if p.TurnID != "" || p.ScopePath != "" {
 		var inScope bool
 		err := v.db.QueryRow(ctx, `
-			SELECT $1::ltree <@ (SELECT scope_path FROM turns WHERE id = $2)
-		`, p.RegionPath, p.TurnID).Scan(&inScope)
+			SELECT $1::ltree <@ COALESCE(NULLIF($3, '')::ltree, (SELECT scope_path FROM turns WHERE id = $2))
+		`, p.RegionPath, p.TurnID, p.ScopePath).Scan(&inScope)
 		if err == nil && !inScope {
 			result.Passed = false
 			result.Code = 2
@@ -193,14 +206,19 @@ func (v *Validator) Tier1StateMachine(ctx context.Context, p *gam.Proposal) (*ga
 	return result, nil
 }
 
+// conceptsInScope joins the concept assignments on $1 and every ancestor
+// region; a region's concepts apply to its whole subtree.
+const conceptsInScope = `
+		FROM regions r
+		JOIN concept_region_assignments cra ON cra.region_id = r.id
+		JOIN concepts c ON c.id = cra.concept_id
+		WHERE r.path @> $1::ltree OR r.path = $1::ltree`
+
 // GetConceptsForRegion collects concepts via LTREE ancestor walk through the junction table.
 func (v *Validator) GetConceptsForRegion(ctx context.Context, path string) ([]gam.Concept, error) {
 	rows, err := v.db.Query(ctx, `
 		SELECT DISTINCT c.id, c.name, c.purpose, c.spec, c.state_machine, c.invariants
-		FROM regions r
-		JOIN concept_region_assignments cra ON cra.region_id = r.id
-		JOIN concepts c ON c.id = cra.concept_id
-		WHERE r.path @> $1::ltree OR r.path = $1::ltree
+		`+conceptsInScope+`
 		ORDER BY c.name
 	`, path)
 	if err != nil {
@@ -223,6 +241,146 @@ func (v *Validator) GetConceptsForRegion(ctx context.Context, path string) ([]ga
 	return concepts, nil
 }
 
+// RegionConcept is one concept assignment covering a region, either on the
+// region itself or inherited from an ancestor.
+type RegionConcept struct {
+	Concept    string `json:"concept"`
+	Role       string `json:"role"`
+	AssignedAt string `json:"assigned_at"`
+	Inherited  bool   `json:"inherited"`
+}
+
+// ConceptAssignments lists the concept assignments in scope for path through
+// the same ancestor walk as GetConceptsForRegion, nearest region first.
+func (v *Validator) ConceptAssignments(ctx context.Context, path string) ([]RegionConcept, error) {
+	rows, err := v.db.Query(ctx, `
+		SELECT c.name, cra.role, r.path::text
+		`+conceptsInScope+`
+		ORDER BY nlevel(r.path) DESC, c.name
+	`, path)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []RegionConcept
+	for rows.Next() {
+		var rc RegionConcept
+		if err := rows.Scan(&rc.Concept, &rc.Role, &rc.AssignedAt); err != nil {
+			return nil, err
+		}
+		rc.Inherited = rc.AssignedAt != path
+		out = append(out, rc)
+	}
+	return out, rows.Err()
+}
+
+// RegionsWithoutConcepts returns the non-deprecated regions that no concept
+// covers, directly or through an ancestor. GetConceptsForRegion is empty for
+// these, so Tier 1 has nothing to check and their proposals pass it unexamined.
+func (v *Validator) RegionsWithoutConcepts(ctx context.Context) ([]string, error) {
+	rows, err := v.db.Query(ctx, `
+		SELECT r.path::text FROM regions r
+		WHERE r.lifecycle_state != 'deprecated'
+		  AND NOT EXISTS (
+			SELECT 1 FROM regions a
+			JOIN concept_region_assignments cra ON cra.region_id = a.id
+			WHERE a.path @> r.path
+		  )
+		ORDER BY r.path
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
+
+// Explain describes what the validator enforces for regionPath: the concepts
+// in scope through the ancestor walk, with their legal transitions and
+// invariants.
+func (v *Validator) Explain(ctx context.Context, regionPath string) (string, error) {
+	concepts, err := v.GetConceptsForRegion(ctx, regionPath)
+	if err != nil {
+		return "", fmt.Errorf("concept lookup: %w", err)
+	}
+	return FormatExplanation(regionPath, concepts), nil
+}
+
+// FormatExplanation renders concepts' state machines and invariants as the
+// rules Tier 1 applies to proposals on regionPath.
+func FormatExplanation(regionPath string, concepts []gam.Concept) string {
+	if len(concepts) == 0 {
+		return fmt.Sprintf("No concepts in scope for %s; Tier 1 has no transitions or invariants to enforce.\n", regionPath)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Concepts in scope for %s:\n", regionPath)
+	for _, c := range concepts {
+		fmt.Fprintf(&sb, "\n  %s: %s\n", c.Name, c.Purpose)
+
+		sm := c.StateMachine
+		if len(sm.States) > 0 {
+			fmt.Fprintf(&sb, "    States: %s\n", strings.Join(sm.States, ", "))
+		}
+		if len(sm.Transitions) == 0 {
+			sb.WriteString("    Transitions: (none)\n")
+		} else {
+			sb.WriteString("    Legal transitions:\n")
+			for _, from := range transitionSources(sm) {
+				fmt.Fprintf(&sb, "      from %s: %s\n", from, legalTransitionsFrom(sm, from))
+			}
+		}
+
+		if len(c.Invariants) == 0 {
+			sb.WriteString("    Invariants: (none)\n")
+			continue
+		}
+		sb.WriteString("    Invariants:\n")
+		for _, inv := range c.Invariants {
+			line := fmt.Sprintf("      %s [%s]", inv.Name, inv.Type)
+			if inv.Rule != "" {
+				line += ": " + inv.Rule
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// transitionSources lists the states transitions start from, in declared
+// state order followed by any undeclared source states.
+func transitionSources(sm gam.StateMachine) []string {
+	hasFrom := make(map[string]bool)
+	for _, t := range sm.Transitions {
+		hasFrom[t.From] = true
+	}
+	var sources []string
+	seen := make(map[string]bool)
+	for _, st := range sm.States {
+		if hasFrom[st] && !seen[st] {
+			seen[st] = true
+			sources = append(sources, st)
+		}
+	}
+	for _, t := range sm.Transitions {
+		if !seen[t.From] {
+			seen[t.From] = true
+			sources = append(sources, t.From)
+		}
+	}
+	return sources
+}
+
 func isLegalTransition(sm gam.StateMachine, from, to, action string) bool {
 	for _, t := range sm.Transitions {
 		if t.From == from && t.To == to && t.Action == action {
@@ -256,6 +414,22 @@ func joinStrings(ss []string, sep string) string {
 	return result
 }
 
+// CheckEvidence runs every invariant of concepts against evidence the way
+// Tier 1 does, but without stopping at the first failure, so a Researcher can
+// see every missing or failing evidence block before submitting. Each
+// detail's Check is "Concept.invariant".
+func CheckEvidence(concepts []gam.Concept, evidence gam.ProposalEvidence) []gam.ValidationDetail {
+	var details []gam.ValidationDetail
+	for _, c := range concepts {
+		for _, inv := range c.Invariants {
+			detail := checkInvariant(inv, evidence)
+			detail.Check = c.Name + "." + detail.Check
+			details = append(details, detail)
+		}
+	}
+	return details
+}
+
 func checkInvariant(inv gam.Invariant, evidence gam.ProposalEvidence) gam.ValidationDetail {
 	detail := gam.ValidationDetail{Check: inv.Name, Passed: true}
 
@@ -314,72 +488,45 @@ func checkInvariant(inv gam.Invariant, evidence gam.ProposalEvidence) gam.Valida
 func (v *Validator) validateSyncRefs(ctx context.Context, sync gam.Synchronization) gam.ValidationDetail {
 	detail := gam.ValidationDetail{Check: "sync_refs_" + sync.Name, Passed: true}
 
-	// Check when clause: all referenced concept/action pairs must exist
-	for _, w := range sync.WhenClause {
+	// Every concept/action pair (when, then) and state field (where) must exist
+	for _, ref := range sync.Refs() {
 		var exists bool
-		v.db.QueryRow(ctx, `
-			SELECT EXISTS(
-				SELECT 1 FROM concepts c
-				WHERE c.name = $1
-				AND c.spec->'actions' ? $2
-			)
-		`, w.Concept, w.Action).Scan(&exists)
-
-		if !exists {
-			detail.Passed = false
-			detail.Expected = fmt.Sprintf("action %s/%s exists", w.Concept, w.Action)
-			detail.Got = "not found"
-			detail.Fix = fmt.Sprintf("Define action '%s' in concept '%s' spec, or fix the sync's when clause reference.", w.Action, w.Concept)
-			return detail
+		if ref.ClauseType == "where" {
+			v.db.QueryRow(ctx, `
+				SELECT EXISTS(
+					SELECT 1 FROM concepts c
+					WHERE c.name = $1
+					AND c.spec->'state' ? $2
+				)
+			`, ref.ConceptName, ref.StateField).Scan(&exists)
+
+			if !exists {
+				detail.Passed = false
+				detail.Expected = fmt.Sprintf("state field %s.%s exists", ref.ConceptName, ref.StateField)
+				detail.Got = "not found"
+				detail.Fix = fmt.Sprintf("Add state field '%s' to concept '%s' spec, or fix the sync's where clause.", ref.StateField, ref.ConceptName)
+				return detail
+			}
+			continue
 		}
-	}
 
-	// Check then clause similarly
-	for _, t := range sync.ThenClause {
-		var exists bool
 		v.db.QueryRow(ctx, `
 			SELECT EXISTS(
 				SELECT 1 FROM concepts c
 				WHERE c.name = $1
 				AND c.spec->'actions' ? $2
 			)
-		`, t.Concept, t.Action).Scan(&exists)
+		`, ref.ConceptName, ref.ActionName).Scan(&exists)
 
 		if !exists {
 			detail.Passed = false
-			detail.Expected = fmt.Sprintf("action %s/%s exists", t.Concept, t.Action)
+			detail.Expected = fmt.Sprintf("action %s/%s exists", ref.ConceptName, ref.ActionName)
 			detail.Got = "not found"
-			detail.Fix = fmt.Sprintf("Define action '%s' in concept '%s' spec, or fix the sync's then clause reference.", t.Action, t.Concept)
+			detail.Fix = fmt.Sprintf("Define action '%s' in concept '%s' spec, or fix the sync's %s clause reference.", ref.ActionName, ref.ConceptName, ref.ClauseType)
 			return detail
 		}
 	}
 
-	// Check where clause: referenced state fields must exist in concept specs
-	for _, w := range sync.WhereClause {
-		for _, patternVal := range w.Pattern {
-			if fields, ok := patternVal.(map[string]any); ok {
-				for fieldName := range fields {
-					var exists bool
-					v.db.QueryRow(ctx, `
-						SELECT EXISTS(
-							SELECT 1 FROM concepts c
-							WHERE c.name = $1
-							AND c.spec->'state' ? $2
-						)
-					`, w.Concept, fieldName).Scan(&exists)
-
-					if !exists {
-						detail.Passed = false
-						detail.Expected = fmt.Sprintf("state field %s.%s exists", w.Concept, fieldName)
-						detail.Got = "not found"
-						detail.Fix = fmt.Sprintf("Add state field '%s' to concept '%s' spec, or fix the sync's where clause.", fieldName, w.Concept)
-						return detail
-					}
-				}
-			}
-		}
-	}
-
 	return detail
 }
 
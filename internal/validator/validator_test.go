@@ -0,0 +1,292 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sbenjam1n/gamsync/internal/gam"
+	"github.com/sbenjam1n/gamsync/internal/testdb"
+)
+
+func TestFormatExplanation(t *testing.T) {
+	concept := gam.Concept{
+		Name:    "Search",
+		Purpose: "find documents",
+		StateMachine: gam.StateMachine{
+			States: []string{"idle", "running", "done"},
+			Transitions: []gam.Transition{
+				{From: "idle", To: "running", Action: "start"},
+				{From: "running", To: "done", Action: "finish"},
+				{From: "running", To: "idle", Action: "cancel"},
+			},
+		},
+		Invariants: []gam.Invariant{
+			{Name: "no_removed_exports", Type: "api", Rule: "exports are never removed"},
+		},
+	}
+
+	out := FormatExplanation("app.search", []gam.Concept{concept})
+	for _, want := range []string{
+		"Concepts in scope for app.search:",
+		"Search: find documents",
+		"States: idle, running, done",
+		"from idle: [idle->running via start]",
+		"from running: [running->done via finish, running->idle via cancel]",
+		"no_removed_exports [api]: exports are never removed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("explanation missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "from done") {
+		t.Errorf("terminal state should not list transitions:\n%s", out)
+	}
+
+	if empty := FormatExplanation("app.other", nil); !strings.Contains(empty, "No concepts in scope") {
+		t.Errorf("empty explanation = %q", empty)
+	}
+}
+
+func TestTransitionSourcesIncludesUndeclaredStates(t *testing.T) {
+	sm := gam.StateMachine{
+		States: []string{"b", "a"},
+		Transitions: []gam.Transition{
+			{From: "a", To: "b", Action: "x"},
+			{From: "ghost", To: "a", Action: "y"},
+			{From: "b", To: "a", Action: "z"},
+		},
+	}
+	got := strings.Join(transitionSources(sm), ",")
+	if got != "b,a,ghost" {
+		t.Errorf("transitionSources = %s, want b,a,ghost", got)
+	}
+}
+
+func TestTier0ScopeCheck(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	for _, path := range []string{"scopetest.inside", "scopetest.outside"} {
+		pool.Exec(ctx, `INSERT INTO regions (path) VALUES ($1) ON CONFLICT (path) DO NOTHING`, path)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'scopetest'`)
+	pool.Exec(ctx, `INSERT INTO turns (id, agent_role, scope_path, status) VALUES ('scopetest-turn', 'implementer', 'scopetest.inside', 'COMPLETED')`)
+	defer pool.Exec(ctx, `DELETE FROM turns WHERE id = 'scopetest-turn'`)
+
+	v := New(pool, t.TempDir())
+
+	unscoped := v.Tier0Structural(ctx, &gam.Proposal{RegionPath: "scopetest.outside"})
+	if !unscoped.Passed {
+		t.Errorf("unscoped check should skip scope: %+v", unscoped)
+	}
+
+	for _, p := range []*gam.Proposal{
+		{RegionPath: "scopetest.outside", ScopePath: "scopetest.inside"},
+		{RegionPath: "scopetest.outside", TurnID: "scopetest-turn"},
+	} {
+		if r := v.Tier0Structural(ctx, p); r.Passed || r.Code != 2 {
+			t.Errorf("scoped %+v: got passed=%v code=%d, want scope failure (code 2)", p, r.Passed, r.Code)
+		}
+	}
+
+	if r := v.Tier0Structural(ctx, &gam.Proposal{RegionPath: "scopetest.inside", ScopePath: "scopetest"}); !r.Passed {
+		t.Errorf("in-scope region failed: %+v", r)
+	}
+}
+
+func TestTier2ExternalFailingResult(t *testing.T) {
+	v := New(nil, t.TempDir())
+	v.SetExternalCheck(`cat > proposal.json; echo '{"passed":false,"code":3,"message":"lint failed","details":[{"check":"golint","passed":false,"got":"2 issues"}]}'`, 5*time.Second)
+
+	p := &gam.Proposal{ID: "p1", RegionPath: "app.search"}
+	result := v.Tier2External(context.Background(), p)
+	if result.Tier != 2 || result.Passed {
+		t.Fatalf("expected failing tier 2 result, got %+v", result)
+	}
+	if result.Code != 3 || result.Message != "lint failed" {
+		t.Errorf("code/message = %d %q, want 3 \"lint failed\"", result.Code, result.Message)
+	}
+	if len(result.Details) != 1 || result.Details[0].Check != "golint" {
+		t.Errorf("details = %+v", result.Details)
+	}
+
+	data, err := os.ReadFile(filepath.Join(v.projectRoot, "proposal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"region_path":"app.search"`) {
+		t.Errorf("command stdin missing proposal JSON: %s", data)
+	}
+}
+
+func TestTier2ExternalSkippedAndBounded(t *testing.T) {
+	v := New(nil, t.TempDir())
+	if result := v.Tier2External(context.Background(), &gam.Proposal{}); !result.Passed {
+		t.Errorf("unconfigured tier 2 should pass, got %+v", result)
+	}
+
+	v.retry.Attempts = 1
+	v.SetExternalCheck("sleep 5", 100*time.Millisecond)
+	result := v.Tier2External(context.Background(), &gam.Proposal{})
+	if result.Passed || len(result.Details) != 1 || result.Details[0].Check != "external check timed out" {
+		t.Errorf("expected timeout failure, got %+v", result)
+	} else if d := result.Details[0]; d.Got != "no result after 100ms (timed out)" || d.Expected != "a result within 100ms" {
+		t.Errorf("timeout detail expected=%q got=%q", d.Expected, d.Got)
+	}
+
+	v.SetExternalCheck("echo not json", 0)
+	if result := v.Tier2External(context.Background(), &gam.Proposal{}); result.Passed {
+		t.Errorf("unparseable output should fail, got %+v", result)
+	}
+}
+
+func TestTier2ExternalRetriesTempFail(t *testing.T) {
+	v := New(nil, t.TempDir())
+	v.retry.Initial = time.Millisecond
+	// Fails with EX_TEMPFAIL on the first call, then passes.
+	v.SetExternalCheck(`if [ ! -f tried ]; then touch tried; exit 75; fi; echo '{"passed":true}'`, 5*time.Second)
+
+	if result := v.Tier2External(context.Background(), &gam.Proposal{}); !result.Passed {
+		t.Fatalf("expected retry to pass, got %+v", result)
+	}
+}
+
+func TestConceptAssignmentsInherited(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	for _, path := range []string{"inherittest", "inherittest.child"} {
+		pool.Exec(ctx, `INSERT INTO regions (path) VALUES ($1) ON CONFLICT (path) DO NOTHING`, path)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'inherittest'`)
+	for _, name := range []string{"InheritParent", "InheritChild"} {
+		pool.Exec(ctx, `INSERT INTO concepts (name, purpose, spec, state_machine) VALUES ($1, 'test', '{}', '{}') ON CONFLICT (name) DO NOTHING`, name)
+	}
+	defer pool.Exec(ctx, `DELETE FROM concepts WHERE name IN ('InheritParent', 'InheritChild')`)
+	for _, a := range []struct{ concept, path, role string }{
+		{"InheritParent", "inherittest", "implementation"},
+		{"InheritChild", "inherittest.child", "interface"},
+	} {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO concept_region_assignments (concept_id, region_id, role)
+			SELECT c.id, r.id, $3 FROM concepts c, regions r WHERE c.name = $1 AND r.path = $2::ltree
+		`, a.concept, a.path, a.role); err != nil {
+			t.Fatalf("assign %s: %v", a.concept, err)
+		}
+	}
+
+	got, err := New(pool, t.TempDir()).ConceptAssignments(ctx, "inherittest.child")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RegionConcept{
+		{Concept: "InheritChild", Role: "interface", AssignedAt: "inherittest.child", Inherited: false},
+		{Concept: "InheritParent", Role: "implementation", AssignedAt: "inherittest", Inherited: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("assignments = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("assignment %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRegionsWithoutConcepts(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	for _, path := range []string{"coveragetest.covered", "coveragetest.covered.child", "coveragetest.bare"} {
+		pool.Exec(ctx, `INSERT INTO regions (path) VALUES ($1) ON CONFLICT (path) DO NOTHING`, path)
+	}
+	defer pool.Exec(ctx, `DELETE FROM regions WHERE path <@ 'coveragetest'`)
+	pool.Exec(ctx, `INSERT INTO concepts (name, purpose, spec, state_machine) VALUES ('CoverageTest', 'test', '{}', '{}') ON CONFLICT (name) DO NOTHING`)
+	defer pool.Exec(ctx, `DELETE FROM concepts WHERE name = 'CoverageTest'`)
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO concept_region_assignments (concept_id, region_id)
+		SELECT c.id, r.id FROM concepts c, regions r WHERE c.name = 'CoverageTest' AND r.path = 'coveragetest.covered'
+	`); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	paths, err := New(pool, t.TempDir()).RegionsWithoutConcepts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flagged := make(map[string]bool)
+	for _, p := range paths {
+		flagged[p] = true
+	}
+	if !flagged["coveragetest.bare"] {
+		t.Errorf("concept-less region not flagged: %v", paths)
+	}
+	if flagged["coveragetest.covered"] || flagged["coveragetest.covered.child"] {
+		t.Errorf("covered or inheriting region flagged: %v", paths)
+	}
+}
+
+func TestCheckEvidenceMissingAPIAnalysis(t *testing.T) {
+	concepts := []gam.Concept{{
+		Name: "Search",
+		Invariants: []gam.Invariant{
+			{Name: "no_removed_exports", Type: "api", Config: map[string]any{"no_removals": true}},
+			{Name: "deps_pinned", Type: "dependency"},
+		},
+	}}
+
+	details := CheckEvidence(concepts, gam.ProposalEvidence{})
+	if len(details) != 2 {
+		t.Fatalf("details = %+v, want one per invariant", details)
+	}
+	api := details[0]
+	if api.Check != "Search.no_removed_exports" || api.Passed || api.Got != "missing" || !strings.Contains(api.Expected, "APIAnalysis") {
+		t.Errorf("api check = %+v, want a missing APIAnalysis failure", api)
+	}
+	if !details[1].Passed {
+		t.Errorf("dependency check without a requirement should pass: %+v", details[1])
+	}
+
+	details = CheckEvidence(concepts, gam.ProposalEvidence{APIAnalysis: &gam.APIAnalysis{}})
+	if !details[0].Passed {
+		t.Errorf("api check with evidence = %+v, want pass", details[0])
+	}
+}
+
+func TestValidateSyncRefsForeignFilterVariable(t *testing.T) {
+	pool := testdb.Pool(t)
+	ctx := context.Background()
+
+	for _, c := range []struct{ name, spec string }{
+		{"RefsWeb", `{"actions": {"request": {}}}`},
+		{"RefsUser", `{"state": {"id": "string", "active": "bool"}, "actions": {"notify": {}}}`},
+	} {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO concepts (name, purpose, spec, state_machine) VALUES ($1, 'test', $2, '{}')
+			ON CONFLICT (name) DO UPDATE SET spec = $2
+		`, c.name, c.spec); err != nil {
+			t.Fatalf("insert concept %s: %v", c.name, err)
+		}
+	}
+	defer pool.Exec(ctx, `DELETE FROM concepts WHERE name IN ('RefsWeb', 'RefsUser')`)
+
+	sync := gam.Synchronization{
+		Name:       "NotifyRequester",
+		WhenClause: []gam.WhenPattern{{Concept: "RefsWeb", Action: "request"}},
+		WhereClause: []gam.WherePattern{{
+			Concept: "RefsUser",
+			Pattern: map[string]any{"?u": map[string]any{"active": true}},
+			Filter:  "?req.user == ?u.id",
+		}},
+		ThenClause: []gam.ThenAction{{Concept: "RefsUser", Action: "notify"}},
+	}
+
+	detail := New(pool, t.TempDir()).validateSyncRefs(ctx, sync)
+	if !detail.Passed {
+		t.Errorf("validateSyncRefs rejected a filter on another pattern's variable: %+v", detail)
+	}
+}
@@ -0,0 +1,2 @@
+-- Archived plans are kept for reference but hidden from default listings.
+ALTER TYPE plan_status ADD VALUE IF NOT EXISTS 'ARCHIVED';
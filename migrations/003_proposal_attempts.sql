@@ -0,0 +1,3 @@
+-- Count validation attempts so gam proposal retry can re-queue a rejected
+-- proposal under a fresh idempotency key.
+ALTER TABLE proposals ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+-- Keep the full ValidationResult of the latest validation so agents can read
+-- it back with gam proposal result --json.
+ALTER TABLE proposals ADD COLUMN IF NOT EXISTS validation_result JSONB;
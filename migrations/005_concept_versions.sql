@@ -0,0 +1,13 @@
+-- Prior definitions of each concept, snapshotted by UpsertConcept whenever an
+-- update changes it. Version 1 is the oldest superseded definition.
+CREATE TABLE IF NOT EXISTS concept_versions (
+  concept_name  VARCHAR(255) NOT NULL,
+  version       INT NOT NULL,
+  purpose       TEXT NOT NULL,
+  spec          JSONB NOT NULL,
+  state_machine JSONB NOT NULL,
+  invariants    JSONB NOT NULL DEFAULT '[]',
+  valid_from    TIMESTAMPTZ,
+  superseded_at TIMESTAMPTZ DEFAULT NOW(),
+  PRIMARY KEY (concept_name, version)
+);
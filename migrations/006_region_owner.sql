@@ -0,0 +1,3 @@
+-- Owning team for a region, inherited by descendants without their own owner.
+-- gam export owners turns these into a CODEOWNERS-style file.
+ALTER TABLE regions ADD COLUMN IF NOT EXISTS owner VARCHAR(255);
@@ -0,0 +1,13 @@
+-- Audit trail for synchronization changes: who added, updated, deleted,
+-- enabled or disabled a sync, and when. No foreign key to synchronizations so
+-- the history outlives a deleted sync.
+CREATE TABLE IF NOT EXISTS sync_audit (
+  id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  sync_name  VARCHAR(255) NOT NULL,
+  action     VARCHAR(50) NOT NULL,
+  actor      VARCHAR(255) NOT NULL,
+  detail     TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_audit_name ON sync_audit(sync_name, created_at);
@@ -0,0 +1,8 @@
+-- Structured escalation: a category for triage (gam queue escalated
+-- --category) and the facts behind it. rejection_reason keeps the readable
+-- ESCALATED: ... text.
+ALTER TABLE proposals ADD COLUMN IF NOT EXISTS escalation_category VARCHAR(50);
+ALTER TABLE proposals ADD COLUMN IF NOT EXISTS escalation_details JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_proposals_escalation ON proposals(escalation_category)
+  WHERE escalation_category IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Golden principle severity: warn findings are reported, block findings fail
+-- gam turn end.
+ALTER TABLE golden_principles ADD COLUMN IF NOT EXISTS severity VARCHAR(10) NOT NULL DEFAULT 'warn'
+  CHECK (severity IN ('warn', 'block'));
@@ -0,0 +1,11 @@
+// Package skills embeds the canonical agent skill prompts so that gam can
+// scaffold them into new projects and serve them when no skills/ directory
+// exists on disk.
+package skills
+
+import "embed"
+
+// FS holds the default skill markdown files.
+//
+//go:embed *.md
+var FS embed.FS